@@ -7,6 +7,7 @@ require (
 	github.com/edgexfoundry/go-mod-bootstrap/v4 v4.1.0-dev.68
 	github.com/edgexfoundry/go-mod-core-contracts/v4 v4.1.0-dev.36
 	github.com/edgexfoundry/go-mod-messaging/v4 v4.1.0-dev.26
+	github.com/fsnotify/fsnotify v1.9.0
 	github.com/google/uuid v1.6.0
 	github.com/hashicorp/go-multierror v1.1.1
 	github.com/labstack/echo/v4 v4.15.2
@@ -27,7 +28,6 @@ require (
 	github.com/edgexfoundry/go-mod-registry/v4 v4.1.0-dev.10 // indirect
 	github.com/edgexfoundry/go-mod-secrets/v4 v4.1.0-dev.15 // indirect
 	github.com/emirpasic/gods v1.18.1 // indirect
-	github.com/fsnotify/fsnotify v1.9.0 // indirect
 	github.com/fullsailor/pkcs7 v0.0.0-20190404230743-d7302db945fa // indirect
 	github.com/fxamacker/cbor/v2 v2.9.2 // indirect
 	github.com/gabriel-vasile/mimetype v1.4.13 // indirect
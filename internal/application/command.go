@@ -1,6 +1,6 @@
 // -*- Mode: Go; indent-tabs-mode: t -*-
 //
-// Copyright (C) 2020-2025 IOTech Ltd
+// Copyright (C) 2020-2026 IOTech Ltd
 //
 // SPDX-License-Identifier: Apache-2.0
 
@@ -34,13 +34,17 @@ import (
 	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
 )
 
-func GetCommand(ctx context.Context, deviceName string, commandName string, queryParams string, regexCmd bool, dic *di.Container) (res *dtos.Event, err errors.EdgeX) {
+func GetCommand(ctx context.Context, deviceName string, commandName string, queryParams string, regexCmd bool, targetUnits string, dic *di.Container) (res *dtos.Event, err errors.EdgeX) {
 	if deviceName == "" {
 		return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, "device name is empty", nil)
 	}
 	if commandName == "" {
 		return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, "command is empty", nil)
 	}
+	units, err := transformer.ParseTargetUnits(targetUnits)
+	if err != nil {
+		return nil, errors.NewCommonEdgeXWrapper(err)
+	}
 	var device models.Device
 	defer func() {
 		if err != nil {
@@ -57,11 +61,11 @@ func GetCommand(ctx context.Context, deviceName string, commandName string, quer
 
 	_, cmdExist := cache.Profiles().DeviceCommand(device.ProfileName, commandName)
 	if cmdExist {
-		res, err = readDeviceCommand(device, commandName, queryParams, dic)
+		res, err = readDeviceCommand(device, commandName, queryParams, units, dic)
 	} else if regexCmd {
-		res, err = readDeviceResourcesRegex(device, commandName, queryParams, dic)
+		res, err = readDeviceResourcesRegex(device, commandName, queryParams, units, dic)
 	} else {
-		res, err = readDeviceResource(device, commandName, queryParams, dic)
+		res, err = readDeviceResource(device, commandName, queryParams, units, dic)
 	}
 
 	if err != nil {
@@ -114,7 +118,7 @@ func SetCommand(ctx context.Context, deviceName string, commandName string, quer
 	return event, nil
 }
 
-func readDeviceResource(device models.Device, resourceName string, attributes string, dic *di.Container) (*dtos.Event, errors.EdgeX) {
+func readDeviceResource(device models.Device, resourceName string, attributes string, targetUnits []string, dic *di.Container) (*dtos.Event, errors.EdgeX) {
 	dr, ok := cache.Profiles().DeviceResource(device.ProfileName, resourceName)
 	if !ok {
 		errMsg := fmt.Sprintf("DeviceResource %s not found", resourceName)
@@ -151,7 +155,7 @@ func readDeviceResource(device models.Device, resourceName string, attributes st
 
 	// convert CommandValue to Event
 	configuration := container.ConfigurationFrom(dic.Get)
-	event, err := transformer.CommandValuesToEventDTO(results, device.Name, dr.Name, configuration.Device.DataTransform, dic)
+	event, err := transformer.CommandValuesToEventDTO(results, device.Name, dr.Name, configuration.Device.DataTransform, targetUnits, dic)
 	if err != nil {
 		return nil, errors.NewCommonEdgeX(errors.KindServerError, "failed to convert CommandValue to Event", err)
 	}
@@ -159,7 +163,7 @@ func readDeviceResource(device models.Device, resourceName string, attributes st
 	return event, nil
 }
 
-func readDeviceResourcesRegex(device models.Device, regexResourceName string, attributes string, dic *di.Container) (*dtos.Event, errors.EdgeX) {
+func readDeviceResourcesRegex(device models.Device, regexResourceName string, attributes string, targetUnits []string, dic *di.Container) (*dtos.Event, errors.EdgeX) {
 	regex, err := regexp.CompilePOSIX(regexResourceName)
 	if err != nil {
 		return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, "failed to CompilePOSIX resource name", err)
@@ -210,7 +214,7 @@ func readDeviceResourcesRegex(device models.Device, regexResourceName string, at
 
 	// convert CommandValue to Event
 	configuration := container.ConfigurationFrom(dic.Get)
-	event, err := transformer.CommandValuesToEventDTO(results, device.Name, regexResourceName, configuration.Device.DataTransform, dic)
+	event, err := transformer.CommandValuesToEventDTO(results, device.Name, regexResourceName, configuration.Device.DataTransform, targetUnits, dic)
 	if err != nil {
 		return nil, errors.NewCommonEdgeX(errors.KindServerError, "failed to convert CommandValue to Event", err)
 	}
@@ -218,7 +222,7 @@ func readDeviceResourcesRegex(device models.Device, regexResourceName string, at
 	return event, nil
 }
 
-func readDeviceCommand(device models.Device, commandName string, attributes string, dic *di.Container) (*dtos.Event, errors.EdgeX) {
+func readDeviceCommand(device models.Device, commandName string, attributes string, targetUnits []string, dic *di.Container) (*dtos.Event, errors.EdgeX) {
 	dc, ok := cache.Profiles().DeviceCommand(device.ProfileName, commandName)
 	if !ok {
 		errMsg := fmt.Sprintf("DeviceCommand %s not found", commandName)
@@ -267,7 +271,7 @@ func readDeviceCommand(device models.Device, commandName string, attributes stri
 	}
 
 	// convert CommandValue to Event
-	event, err := transformer.CommandValuesToEventDTO(results, device.Name, dc.Name, configuration.Device.DataTransform, dic)
+	event, err := transformer.CommandValuesToEventDTO(results, device.Name, dc.Name, configuration.Device.DataTransform, targetUnits, dic)
 	if err != nil {
 		return nil, errors.NewCommonEdgeX(errors.KindServerError, "failed to transform CommandValue to Event", err)
 	}
@@ -314,16 +318,16 @@ func writeDeviceResource(device models.Device, resourceName string, attributes s
 		}
 		reqs[0].Attributes[sdkCommon.URLRawQuery] = attributes
 	}
-	reqs[0].Type = cv.Type
 
 	// transform write value
 	configuration := container.ConfigurationFrom(dic.Get)
 	if configuration.Device.DataTransform {
-		edgexErr = transformer.TransformWriteParameter(cv, dr.Properties)
+		edgexErr = transformWriteParameter(cv, device, dr, dic)
 		if edgexErr != nil {
-			return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, "failed to transform set parameter", edgexErr)
+			return nil, errors.NewCommonEdgeXWrapper(edgexErr)
 		}
 	}
+	reqs[0].Type = cv.Type
 
 	// execute protocol-specific write operation
 	driver := container.ProtocolDriverFrom(dic.Get)
@@ -335,7 +339,7 @@ func writeDeviceResource(device models.Device, resourceName string, attributes s
 
 	// Updated resource value will be published to MessageBus as long as it's not write-only
 	if dr.Properties.ReadWrite != common.ReadWrite_W {
-		return transformer.CommandValuesToEventDTO([]*sdkModels.CommandValue{cv}, device.Name, resourceName, configuration.Device.DataTransform, dic)
+		return transformer.CommandValuesToEventDTO([]*sdkModels.CommandValue{cv}, device.Name, resourceName, configuration.Device.DataTransform, nil, dic)
 	}
 
 	return nil, nil
@@ -385,13 +389,13 @@ func writeDeviceCommand(device models.Device, commandName string, attributes str
 
 		// ResourceOperation mapping, notice that the order is opposite to get command mapping
 		// i.e. the mapping value is actually the key for set command.
-		if len(ro.Mappings) > 0 {
-			for k, v := range ro.Mappings {
-				if v == value {
-					value = k
-					break
-				}
+		if label, ok := value.(string); ok && len(ro.Mappings) > 0 {
+			raw, err := transformer.ReverseMapping(label, device.ProfileName, dc.Name, ro)
+			if err != nil {
+				errMsg := fmt.Sprintf("failed to reverse the mapping of DeviceResource %s", dr.Name)
+				return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, errMsg, err)
 			}
+			value = raw
 		}
 
 		// create CommandValue
@@ -416,15 +420,15 @@ func writeDeviceCommand(device models.Device, commandName string, attributes str
 			}
 			reqs[i].Attributes[sdkCommon.URLRawQuery] = attributes
 		}
-		reqs[i].Type = cv.Type
 
 		// transform write value
 		if configuration.Device.DataTransform {
-			err := transformer.TransformWriteParameter(cv, dr.Properties)
+			err := transformWriteParameter(cv, device, dr, dic)
 			if err != nil {
-				return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, "failed to transform set parameter", err)
+				return nil, errors.NewCommonEdgeXWrapper(err)
 			}
 		}
+		reqs[i].Type = cv.Type
 	}
 
 	// execute protocol-specific write operation
@@ -437,7 +441,7 @@ func writeDeviceCommand(device models.Device, commandName string, attributes str
 
 	// Updated resource(s) value will be published to MessageBus as long as they're not write-only
 	if dc.ReadWrite != common.ReadWrite_W {
-		return transformer.CommandValuesToEventDTO(cvs, device.Name, commandName, configuration.Device.DataTransform, dic)
+		return transformer.CommandValuesToEventDTO(cvs, device.Name, commandName, configuration.Device.DataTransform, nil, dic)
 	}
 
 	return nil, nil
@@ -482,6 +486,28 @@ func validateServiceAndDeviceState(deviceName string, dic *di.Container) (models
 	return device, nil
 }
 
+// transformWriteParameter performs the incoming data transformations on the set parameter, the custom transformers
+// run in the reverse order of the reading, i.e. the post-transformers first and the pre-transformers last.
+func transformWriteParameter(cv *sdkModels.CommandValue, device models.Device, dr models.DeviceResource, dic *di.Container) errors.EdgeX {
+	err := transformer.TransformWriteCustom(cv, dr, transformer.PostTransformersAttribute, dic)
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, "failed to transform set parameter", err)
+	}
+	calibration, err := transformer.CalibrationFor(device, dr)
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, "failed to load calibration table", err)
+	}
+	err = transformer.TransformWriteParameter(cv, dr.Properties, calibration)
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, "failed to transform set parameter", err)
+	}
+	err = transformer.TransformWriteCustom(cv, dr, transformer.PreTransformersAttribute, dic)
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, "failed to transform set parameter", err)
+	}
+	return nil
+}
+
 func createCommandValueFromDeviceResource(dr models.DeviceResource, value interface{}) (*sdkModels.CommandValue, errors.EdgeX) {
 	if value == nil {
 		return &sdkModels.CommandValue{
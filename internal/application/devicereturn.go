@@ -52,7 +52,7 @@ func deviceReturn(deviceName string, dic *di.Container) {
 			if dr.Properties.ReadWrite == common.ReadWrite_R ||
 				dr.Properties.ReadWrite == common.ReadWrite_RW ||
 				dr.Properties.ReadWrite == common.ReadWrite_WR {
-				_, err := GetCommand(context.Background(), deviceName, dr.Name, "", true, dic)
+				_, err := GetCommand(context.Background(), deviceName, dr.Name, "", true, "", dic)
 				if err == nil {
 					lc.Infof("Device %s responsive: setting operational state to up.", deviceName)
 					sdkCommon.UpdateOperatingState(deviceName, models.Up, lc, dc)
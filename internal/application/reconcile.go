@@ -0,0 +1,147 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v4/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v4/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/dtos/requests"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
+
+	"github.com/edgexfoundry/device-sdk-go/v4/internal/cache"
+	sdkCommon "github.com/edgexfoundry/device-sdk-go/v4/internal/common"
+	"github.com/edgexfoundry/device-sdk-go/v4/internal/container"
+)
+
+// Reconcile synchronizes the device, profile and provision watcher caches with core-metadata. The differences are
+// applied through the same paths as the metadata system events, so the ProtocolDriver is notified of the changes.
+// Returns the number of differences corrected.
+func Reconcile(instanceName string, baseServiceName string, dic *di.Container) (int, errors.EdgeX) {
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+
+	devices, profiles, pws, err := cache.FetchMetadata(instanceName, baseServiceName, dic)
+	if err != nil {
+		return 0, errors.NewCommonEdgeX(errors.KindServerError, "failed to retrieve metadata for reconciliation", err)
+	}
+
+	corrected := 0
+	failed := 0
+	apply := func(drift string, err errors.EdgeX) {
+		if err != nil {
+			lc.Errorf("failed to reconcile %s: %v", drift, err)
+			failed++
+			return
+		}
+		lc.Infof("reconciled %s", drift)
+		sdkCommon.IncMetadataDriftsCorrected()
+		corrected++
+	}
+
+	profileNames := make(map[string]struct{}, len(profiles))
+	for _, p := range profiles {
+		if _, ok := profileNames[p.Name]; ok {
+			continue
+		}
+		profileNames[p.Name] = struct{}{}
+		cached, ok := cache.Profiles().ForName(p.Name)
+		if !ok {
+			apply(fmt.Sprintf("profile %s missing in cache", p.Name), cache.Profiles().Add(p))
+		} else if !sdkCommon.EquivalentJSON(dtos.FromDeviceProfileModelToDTO(cached), dtos.FromDeviceProfileModelToDTO(p)) {
+			req := requests.NewDeviceProfileRequest(dtos.FromDeviceProfileModelToDTO(p))
+			apply(fmt.Sprintf("profile %s changed in metadata", p.Name), UpdateProfile(req, dic))
+		}
+	}
+
+	deviceNames := make(map[string]struct{}, len(devices))
+	for _, d := range devices {
+		deviceNames[d.Name] = struct{}{}
+		cached, ok := cache.Devices().ForName(d.Name)
+		if !ok {
+			req := requests.NewAddDeviceRequest(dtos.FromDeviceModelToDTO(d))
+			apply(fmt.Sprintf("device %s missing in cache", d.Name), AddDevice(req, dic))
+		} else if !sdkCommon.EquivalentJSON(dtos.FromDeviceModelToDTO(cached), dtos.FromDeviceModelToDTO(d)) {
+			req := requests.NewUpdateDeviceRequest(dtos.FromDeviceModelToUpdateDTO(d))
+			apply(fmt.Sprintf("device %s changed in metadata", d.Name), UpdateDevice(req, dic))
+		}
+	}
+	for _, d := range cache.Devices().All() {
+		if _, ok := deviceNames[d.Name]; !ok {
+			apply(fmt.Sprintf("device %s removed from metadata", d.Name), DeleteDevice(d.Name, dic))
+		}
+	}
+	for _, p := range cache.Profiles().All() {
+		if _, ok := profileNames[p.Name]; !ok && cache.CheckProfileNotUsed(p.Name) {
+			apply(fmt.Sprintf("profile %s no longer used", p.Name), DeleteProfile(p.Name, dic))
+		}
+	}
+
+	pwNames := make(map[string]struct{}, len(pws))
+	for _, pw := range pws {
+		pwNames[pw.Name] = struct{}{}
+		cached, ok := cache.ProvisionWatchers().ForName(pw.Name)
+		if !ok {
+			req := requests.NewAddProvisionWatcherRequest(dtos.FromProvisionWatcherModelToDTO(pw))
+			apply(fmt.Sprintf("provision watcher %s missing in cache", pw.Name), AddProvisionWatcher(req, dic))
+		} else if !sdkCommon.EquivalentJSON(dtos.FromProvisionWatcherModelToDTO(cached), dtos.FromProvisionWatcherModelToDTO(pw)) {
+			req := requests.NewUpdateProvisionWatcherRequest(dtos.FromProvisionWatcherModelToUpdateDTO(pw))
+			apply(fmt.Sprintf("provision watcher %s changed in metadata", pw.Name), UpdateProvisionWatcher(req, dic))
+		}
+	}
+	for _, pw := range cache.ProvisionWatchers().All() {
+		if _, ok := pwNames[pw.Name]; !ok {
+			apply(fmt.Sprintf("provision watcher %s removed from metadata", pw.Name), DeleteProvisionWatcher(pw.Name, dic))
+		}
+	}
+
+	if failed > 0 {
+		errMsg := fmt.Sprintf("failed to reconcile %d differences with metadata", failed)
+		return corrected, errors.NewCommonEdgeX(errors.KindServerError, errMsg, nil)
+	}
+	return corrected, nil
+}
+
+// StartReconciler reconciles the caches with core-metadata periodically by the Device.Reconciliation.Interval, so the
+// caches do not drift from core-metadata when a metadata system event is missed
+func StartReconciler(ctx context.Context, wg *sync.WaitGroup, instanceName string, baseServiceName string, dic *di.Container) {
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	configuration := container.ConfigurationFrom(dic.Get)
+
+	interval, err := time.ParseDuration(configuration.Device.Reconciliation.Interval)
+	if err != nil || interval <= 0 {
+		lc.Errorf("Reconciliation stopped: interval %s error in configuration: %v", configuration.Device.Reconciliation.Interval, err)
+		return
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				corrected, err := Reconcile(instanceName, baseServiceName, dic)
+				if err != nil {
+					lc.Errorf("failed to reconcile the caches with metadata: %v", err)
+				}
+				if corrected > 0 {
+					lc.Warnf("%d differences between the caches and metadata corrected by the reconciliation", corrected)
+				} else {
+					lc.Debug("the caches are consistent with metadata")
+				}
+			}
+		}
+	}()
+}
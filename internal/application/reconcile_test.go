@@ -0,0 +1,139 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package application
+
+import (
+	"testing"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v4/bootstrap/container"
+	bootstrapMocks "github.com/edgexfoundry/go-mod-bootstrap/v4/bootstrap/interfaces/mocks"
+	"github.com/edgexfoundry/go-mod-bootstrap/v4/di"
+	clientMocks "github.com/edgexfoundry/go-mod-core-contracts/v4/clients/interfaces/mocks"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/dtos/responses"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/device-sdk-go/v4/internal/cache"
+	"github.com/edgexfoundry/device-sdk-go/v4/internal/config"
+	"github.com/edgexfoundry/device-sdk-go/v4/internal/container"
+	"github.com/edgexfoundry/device-sdk-go/v4/pkg/interfaces/mocks"
+)
+
+const (
+	testServiceName = "test-service"
+	testProfileName = "test-profile"
+)
+
+func testDeviceDTO(name string, labels ...string) dtos.Device {
+	return dtos.Device{
+		Name:        name,
+		ServiceName: testServiceName,
+		ProfileName: testProfileName,
+		AdminState:  models.Unlocked,
+		Labels:      labels,
+		Protocols:   map[string]dtos.ProtocolProperties{"other": {"Address": name}},
+	}
+}
+
+func testProvisionWatcherDTO(name string) dtos.ProvisionWatcher {
+	return dtos.ProvisionWatcher{
+		Name:        name,
+		ServiceName: testServiceName,
+		AdminState:  models.Unlocked,
+		DiscoveredDevice: dtos.DiscoveredDevice{
+			ProfileName: testProfileName,
+			AdminState:  models.Unlocked,
+		},
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	dcMock := &clientMocks.DeviceClient{}
+	dcMock.On("DevicesByServiceName", mock.Anything, testServiceName, 0, -1).Return(
+		responses.NewMultiDevicesResponse("", "", 200, 2, []dtos.Device{testDeviceDTO("device-1"), testDeviceDTO("device-2")}), nil).Once()
+	dcMock.On("DevicesByServiceName", mock.Anything, testServiceName, 0, -1).Return(
+		responses.NewMultiDevicesResponse("", "", 200, 2, []dtos.Device{testDeviceDTO("device-1", "changed"), testDeviceDTO("device-3")}), nil)
+	dpcMock := &clientMocks.DeviceProfileClient{}
+	dpcMock.On("DeviceProfileByName", mock.Anything, testProfileName).Return(
+		responses.NewDeviceProfileResponse("", "", 200, dtos.DeviceProfile{DeviceProfileBasicInfo: dtos.DeviceProfileBasicInfo{Name: testProfileName}}), nil)
+	pwcMock := &clientMocks.ProvisionWatcherClient{}
+	pwcMock.On("ProvisionWatchersByServiceName", mock.Anything, testServiceName, 0, -1).Return(
+		responses.NewMultiProvisionWatchersResponse("", "", 200, 1, []dtos.ProvisionWatcher{testProvisionWatcherDTO("watcher-1")}), nil).Once()
+	pwcMock.On("ProvisionWatchersByServiceName", mock.Anything, testServiceName, 0, -1).Return(
+		responses.NewMultiProvisionWatchersResponse("", "", 200, 1, []dtos.ProvisionWatcher{testProvisionWatcherDTO("watcher-2")}), nil)
+
+	driverMock := &mocks.ProtocolDriver{}
+	driverMock.On("AddDevice", "device-3", mock.Anything, models.AdminState(models.Unlocked)).Return(nil)
+	driverMock.On("UpdateDevice", "device-1", mock.Anything, models.AdminState(models.Unlocked)).Return(nil)
+	driverMock.On("RemoveDevice", "device-2", mock.Anything).Return(nil)
+	autoEventManagerMock := &mocks.AutoEventManager{}
+	autoEventManagerMock.On("RestartForDevice", mock.Anything)
+	autoEventManagerMock.On("StopForDevice", mock.Anything)
+	metricsManagerMock := &bootstrapMocks.MetricsManager{}
+	metricsManagerMock.On("Register", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	metricsManagerMock.On("Unregister", mock.Anything)
+
+	dic := di.NewContainer(di.ServiceConstructorMap{
+		bootstrapContainer.LoggingClientInterfaceName: func(get di.Get) any {
+			return logger.NewMockClient()
+		},
+		bootstrapContainer.MetricsManagerInterfaceName: func(get di.Get) any {
+			return metricsManagerMock
+		},
+		bootstrapContainer.DeviceClientName: func(get di.Get) any {
+			return dcMock
+		},
+		bootstrapContainer.DeviceProfileClientName: func(get di.Get) any {
+			return dpcMock
+		},
+		bootstrapContainer.ProvisionWatcherClientName: func(get di.Get) any {
+			return pwcMock
+		},
+		container.ConfigurationName: func(get di.Get) any {
+			return &config.ConfigurationStruct{}
+		},
+		container.DeviceServiceName: func(get di.Get) any {
+			return &models.DeviceService{Name: testServiceName}
+		},
+		container.ProtocolDriverName: func(get di.Get) any {
+			return driverMock
+		},
+		container.AutoEventManagerName: func(get di.Get) any {
+			return autoEventManagerMock
+		},
+		container.AllowedRequestFailuresTrackerName: func(get di.Get) any {
+			return container.NewAllowedFailuresTracker()
+		},
+	})
+	require.NoError(t, cache.InitCache(testServiceName, testServiceName, dic))
+
+	corrected, err := Reconcile(testServiceName, testServiceName, dic)
+	require.NoError(t, err)
+	assert.Equal(t, 5, corrected)
+
+	device, ok := cache.Devices().ForName("device-1")
+	require.True(t, ok)
+	assert.Equal(t, []string{"changed"}, device.Labels)
+	_, ok = cache.Devices().ForName("device-2")
+	assert.False(t, ok)
+	_, ok = cache.Devices().ForName("device-3")
+	assert.True(t, ok)
+	_, ok = cache.ProvisionWatchers().ForName("watcher-1")
+	assert.False(t, ok)
+	_, ok = cache.ProvisionWatchers().ForName("watcher-2")
+	assert.True(t, ok)
+	driverMock.AssertExpectations(t)
+
+	// nothing to correct once the caches are consistent with metadata
+	corrected, err = Reconcile(testServiceName, testServiceName, dic)
+	require.NoError(t, err)
+	assert.Equal(t, 0, corrected)
+}
@@ -0,0 +1,258 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package autodiscovery
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
+
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v4/pkg/models"
+)
+
+// the results of evaluating a provision watcher against a discovered device
+const (
+	WatcherResultMatched    = "Matched"
+	WatcherResultNotMatched = "NotMatched"
+	WatcherResultBlocked    = "Blocked"
+	WatcherResultLocked     = "Locked"
+)
+
+// the actions taken for a discovered device
+const (
+	CandidateActionAdded         = "Added"
+	CandidateActionAlreadyExists = "AlreadyExists"
+	CandidateActionAddFailed     = "AddFailed"
+	CandidateActionNoMatch       = "NoMatch"
+	// CandidateActionPendingApproval is the device put in the pending queue for approval
+	CandidateActionPendingApproval = "PendingApproval"
+	// CandidateActionRejected is the device rejected from the pending queue
+	CandidateActionRejected = "Rejected"
+	// CandidateActionUpdated is the existing device matched by identity whose protocols were updated
+	CandidateActionUpdated = "Updated"
+	// CandidateActionUpdateFailed is the existing device matched by identity which failed to be updated
+	CandidateActionUpdateFailed = "UpdateFailed"
+)
+
+// the reasons of an identifier mismatch
+const (
+	MismatchReasonMissing      = "identifier is missing"
+	MismatchReasonEmpty        = "value is empty"
+	MismatchReasonNotMatched   = "value does not match the pattern"
+	MismatchReasonInvalidRegex = "pattern is not a valid regular expression"
+)
+
+// IdentifierMismatch is an identifier of a provision watcher which a protocol of a discovered device failed
+type IdentifierMismatch struct {
+	Protocol   string `json:"protocol"`
+	Identifier string `json:"identifier"`
+	Value      string `json:"value,omitempty"`
+	Pattern    string `json:"pattern"`
+	Reason     string `json:"reason"`
+}
+
+// BlockedIdentifier is the blocking identifier of a provision watcher which a discovered device matched
+type BlockedIdentifier struct {
+	Protocol   string `json:"protocol"`
+	Identifier string `json:"identifier"`
+	Value      string `json:"value"`
+}
+
+// WatcherEvaluation is the result of evaluating a provision watcher against a discovered device. Protocol is the
+// protocol which matched all the identifiers, Mismatches are the identifiers failed by the other protocols.
+type WatcherEvaluation struct {
+	ProvisionWatcher string               `json:"provisionWatcher"`
+	Result           string               `json:"result"`
+	Protocol         string               `json:"protocol,omitempty"`
+	Mismatches       []IdentifierMismatch `json:"mismatches,omitempty"`
+	Blocked          *BlockedIdentifier   `json:"blocked,omitempty"`
+}
+
+// Candidate is a discovered device with the provision watchers evaluated in order and the resulting action.
+// ProvisionWatcher is the matched provision watcher which determined the action, DeviceName is the name of the device
+// added or updated when it differs from the discovered name.
+type Candidate struct {
+	Name             string                               `json:"name"`
+	Description      string                               `json:"description,omitempty"`
+	Labels           []string                             `json:"labels,omitempty"`
+	Protocols        map[string]models.ProtocolProperties `json:"protocols"`
+	Evaluations      []WatcherEvaluation                  `json:"provisionWatchers"`
+	Action           string                               `json:"action"`
+	ProvisionWatcher string                               `json:"provisionWatcher,omitempty"`
+	DeviceName       string                               `json:"deviceName,omitempty"`
+	Message          string                               `json:"message,omitempty"`
+}
+
+// DiscoveryRun is the candidates of a device discovery, Timestamp is when the last candidates were recorded
+type DiscoveryRun struct {
+	RequestId  string      `json:"requestId"`
+	Timestamp  int64       `json:"timestamp"`
+	Candidates []Candidate `json:"candidates"`
+}
+
+type candidateStore struct {
+	run *DiscoveryRun
+	mux sync.RWMutex
+}
+
+var candidates candidateStore
+
+// RecordCandidates records the candidates of the device discovery of the request id. The candidates of the same
+// request id are merged into the run by name, as the discovered devices may be sent in several batches, and the
+// candidates of a new request id replace the last run.
+func RecordCandidates(requestId string, recorded []Candidate) {
+	candidates.mux.Lock()
+	defer candidates.mux.Unlock()
+
+	run := candidates.run
+	if run == nil || run.RequestId != requestId {
+		run = &DiscoveryRun{RequestId: requestId}
+	} else {
+		run = &DiscoveryRun{RequestId: requestId, Candidates: append([]Candidate(nil), run.Candidates...)}
+	}
+	index := make(map[string]int, len(run.Candidates))
+	for i, c := range run.Candidates {
+		index[c.Name] = i
+	}
+	for _, c := range recorded {
+		if i, ok := index[c.Name]; ok {
+			run.Candidates[i] = c
+			continue
+		}
+		index[c.Name] = len(run.Candidates)
+		run.Candidates = append(run.Candidates, c)
+	}
+	sort.SliceStable(run.Candidates, func(i, j int) bool { return run.Candidates[i].Name < run.Candidates[j].Name })
+	run.Timestamp = time.Now().UnixNano()
+	candidates.run = run
+}
+
+// LastDiscoveryRun returns the candidates of the last device discovery, false if no device has been discovered
+func LastDiscoveryRun() (DiscoveryRun, bool) {
+	candidates.mux.RLock()
+	defer candidates.mux.RUnlock()
+	if candidates.run == nil {
+		return DiscoveryRun{}, false
+	}
+	return *candidates.run, true
+}
+
+// EvaluateProvisionWatcher evaluates the provision watcher against the discovered device, the device must match all
+// the identifiers in one of its protocols and none of the blocking identifiers
+func EvaluateProvisionWatcher(d sdkModels.DiscoveredDevice, pw models.ProvisionWatcher) WatcherEvaluation {
+	evaluation := WatcherEvaluation{ProvisionWatcher: pw.Name}
+	if pw.AdminState == models.Locked {
+		evaluation.Result = WatcherResultLocked
+		return evaluation
+	}
+	protocol, matched, mismatches := EvaluateAllowList(d, pw)
+	if !matched {
+		evaluation.Result = WatcherResultNotMatched
+		evaluation.Mismatches = mismatches
+		return evaluation
+	}
+	evaluation.Protocol = protocol
+	if blocked := EvaluateBlockList(d, pw); blocked != nil {
+		evaluation.Result = WatcherResultBlocked
+		evaluation.Blocked = blocked
+		return evaluation
+	}
+	evaluation.Result = WatcherResultMatched
+	return evaluation
+}
+
+// EvaluateAllowList returns the protocol of the discovered device which matches all the identifiers of the provision
+// watcher, or the mismatches of the protocols if none of them matches
+func EvaluateAllowList(d sdkModels.DiscoveredDevice, pw models.ProvisionWatcher) (string, bool, []IdentifierMismatch) {
+	var mismatches []IdentifierMismatch
+	// ignore the device protocol properties name
+	for _, protocolName := range sortedProtocolNames(d.Protocols) {
+		protocol := d.Protocols[protocolName]
+		matchedCount := 0
+		for _, name := range sortedKeys(pw.Identifiers) {
+			regex := pw.Identifiers[name]
+			mismatch := IdentifierMismatch{Protocol: protocolName, Identifier: name, Pattern: regex}
+			value, ok := protocol[name]
+			if !ok {
+				mismatch.Reason = MismatchReasonMissing
+				mismatches = append(mismatches, mismatch)
+				continue
+			}
+			valueString := fmt.Sprintf("%v", value)
+			mismatch.Value = valueString
+			if valueString == "" {
+				mismatch.Reason = MismatchReasonEmpty
+				mismatches = append(mismatches, mismatch)
+				continue
+			}
+			matched, err := regexp.MatchString(regex, valueString)
+			if err != nil {
+				mismatch.Reason = MismatchReasonInvalidRegex
+				mismatches = append(mismatches, mismatch)
+				break
+			}
+			if !matched {
+				mismatch.Reason = MismatchReasonNotMatched
+				mismatches = append(mismatches, mismatch)
+				break
+			}
+			matchedCount += 1
+		}
+		// match succeed on all identifiers
+		if matchedCount == len(pw.Identifiers) {
+			return protocolName, true, nil
+		}
+	}
+	return "", false, mismatches
+}
+
+// EvaluateBlockList returns the blocking identifier of the provision watcher which the discovered device matches, nil
+// if the device matches none of them
+func EvaluateBlockList(d sdkModels.DiscoveredDevice, pw models.ProvisionWatcher) *BlockedIdentifier {
+	for _, name := range sortedKeys(pw.BlockingIdentifiers) {
+		// ignore the device protocol properties name
+		for _, protocolName := range sortedProtocolNames(d.Protocols) {
+			value, ok := d.Protocols[protocolName][name]
+			if !ok {
+				continue
+			}
+			valueString := fmt.Sprintf("%v", value)
+			if valueString == "" {
+				continue
+			}
+			for _, v := range pw.BlockingIdentifiers[name] {
+				if valueString == v {
+					return &BlockedIdentifier{Protocol: protocolName, Identifier: name, Value: valueString}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// sortedProtocolNames returns the protocol names in order, so the evaluations are deterministic
+func sortedProtocolNames(protocols map[string]models.ProtocolProperties) []string {
+	names := make([]string, 0, len(protocols))
+	for name := range protocols {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
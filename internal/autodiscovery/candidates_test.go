@@ -0,0 +1,220 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2020-2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package autodiscovery
+
+import (
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v4/pkg/models"
+)
+
+var d = sdkModels.DiscoveredDevice{
+	Name: "device-sdk-test",
+}
+
+func TestEvaluateAllowList(t *testing.T) {
+	pw := models.ProvisionWatcher{
+		Name: "test-watcher",
+		Identifiers: map[string]string{
+			"host": "localhost",
+			"port": "3[0-9]{2}",
+		},
+	}
+
+	onlyOneMatch := map[string]models.ProtocolProperties{
+		"http": {
+			"host": "localhost",
+			"port": "301",
+		},
+	}
+	oneOfProtocolsMatch := map[string]models.ProtocolProperties{
+		"tcp": {
+			"host": "localhost",
+			"port": "80",
+		},
+		"http": {
+			"host": "localhost",
+			"port": "301",
+		},
+	}
+	noIdentifiersMatch := map[string]models.ProtocolProperties{
+		"http": {
+			"host": "192.168.0.1",
+			"port": "400",
+		},
+	}
+	someIdentifiersMatch := map[string]models.ProtocolProperties{
+		"http": {
+			"host": "127.0.0.1",
+			"port": "301",
+		},
+		"tcp": {
+			"host": "localhost",
+			"port": "80",
+		},
+	}
+	noMatchInSingleIdentifier := map[string]models.ProtocolProperties{
+		"http": {
+			"port": "301",
+		},
+		"tcp": {
+			"host": "localhost",
+		},
+	}
+
+	tests := []struct {
+		name      string
+		protocols map[string]models.ProtocolProperties
+		expected  bool
+	}{
+		{"pass - match found", onlyOneMatch, true},
+		{"pass - one match found in multiple protocol", oneOfProtocolsMatch, true},
+		{"fail - none of identifier match in one protocol", noIdentifiersMatch, false},
+		{"fail - only partial of identifiers match in one protocol", someIdentifiersMatch, false},
+		{"fail - all of the identifiers match but across different protocol", noMatchInSingleIdentifier, false},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			d.Protocols = testCase.protocols
+			_, result, mismatches := EvaluateAllowList(d, pw)
+			assert.Equal(t, testCase.expected, result)
+			assert.Equal(t, testCase.expected, len(mismatches) == 0, "mismatches are not reported")
+		})
+	}
+}
+
+func TestEvaluateBlockList(t *testing.T) {
+	pw := models.ProvisionWatcher{
+		Name: "test-watcher",
+		BlockingIdentifiers: map[string][]string{
+			"port": []string{"399", "398", "397"},
+		},
+	}
+
+	noBlockingIdentifierFound := map[string]models.ProtocolProperties{
+		"http": {
+			"host": "localhost",
+		},
+		"tcp": {
+			"host": "127.0.0.1",
+		},
+	}
+	noBlockingIdentifierMatch := map[string]models.ProtocolProperties{
+		"http": {
+			"host": "localhost",
+			"port": "400",
+		},
+		"tcp": {
+			"host": "localhost",
+			"port": "80",
+		},
+	}
+	blockingIdentifierMatch := map[string]models.ProtocolProperties{
+		"http": {
+			"host": "localhost",
+			"port": "399",
+		},
+		"tcp": {
+			"host": "localhost",
+			"port": "80",
+		},
+	}
+
+	tests := []struct {
+		name      string
+		protocols map[string]models.ProtocolProperties
+		expected  bool
+	}{
+		{"pass - no blocking identifier found", noBlockingIdentifierFound, true},
+		{"pass - blocking identifier found but not match", noBlockingIdentifierMatch, true},
+		{"fail - blocking identifier match", blockingIdentifierMatch, false},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			d.Protocols = testCase.protocols
+			result := EvaluateBlockList(d, pw) == nil
+			assert.Equal(t, testCase.expected, result)
+		})
+	}
+}
+
+func TestEvaluateProvisionWatcher(t *testing.T) {
+	device := sdkModels.DiscoveredDevice{
+		Name: "device-sdk-test",
+		Protocols: map[string]models.ProtocolProperties{
+			"http": {"host": "localhost", "port": "399"},
+		},
+	}
+
+	tests := []struct {
+		name               string
+		pw                 models.ProvisionWatcher
+		expectedResult     string
+		expectedMismatches []IdentifierMismatch
+		expectedBlocked    *BlockedIdentifier
+	}{
+		{"matched",
+			models.ProvisionWatcher{Name: "pw", AdminState: models.Unlocked, Identifiers: map[string]string{"host": "local.*"}},
+			WatcherResultMatched, nil, nil},
+		{"locked",
+			models.ProvisionWatcher{Name: "pw", AdminState: models.Locked, Identifiers: map[string]string{"host": "local.*"}},
+			WatcherResultLocked, nil, nil},
+		{"identifier not matched",
+			models.ProvisionWatcher{Name: "pw", AdminState: models.Unlocked, Identifiers: map[string]string{"port": "4[0-9]{2}"}},
+			WatcherResultNotMatched,
+			[]IdentifierMismatch{{Protocol: "http", Identifier: "port", Value: "399", Pattern: "4[0-9]{2}", Reason: MismatchReasonNotMatched}},
+			nil},
+		{"identifier missing",
+			models.ProvisionWatcher{Name: "pw", AdminState: models.Unlocked, Identifiers: map[string]string{"mac": ".*"}},
+			WatcherResultNotMatched,
+			[]IdentifierMismatch{{Protocol: "http", Identifier: "mac", Pattern: ".*", Reason: MismatchReasonMissing}},
+			nil},
+		{"invalid pattern",
+			models.ProvisionWatcher{Name: "pw", AdminState: models.Unlocked, Identifiers: map[string]string{"host": "["}},
+			WatcherResultNotMatched,
+			[]IdentifierMismatch{{Protocol: "http", Identifier: "host", Value: "localhost", Pattern: "[", Reason: MismatchReasonInvalidRegex}},
+			nil},
+		{"blocked",
+			models.ProvisionWatcher{Name: "pw", AdminState: models.Unlocked, Identifiers: map[string]string{"host": "local.*"}, BlockingIdentifiers: map[string][]string{"port": {"398", "399"}}},
+			WatcherResultBlocked, nil,
+			&BlockedIdentifier{Protocol: "http", Identifier: "port", Value: "399"}},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			evaluation := EvaluateProvisionWatcher(device, testCase.pw)
+			assert.Equal(t, testCase.pw.Name, evaluation.ProvisionWatcher)
+			assert.Equal(t, testCase.expectedResult, evaluation.Result)
+			assert.Equal(t, testCase.expectedMismatches, evaluation.Mismatches)
+			assert.Equal(t, testCase.expectedBlocked, evaluation.Blocked)
+		})
+	}
+}
+
+func TestRecordCandidates(t *testing.T) {
+	RecordCandidates("request-1", []Candidate{{Name: "b", Action: CandidateActionNoMatch}})
+	RecordCandidates("request-1", []Candidate{{Name: "a", Action: CandidateActionAdded}, {Name: "b", Action: CandidateActionAdded}})
+
+	run, ok := LastDiscoveryRun()
+	require.True(t, ok)
+	assert.Equal(t, "request-1", run.RequestId)
+	assert.NotZero(t, run.Timestamp)
+	require.Len(t, run.Candidates, 2)
+	assert.Equal(t, "a", run.Candidates[0].Name)
+	assert.Equal(t, CandidateActionAdded, run.Candidates[1].Action, "candidate of the same name is not replaced")
+
+	RecordCandidates("request-2", []Candidate{{Name: "c", Action: CandidateActionNoMatch}})
+	run, ok = LastDiscoveryRun()
+	require.True(t, ok)
+	assert.Equal(t, "request-2", run.RequestId)
+	require.Len(t, run.Candidates, 1)
+	assert.Equal(t, "c", run.Candidates[0].Name)
+}
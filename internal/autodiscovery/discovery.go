@@ -1,6 +1,6 @@
 // -*- Mode: Go; indent-tabs-mode: t -*-
 //
-// Copyright (C) 2020-2024 IOTech Ltd
+// Copyright (C) 2020-2026 IOTech Ltd
 //
 // SPDX-License-Identifier: Apache-2.0
 
@@ -53,6 +53,7 @@ func DiscoveryWrapper(driver interfaces.ProtocolDriver, ctx context.Context, dic
 		},
 	})
 
+	checkStaleDevices(ctx, dic)
 	utils.PublishDeviceDiscoveryProgressSystemEvent(requestId, 0, 0, "", ctx, dic)
 	lc.Debugf("protocol discovery triggered with correlation id: %s", requestId)
 	err := driver.Discover()
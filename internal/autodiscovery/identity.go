@@ -0,0 +1,84 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package autodiscovery
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
+
+	"github.com/edgexfoundry/device-sdk-go/v4/internal/cache"
+	sdkCommon "github.com/edgexfoundry/device-sdk-go/v4/internal/common"
+)
+
+var invalidNameChars = regexp.MustCompile(`[^A-Za-z0-9._~-]`)
+
+// IdentityKey returns the protocol property which identifies the devices matched by the provision watcher, which is
+// set by the ds-identity-key=<property> label, e.g. ds-identity-key=SerialNumber
+func IdentityKey(pw models.ProvisionWatcher) (string, bool) {
+	for _, label := range pw.Labels {
+		if key, ok := strings.CutPrefix(label, sdkCommon.IdentityKeyLabelPrefix); ok && key != "" {
+			return key, true
+		}
+	}
+	return "", false
+}
+
+// IdentityValue returns the value of the identity key in the first protocol of the device which has it
+func IdentityValue(protocols map[string]models.ProtocolProperties, key string) (string, bool) {
+	for _, protocolName := range sortedProtocolNames(protocols) {
+		value, ok := protocols[protocolName][key]
+		if !ok {
+			continue
+		}
+		if valueString := fmt.Sprintf("%v", value); valueString != "" {
+			return valueString, true
+		}
+	}
+	return "", false
+}
+
+// FindDeviceByIdentity returns the cached device whose identity key has the value, looked up by the protocol property
+// index of the device cache. The values are compared case-insensitively as the MAC addresses and serial numbers may be
+// reported in either case, so the value is looked up as it is, in upper case and in lower case.
+func FindDeviceByIdentity(key, value string) (models.Device, bool) {
+	for _, v := range []string{value, strings.ToUpper(value), strings.ToLower(value)} {
+		for _, d := range cache.Devices().ForProtocolProperty("", key, v) {
+			if identity, ok := IdentityValue(d.Protocols, key); ok && strings.EqualFold(identity, value) {
+				return d, true
+			}
+		}
+	}
+	return models.Device{}, false
+}
+
+// StableDeviceName returns the name of the discovered device derived from its identity, so the device keeps the same
+// name whatever the driver names it after, e.g. its IP address
+func StableDeviceName(prefix, value string) string {
+	return invalidNameChars.ReplaceAllString(prefix+"-"+value, "_")
+}
+
+// ProtocolsChanged returns whether the discovered protocols differ from the protocols of the existing device, the
+// values are compared by their string representation as the cached properties may be decoded into other types
+func ProtocolsChanged(existing, discovered map[string]models.ProtocolProperties) bool {
+	return !reflect.DeepEqual(stringifyProtocols(existing), stringifyProtocols(discovered))
+}
+
+func stringifyProtocols(protocols map[string]models.ProtocolProperties) map[string]map[string]string {
+	result := make(map[string]map[string]string, len(protocols))
+	for name, properties := range protocols {
+		values := make(map[string]string, len(properties))
+		for k, v := range properties {
+			values[k] = fmt.Sprintf("%v", v)
+		}
+		result[name] = values
+	}
+	return result
+}
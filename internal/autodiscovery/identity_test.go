@@ -0,0 +1,80 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package autodiscovery
+
+import (
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/edgexfoundry/device-sdk-go/v4/internal/cache"
+	sdkCommon "github.com/edgexfoundry/device-sdk-go/v4/internal/common"
+)
+
+func TestIdentityKey(t *testing.T) {
+	tests := []struct {
+		name        string
+		labels      []string
+		expectedKey string
+		expectedOk  bool
+	}{
+		{"set", []string{"meter", sdkCommon.IdentityKeyLabelPrefix + "SerialNumber"}, "SerialNumber", true},
+		{"empty key", []string{sdkCommon.IdentityKeyLabelPrefix}, "", false},
+		{"not set", []string{"meter"}, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, ok := IdentityKey(models.ProvisionWatcher{Labels: tt.labels})
+			assert.Equal(t, tt.expectedKey, key)
+			assert.Equal(t, tt.expectedOk, ok)
+		})
+	}
+}
+
+func TestFindDeviceByIdentity(t *testing.T) {
+	cache.InitCacheFromSnapshot(cache.Snapshot{
+		Devices: []dtos.Device{
+			{Name: "meter-01", Protocols: map[string]dtos.ProtocolProperties{"http": {"host": "10.0.0.1", "mac": "00:1A:2B"}}},
+			{Name: "meter-02", Protocols: map[string]dtos.ProtocolProperties{"modbus-tcp": {"Address": "10.0.0.2"}, "other": {"mac": "00:1a:2c"}}},
+			{Name: "meter-03", Protocols: map[string]dtos.ProtocolProperties{"http": {"host": "10.0.0.3"}}},
+		},
+	}, mockDic(false, nil))
+
+	tests := []struct {
+		name         string
+		value        string
+		expectedName string
+		expectedOk   bool
+	}{
+		{"same case", "00:1A:2B", "meter-01", true},
+		{"lower case", "00:1a:2b", "meter-01", true},
+		{"upper case", "00:1A:2C", "meter-02", true},
+		{"not found", "00:1A:2D", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, ok := FindDeviceByIdentity("mac", tt.value)
+			assert.Equal(t, tt.expectedOk, ok)
+			assert.Equal(t, tt.expectedName, d.Name)
+		})
+	}
+}
+
+func TestStableDeviceName(t *testing.T) {
+	assert.Equal(t, "Meter-A-00_1A_2B", StableDeviceName("Meter-A", "00:1A:2B"))
+	assert.Equal(t, "Meter-A-SN_0042", StableDeviceName("Meter-A", "SN/0042"))
+}
+
+func TestProtocolsChanged(t *testing.T) {
+	existing := map[string]models.ProtocolProperties{"http": {"host": "10.0.0.1", "port": "80"}}
+
+	assert.False(t, ProtocolsChanged(existing, map[string]models.ProtocolProperties{"http": {"host": "10.0.0.1", "port": 80}}))
+	assert.True(t, ProtocolsChanged(existing, map[string]models.ProtocolProperties{"http": {"host": "10.0.0.2", "port": "80"}}))
+	assert.True(t, ProtocolsChanged(existing, map[string]models.ProtocolProperties{"http": {"host": "10.0.0.1"}}))
+}
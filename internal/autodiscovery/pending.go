@@ -0,0 +1,186 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package autodiscovery
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v4/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v4/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/dtos/requests"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
+
+	sdkCommon "github.com/edgexfoundry/device-sdk-go/v4/internal/common"
+	"github.com/edgexfoundry/device-sdk-go/v4/internal/container"
+	"github.com/edgexfoundry/device-sdk-go/v4/internal/utils"
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v4/pkg/models"
+)
+
+// PendingDevice is a discovered device matched by the provision watcher which waits for the approval to be added
+type PendingDevice struct {
+	Id               string      `json:"id"`
+	ProvisionWatcher string      `json:"provisionWatcher"`
+	Created          int64       `json:"created"`
+	Device           dtos.Device `json:"device"`
+}
+
+// PendingDeviceEdits is the optional changes to the pending device on approval, nil fields are left unchanged
+type PendingDeviceEdits struct {
+	Name        *string  `json:"name,omitempty"`
+	ProfileName *string  `json:"profileName,omitempty"`
+	Labels      []string `json:"labels,omitempty"`
+}
+
+type pendingQueue struct {
+	devices map[string]PendingDevice
+	// rejected is the names of the rejected devices, which are not queued again when they are rediscovered
+	rejected map[string]struct{}
+	mux      sync.RWMutex
+}
+
+var pending = pendingQueue{devices: make(map[string]PendingDevice), rejected: make(map[string]struct{})}
+
+// RequiresApproval returns whether the devices matched by the provision watcher must be approved before they are
+// added, which is enabled for all the provision watchers by the Discovery configuration or per provision watcher by
+// the ds-require-approval label
+func RequiresApproval(pw models.ProvisionWatcher, dic *di.Container) bool {
+	return container.ConfigurationFrom(dic.Get).Device.Discovery.RequireApproval ||
+		slices.Contains(pw.Labels, sdkCommon.RequireApprovalLabel)
+}
+
+// EnqueuePendingDevice puts the discovered device in the pending queue and returns the id of the entry. The entry of
+// a rediscovered device is refreshed with the same id, and the system event is only published for the new entries.
+// False is returned if the device has been rejected.
+func EnqueuePendingDevice(ctx context.Context, device models.Device, pwName string, dic *di.Container) (string, bool) {
+	pending.mux.Lock()
+	if _, ok := pending.rejected[device.Name]; ok {
+		pending.mux.Unlock()
+		return "", false
+	}
+	for id, p := range pending.devices {
+		if p.Device.Name == device.Name {
+			p.ProvisionWatcher = pwName
+			p.Device = dtos.FromDeviceModelToDTO(device)
+			pending.devices[id] = p
+			pending.mux.Unlock()
+			return id, true
+		}
+	}
+	p := PendingDevice{
+		Id:               uuid.NewString(),
+		ProvisionWatcher: pwName,
+		Created:          time.Now().UnixMilli(),
+		Device:           dtos.FromDeviceModelToDTO(device),
+	}
+	pending.devices[p.Id] = p
+	pending.mux.Unlock()
+
+	bootstrapContainer.LoggingClientFrom(dic.Get).Infof("Discovered device %s matched by provision watcher %s is pending approval", device.Name, pwName)
+	utils.PublishPendingApprovalSystemEvent(sdkModels.PendingApproval{
+		Id:               p.Id,
+		DeviceName:       device.Name,
+		ProvisionWatcher: pwName,
+		ProfileName:      device.ProfileName,
+	}, ctx, dic)
+	return p.Id, true
+}
+
+// PendingDevices returns the devices pending approval in the order they were queued
+func PendingDevices() []PendingDevice {
+	pending.mux.RLock()
+	defer pending.mux.RUnlock()
+	devices := make([]PendingDevice, 0, len(pending.devices))
+	for _, p := range pending.devices {
+		devices = append(devices, p)
+	}
+	sort.Slice(devices, func(i, j int) bool {
+		if devices[i].Created != devices[j].Created {
+			return devices[i].Created < devices[j].Created
+		}
+		return devices[i].Device.Name < devices[j].Device.Name
+	})
+	return devices
+}
+
+// ApprovePendingDevice adds the pending device of the id with the edits applied and removes it from the queue. The
+// device stays in the queue if it fails to be added.
+func ApprovePendingDevice(ctx context.Context, id string, edits PendingDeviceEdits, dic *di.Container) (dtos.Device, errors.EdgeX) {
+	pending.mux.Lock()
+	p, ok := pending.devices[id]
+	if !ok {
+		pending.mux.Unlock()
+		return dtos.Device{}, errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, fmt.Sprintf("pending device %s does not exist", id), nil)
+	}
+	// removed while being added, so the same device can't be approved twice
+	delete(pending.devices, id)
+	pending.mux.Unlock()
+
+	device := p.Device
+	if edits.Name != nil {
+		device.Name = *edits.Name
+	}
+	if edits.ProfileName != nil {
+		device.ProfileName = *edits.ProfileName
+	}
+	if edits.Labels != nil {
+		device.Labels = edits.Labels
+	}
+
+	edgexErr := AddDiscoveredDevice(ctx, dtos.ToDeviceModel(device), dic)
+	if edgexErr != nil {
+		pending.mux.Lock()
+		pending.devices[id] = p
+		pending.mux.Unlock()
+		return dtos.Device{}, errors.NewCommonEdgeX(errors.Kind(edgexErr), fmt.Sprintf("failed to add pending device %s", p.Device.Name), edgexErr)
+	}
+	bootstrapContainer.LoggingClientFrom(dic.Get).Infof("Pending device %s approved and added as %s", p.Device.Name, device.Name)
+	return device, nil
+}
+
+// RejectPendingDevice removes the pending device of the id from the queue, the device is not queued again when it is
+// rediscovered until the service restarts
+func RejectPendingDevice(id string) (dtos.Device, errors.EdgeX) {
+	pending.mux.Lock()
+	defer pending.mux.Unlock()
+	p, ok := pending.devices[id]
+	if !ok {
+		return dtos.Device{}, errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, fmt.Sprintf("pending device %s does not exist", id), nil)
+	}
+	delete(pending.devices, id)
+	pending.rejected[p.Device.Name] = struct{}{}
+	return p.Device, nil
+}
+
+// AddDiscoveredDevice adds the discovered device to Metadata, bypassing the validation by the device service as the
+// device is created by the device service itself
+func AddDiscoveredDevice(ctx context.Context, device models.Device, dic *di.Container) errors.EdgeX {
+	req := requests.NewAddDeviceRequest(dtos.FromDeviceModelToDTO(device))
+	res, err := bootstrapContainer.DeviceClientFrom(dic.Get).AddWithQueryParams(ctx, []requests.AddDeviceRequest{req},
+		map[string]string{sdkCommon.BypassValidationQueryParam: common.ValueTrue})
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.Kind(err), fmt.Sprintf("failed to add device %s", device.Name), err)
+	}
+	// the request is answered with a multi-status response, each item carrying its own status code
+	if len(res) == 0 {
+		return errors.NewCommonEdgeX(errors.KindServerError, fmt.Sprintf("failed to add device %s: no response from Metadata", device.Name), nil)
+	}
+	if res[0].StatusCode != http.StatusCreated {
+		return errors.NewCommonEdgeX(errors.KindMapping(res[0].StatusCode), fmt.Sprintf("failed to add device %s: %s", device.Name, res[0].Message), nil)
+	}
+	return nil
+}
@@ -0,0 +1,164 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package autodiscovery
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v4/bootstrap/container"
+	bootstrapMocks "github.com/edgexfoundry/go-mod-bootstrap/v4/bootstrap/interfaces/mocks"
+	"github.com/edgexfoundry/go-mod-bootstrap/v4/di"
+	clientMocks "github.com/edgexfoundry/go-mod-core-contracts/v4/clients/interfaces/mocks"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/clients/logger"
+	dtoCommon "github.com/edgexfoundry/go-mod-core-contracts/v4/dtos/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/dtos/requests"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	sdkCommon "github.com/edgexfoundry/device-sdk-go/v4/internal/common"
+	"github.com/edgexfoundry/device-sdk-go/v4/internal/config"
+	"github.com/edgexfoundry/device-sdk-go/v4/internal/container"
+)
+
+func mockDic(requireApproval bool, dcMock *clientMocks.DeviceClient) *di.Container {
+	configuration := &config.ConfigurationStruct{}
+	configuration.Device.Discovery.RequireApproval = requireApproval
+	metricsManager := &bootstrapMocks.MetricsManager{}
+	metricsManager.On("Register", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	metricsManager.On("Unregister", mock.Anything)
+	return di.NewContainer(di.ServiceConstructorMap{
+		bootstrapContainer.LoggingClientInterfaceName: func(get di.Get) interface{} {
+			return logger.NewMockClient()
+		},
+		container.ConfigurationName: func(get di.Get) interface{} {
+			return configuration
+		},
+		container.DeviceServiceName: func(get di.Get) interface{} {
+			return &models.DeviceService{Name: "test-service"}
+		},
+		bootstrapContainer.DeviceClientName: func(get di.Get) interface{} {
+			return dcMock
+		},
+		bootstrapContainer.MetricsManagerInterfaceName: func(get di.Get) interface{} {
+			return metricsManager
+		},
+	})
+}
+
+func resetPendingQueue() {
+	pending.mux.Lock()
+	defer pending.mux.Unlock()
+	pending.devices = make(map[string]PendingDevice)
+	pending.rejected = make(map[string]struct{})
+}
+
+func TestRequiresApproval(t *testing.T) {
+	labeled := models.ProvisionWatcher{Labels: []string{"meter", sdkCommon.RequireApprovalLabel}}
+	unlabeled := models.ProvisionWatcher{Labels: []string{"meter"}}
+
+	tests := []struct {
+		name            string
+		requireApproval bool
+		pw              models.ProvisionWatcher
+		expected        bool
+	}{
+		{"global", true, unlabeled, true},
+		{"per provision watcher", false, labeled, true},
+		{"not required", false, unlabeled, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, RequiresApproval(tt.pw, mockDic(tt.requireApproval, nil)))
+		})
+	}
+}
+
+func TestEnqueuePendingDevice(t *testing.T) {
+	resetPendingQueue()
+	dic := mockDic(true, nil)
+
+	id, ok := EnqueuePendingDevice(context.Background(), models.Device{Name: "meter-01", ProfileName: "meter"}, "watcher-a", dic)
+	require.True(t, ok)
+	require.NotEmpty(t, id)
+	_, ok = EnqueuePendingDevice(context.Background(), models.Device{Name: "meter-02"}, "watcher-a", dic)
+	require.True(t, ok)
+
+	// the rediscovered device refreshes its entry
+	refreshedId, ok := EnqueuePendingDevice(context.Background(), models.Device{Name: "meter-01", ProfileName: "meter-v2"}, "watcher-b", dic)
+	require.True(t, ok)
+	assert.Equal(t, id, refreshedId)
+
+	devices := PendingDevices()
+	require.Len(t, devices, 2)
+	assert.Equal(t, "meter-01", devices[0].Device.Name)
+	assert.Equal(t, "meter-v2", devices[0].Device.ProfileName)
+	assert.Equal(t, "watcher-b", devices[0].ProvisionWatcher)
+	assert.Equal(t, "meter-02", devices[1].Device.Name)
+
+	rejected, edgexErr := RejectPendingDevice(id)
+	require.NoError(t, edgexErr)
+	assert.Equal(t, "meter-01", rejected.Name)
+	_, ok = EnqueuePendingDevice(context.Background(), models.Device{Name: "meter-01"}, "watcher-a", dic)
+	assert.False(t, ok, "rejected device is queued again")
+	assert.Len(t, PendingDevices(), 1)
+
+	_, edgexErr = RejectPendingDevice(id)
+	require.Error(t, edgexErr)
+	assert.Equal(t, errors.KindEntityDoesNotExist, errors.Kind(edgexErr))
+}
+
+func TestApprovePendingDevice(t *testing.T) {
+	resetPendingQueue()
+	var added []requests.AddDeviceRequest
+	dcMock := &clientMocks.DeviceClient{}
+	dcMock.On("AddWithQueryParams", mock.Anything, mock.MatchedBy(func(reqs []requests.AddDeviceRequest) bool {
+		return reqs[0].Device.ProfileName == "missing"
+	}), mock.Anything).Return(nil, errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, "profile not found", nil))
+	dcMock.On("AddWithQueryParams", mock.Anything, mock.MatchedBy(func(reqs []requests.AddDeviceRequest) bool {
+		return reqs[0].Device.Name == "duplicate"
+	}), mock.Anything).Return([]dtoCommon.BaseWithIdResponse{
+		{BaseResponse: dtoCommon.NewBaseResponse("", "device name duplicate exists", http.StatusConflict)}}, nil)
+	dcMock.On("AddWithQueryParams", mock.Anything, mock.Anything, mock.Anything).Return([]dtoCommon.BaseWithIdResponse{
+		{BaseResponse: dtoCommon.NewBaseResponse("", "", http.StatusCreated)}}, nil).
+		Run(func(args mock.Arguments) { added = append(added, args.Get(1).([]requests.AddDeviceRequest)...) })
+	dic := mockDic(true, dcMock)
+
+	device := models.Device{Name: "meter-01", ProfileName: "meter", Labels: []string{"discovered"}, ServiceName: "test-service"}
+	id, ok := EnqueuePendingDevice(context.Background(), device, "watcher-a", dic)
+	require.True(t, ok)
+
+	missing := "missing"
+	_, edgexErr := ApprovePendingDevice(context.Background(), id, PendingDeviceEdits{ProfileName: &missing}, dic)
+	require.Error(t, edgexErr)
+	assert.Equal(t, errors.KindEntityDoesNotExist, errors.Kind(edgexErr))
+	require.Len(t, PendingDevices(), 1, "device failed to be added is removed from the queue")
+
+	duplicate := "duplicate"
+	_, edgexErr = ApprovePendingDevice(context.Background(), id, PendingDeviceEdits{Name: &duplicate}, dic)
+	require.Error(t, edgexErr, "device rejected in the multi-status response is not added")
+	assert.Equal(t, errors.KindStatusConflict, errors.Kind(edgexErr))
+	require.Len(t, PendingDevices(), 1, "device rejected by Metadata is removed from the queue")
+
+	name := "meter-renamed"
+	approved, edgexErr := ApprovePendingDevice(context.Background(), id, PendingDeviceEdits{Name: &name, Labels: []string{"approved"}}, dic)
+	require.NoError(t, edgexErr)
+	assert.Equal(t, name, approved.Name)
+	assert.Equal(t, "meter", approved.ProfileName)
+	assert.Equal(t, []string{"approved"}, approved.Labels)
+	require.Len(t, added, 1)
+	assert.Equal(t, name, added[0].Device.Name)
+	assert.Empty(t, PendingDevices())
+
+	_, edgexErr = ApprovePendingDevice(context.Background(), id, PendingDeviceEdits{}, dic)
+	require.Error(t, edgexErr)
+	assert.Equal(t, errors.KindEntityDoesNotExist, errors.Kind(edgexErr))
+}
@@ -0,0 +1,274 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package autodiscovery
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v4/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v4/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/dtos"
+	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v4/dtos/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/dtos/requests"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
+
+	"github.com/edgexfoundry/device-sdk-go/v4/internal/cache"
+	sdkCommon "github.com/edgexfoundry/device-sdk-go/v4/internal/common"
+	"github.com/edgexfoundry/device-sdk-go/v4/internal/utils"
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v4/pkg/models"
+)
+
+// the actions taken for a stale device
+const (
+	StaleActionDown  = "down"
+	StaleActionEvent = "event"
+)
+
+// StalePolicy is when a device matched by the provision watcher is stale, after Runs discovery runs or the After
+// duration without being discovered, and whether the stale device is marked down or only reported by the system event
+type StalePolicy struct {
+	Runs     int
+	After    time.Duration
+	MarkDown bool
+}
+
+// StalePolicyFrom returns the stale policy of the provision watcher set by the ds-stale-after=<runs|duration> label and
+// the optional ds-stale-action=<down|event> label, false if the provision watcher doesn't opt in
+func StalePolicyFrom(pw models.ProvisionWatcher) (StalePolicy, bool, error) {
+	policy := StalePolicy{MarkDown: true}
+	var optIn bool
+	for _, label := range pw.Labels {
+		if value, ok := strings.CutPrefix(label, sdkCommon.StaleAfterLabelPrefix); ok {
+			if runs, err := strconv.Atoi(value); err == nil {
+				if runs <= 0 {
+					return StalePolicy{}, false, fmt.Errorf("number of discovery runs %s must be positive", value)
+				}
+				policy.Runs = runs
+			} else if after, err := time.ParseDuration(value); err == nil {
+				if after <= 0 {
+					return StalePolicy{}, false, fmt.Errorf("duration %s must be positive", value)
+				}
+				policy.After = after
+			} else {
+				return StalePolicy{}, false, fmt.Errorf("%s is neither a number of discovery runs nor a duration", value)
+			}
+			optIn = true
+		} else if value, ok := strings.CutPrefix(label, sdkCommon.StaleActionLabelPrefix); ok {
+			switch value {
+			case StaleActionDown:
+				policy.MarkDown = true
+			case StaleActionEvent:
+				policy.MarkDown = false
+			default:
+				return StalePolicy{}, false, fmt.Errorf("stale action %s is neither %s nor %s", value, StaleActionDown, StaleActionEvent)
+			}
+		}
+	}
+	return policy, optIn, nil
+}
+
+// isStale returns whether the device last seen in the run at the time is stale, the missed runs are the discovery runs
+// completed since then
+func (p StalePolicy) isStale(missedRuns int, lastSeen time.Time, now time.Time) bool {
+	if p.Runs > 0 {
+		return missedRuns >= p.Runs
+	}
+	return now.Sub(lastSeen) >= p.After
+}
+
+type seenDevice struct {
+	provisionWatcher string
+	lastSeen         time.Time
+	lastSeenRun      int
+	stale            bool
+}
+
+type staleTracker struct {
+	devices map[string]*seenDevice
+	// run is the number of the discovery runs started
+	run int
+	// loaded is whether the cached devices are tracked, which is done by the first discovery run after the start
+	loaded bool
+	mux    sync.Mutex
+}
+
+var tracker = staleTracker{devices: make(map[string]*seenDevice)}
+
+// MarkDeviceSeen records the device as seen in the current discovery run if the provision watcher which matched it
+// opts in the stale detection. The stale device marked down is marked up again.
+func MarkDeviceSeen(ctx context.Context, deviceName string, pw models.ProvisionWatcher, dic *di.Container) {
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	policy, ok, err := StalePolicyFrom(pw)
+	if err != nil {
+		lc.Warnf("Stale detection of provision watcher %s is disabled: %v", pw.Name, err)
+	}
+	if !ok {
+		tracker.mux.Lock()
+		delete(tracker.devices, deviceName)
+		tracker.mux.Unlock()
+		return
+	}
+
+	tracker.mux.Lock()
+	seen, tracked := tracker.devices[deviceName]
+	if !tracked {
+		seen = &seenDevice{}
+		tracker.devices[deviceName] = seen
+	}
+	wasStale := seen.stale
+	seen.provisionWatcher = pw.Name
+	seen.lastSeen = time.Now()
+	seen.lastSeenRun = tracker.run
+	seen.stale = false
+	tracker.mux.Unlock()
+
+	if !wasStale || !policy.MarkDown {
+		return
+	}
+	if device, ok := cache.Devices().ForName(deviceName); ok && slices.Contains(device.Labels, sdkCommon.StaleLabel) {
+		lc.Infof("Stale device %s is discovered again, marking it %s", deviceName, models.Up)
+		if edgexErr := updateStaleState(ctx, device, false, dic); edgexErr != nil {
+			lc.Errorf("failed to mark rediscovered device %s %s: %v", deviceName, models.Up, edgexErr)
+		}
+	}
+}
+
+// checkStaleDevices starts a new discovery run after detecting the devices which were not seen in the completed runs.
+// A device is untracked if it or its provision watcher is removed, or the provision watcher opts out.
+func checkStaleDevices(ctx context.Context, dic *di.Container) {
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	now := time.Now()
+
+	tracker.mux.Lock()
+	if !tracker.loaded {
+		tracker.loadCachedDevices(now)
+		tracker.loaded = true
+	}
+	var stale []sdkModels.StaleDevice
+	var markDown []string
+	for _, name := range sortedKeys(tracker.devices) {
+		seen := tracker.devices[name]
+		if _, ok := cache.Devices().ForName(name); !ok {
+			delete(tracker.devices, name)
+			continue
+		}
+		pw, ok := cache.ProvisionWatchers().ForName(seen.provisionWatcher)
+		if !ok {
+			delete(tracker.devices, name)
+			continue
+		}
+		policy, ok, _ := StalePolicyFrom(pw)
+		if !ok {
+			delete(tracker.devices, name)
+			continue
+		}
+		missedRuns := tracker.run - seen.lastSeenRun
+		if seen.stale || !policy.isStale(missedRuns, seen.lastSeen, now) {
+			continue
+		}
+		seen.stale = true
+		stale = append(stale, sdkModels.StaleDevice{
+			DeviceName:       name,
+			ProvisionWatcher: pw.Name,
+			LastSeen:         seen.lastSeen.UnixMilli(),
+			MissedRuns:       missedRuns,
+		})
+		if policy.MarkDown {
+			markDown = append(markDown, name)
+		}
+	}
+	tracker.run++
+	tracker.mux.Unlock()
+
+	for _, details := range stale {
+		lc.Warnf("Device %s has not been discovered in %d discovery runs since %s", details.DeviceName, details.MissedRuns, time.UnixMilli(details.LastSeen).Format(time.RFC3339))
+		utils.PublishStaleDeviceSystemEvent(details, ctx, dic)
+	}
+	for _, name := range markDown {
+		device, ok := cache.Devices().ForName(name)
+		if !ok {
+			continue
+		}
+		if edgexErr := updateStaleState(ctx, device, true, dic); edgexErr != nil {
+			lc.Errorf("failed to mark stale device %s %s: %v", name, models.Down, edgexErr)
+		}
+	}
+}
+
+// loadCachedDevices tracks the cached devices matched by the provision watchers which opt in the stale detection, so
+// the devices added before the restart are detected too. They are tracked as seen at the start, except the devices
+// labeled as marked down by the stale detection which are tracked as stale, so they are not reported again. The
+// devices DOWN for any other reason are left to their owner. The lock must be held by the caller.
+func (t *staleTracker) loadCachedDevices(now time.Time) {
+	var watchers []models.ProvisionWatcher
+	for _, pw := range cache.ProvisionWatchers().All() {
+		if _, ok, _ := StalePolicyFrom(pw); ok {
+			watchers = append(watchers, pw)
+		}
+	}
+	if len(watchers) == 0 {
+		return
+	}
+	sort.Slice(watchers, func(i, j int) bool {
+		return watchers[i].Name < watchers[j].Name
+	})
+
+	for _, device := range cache.Devices().All() {
+		if _, tracked := t.devices[device.Name]; tracked {
+			continue
+		}
+		// the provision watcher which added the device is not recorded, it is found by matching the device again
+		discovered := sdkModels.DiscoveredDevice{Name: device.Name, Protocols: device.Protocols}
+		for _, pw := range watchers {
+			if pw.DiscoveredDevice.ProfileName != device.ProfileName {
+				continue
+			}
+			if _, matched, _ := EvaluateAllowList(discovered, pw); !matched || EvaluateBlockList(discovered, pw) != nil {
+				continue
+			}
+			t.devices[device.Name] = &seenDevice{
+				provisionWatcher: pw.Name,
+				lastSeen:         now,
+				lastSeenRun:      t.run,
+				stale:            slices.Contains(device.Labels, sdkCommon.StaleLabel),
+			}
+			break
+		}
+	}
+}
+
+// updateStaleState marks the stale device DOWN with the ds-stale label, or the device discovered again UP without it
+func updateStaleState(ctx context.Context, device models.Device, stale bool, dic *di.Container) errors.EdgeX {
+	name := device.Name
+	state := string(models.Up)
+	labels := slices.DeleteFunc(slices.Clone(device.Labels), func(label string) bool {
+		return label == sdkCommon.StaleLabel
+	})
+	if stale {
+		state = string(models.Down)
+		labels = append(labels, sdkCommon.StaleLabel)
+	}
+	req := requests.UpdateDeviceRequest{
+		BaseRequest: commonDTO.NewBaseRequest(),
+		Device:      dtos.UpdateDevice{Name: &name, OperatingState: &state, Labels: labels},
+	}
+	_, err := bootstrapContainer.DeviceClientFrom(dic.Get).UpdateWithQueryParams(ctx, []requests.UpdateDeviceRequest{req},
+		map[string]string{sdkCommon.BypassValidationQueryParam: common.ValueTrue})
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.Kind(err), fmt.Sprintf("failed to update device %s", name), err)
+	}
+	return nil
+}
@@ -0,0 +1,190 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package autodiscovery
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	clientMocks "github.com/edgexfoundry/go-mod-core-contracts/v4/clients/interfaces/mocks"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/dtos/requests"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/device-sdk-go/v4/internal/cache"
+	sdkCommon "github.com/edgexfoundry/device-sdk-go/v4/internal/common"
+)
+
+func TestStalePolicyFrom(t *testing.T) {
+	tests := []struct {
+		name           string
+		labels         []string
+		expectedPolicy StalePolicy
+		expectedOk     bool
+		expectedErr    bool
+	}{
+		{"runs", []string{sdkCommon.StaleAfterLabelPrefix + "3"}, StalePolicy{Runs: 3, MarkDown: true}, true, false},
+		{"duration", []string{sdkCommon.StaleAfterLabelPrefix + "1h"}, StalePolicy{After: time.Hour, MarkDown: true}, true, false},
+		{"event only", []string{sdkCommon.StaleActionLabelPrefix + StaleActionEvent, sdkCommon.StaleAfterLabelPrefix + "2"}, StalePolicy{Runs: 2}, true, false},
+		{"not opted in", []string{sdkCommon.StaleActionLabelPrefix + StaleActionDown}, StalePolicy{MarkDown: true}, false, false},
+		{"invalid - zero runs", []string{sdkCommon.StaleAfterLabelPrefix + "0"}, StalePolicy{}, false, true},
+		{"invalid - negative duration", []string{sdkCommon.StaleAfterLabelPrefix + "-1m"}, StalePolicy{}, false, true},
+		{"invalid - value", []string{sdkCommon.StaleAfterLabelPrefix + "often"}, StalePolicy{}, false, true},
+		{"invalid - action", []string{sdkCommon.StaleAfterLabelPrefix + "2", sdkCommon.StaleActionLabelPrefix + "delete"}, StalePolicy{}, false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy, ok, err := StalePolicyFrom(models.ProvisionWatcher{Labels: tt.labels})
+			if tt.expectedErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedOk, ok)
+			assert.Equal(t, tt.expectedPolicy, policy)
+		})
+	}
+}
+
+func resetTracker(loaded bool) {
+	tracker.mux.Lock()
+	defer tracker.mux.Unlock()
+	tracker.devices = make(map[string]*seenDevice)
+	tracker.run = 0
+	tracker.loaded = loaded
+}
+
+func TestCheckStaleDevices(t *testing.T) {
+	pw := models.ProvisionWatcher{Name: "meter-watcher", Labels: []string{sdkCommon.StaleAfterLabelPrefix + "2"}}
+	eventPw := models.ProvisionWatcher{Name: "event-watcher", Labels: []string{sdkCommon.StaleAfterLabelPrefix + "2", sdkCommon.StaleActionLabelPrefix + StaleActionEvent}}
+
+	var updates []dtos.UpdateDevice
+	dcMock := &clientMocks.DeviceClient{}
+	dcMock.On("UpdateWithQueryParams", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).
+		Run(func(args mock.Arguments) {
+			for _, req := range args.Get(1).([]requests.UpdateDeviceRequest) {
+				updates = append(updates, req.Device)
+			}
+		})
+	dic := mockDic(false, dcMock)
+	cache.InitCacheFromSnapshot(cache.Snapshot{
+		Devices: []dtos.Device{
+			{Name: "meter-01", OperatingState: string(models.Up)},
+			{Name: "meter-02", OperatingState: string(models.Up)},
+			{Name: "meter-03", OperatingState: string(models.Up)},
+		},
+		ProvisionWatchers: []dtos.ProvisionWatcher{dtos.FromProvisionWatcherModelToDTO(pw), dtos.FromProvisionWatcherModelToDTO(eventPw)},
+	}, dic)
+	resetTracker(true)
+
+	ctx := context.Background()
+	run := func(seen ...string) {
+		checkStaleDevices(ctx, dic)
+		for _, name := range seen {
+			p := pw
+			if name == "meter-02" {
+				p = eventPw
+			}
+			MarkDeviceSeen(ctx, name, p, dic)
+		}
+	}
+	// meter-03 is matched by a provision watcher which doesn't opt in
+	MarkDeviceSeen(ctx, "meter-03", models.ProvisionWatcher{Name: "other-watcher"}, dic)
+	run("meter-01", "meter-02")
+	run()
+	run()
+	assert.Empty(t, updates, "device is stale before missing 2 discovery runs")
+
+	run()
+	require.Len(t, updates, 1, "only meter-01 is marked down")
+	assert.Equal(t, "meter-01", *updates[0].Name)
+	assert.Equal(t, string(models.Down), *updates[0].OperatingState)
+	assert.Equal(t, []string{sdkCommon.StaleLabel}, updates[0].Labels)
+	tracker.mux.Lock()
+	assert.True(t, tracker.devices["meter-01"].stale)
+	assert.True(t, tracker.devices["meter-02"].stale)
+	assert.NotContains(t, tracker.devices, "meter-03")
+	tracker.mux.Unlock()
+
+	// the stale device is reported once
+	run()
+	assert.Len(t, updates, 1)
+
+	// the stale device marked down is marked up when it is discovered again
+	require.NoError(t, cache.Devices().Update(models.Device{Name: "meter-01", OperatingState: models.Down, Labels: []string{"meter", sdkCommon.StaleLabel}}))
+	run("meter-01")
+	require.Len(t, updates, 2)
+	assert.Equal(t, string(models.Up), *updates[1].OperatingState)
+	assert.Equal(t, []string{"meter"}, updates[1].Labels)
+	tracker.mux.Lock()
+	assert.False(t, tracker.devices["meter-01"].stale)
+	tracker.mux.Unlock()
+}
+
+func TestCheckStaleDevices_restart(t *testing.T) {
+	pw := models.ProvisionWatcher{
+		Name:             "meter-watcher",
+		Labels:           []string{sdkCommon.StaleAfterLabelPrefix + "2"},
+		Identifiers:      map[string]string{"Address": "10\\.0\\..*"},
+		DiscoveredDevice: models.DiscoveredDevice{ProfileName: "meter"},
+	}
+	var updates []dtos.UpdateDevice
+	dcMock := &clientMocks.DeviceClient{}
+	dcMock.On("UpdateWithQueryParams", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).
+		Run(func(args mock.Arguments) {
+			for _, req := range args.Get(1).([]requests.UpdateDeviceRequest) {
+				updates = append(updates, req.Device)
+			}
+		})
+	dic := mockDic(false, dcMock)
+	protocols := func(address string) map[string]dtos.ProtocolProperties {
+		return map[string]dtos.ProtocolProperties{"modbus-tcp": {"Address": address}}
+	}
+	cache.InitCacheFromSnapshot(cache.Snapshot{
+		Devices: []dtos.Device{
+			{Name: "meter-01", ProfileName: "meter", Protocols: protocols("10.0.0.1"), OperatingState: string(models.Up)},
+			{Name: "meter-02", ProfileName: "meter", Protocols: protocols("10.0.0.2"), OperatingState: string(models.Down), Labels: []string{sdkCommon.StaleLabel}},
+			// down for another reason than the stale detection
+			{Name: "meter-04", ProfileName: "meter", Protocols: protocols("10.0.0.5"), OperatingState: string(models.Down)},
+			// not matched by the provision watcher
+			{Name: "meter-03", ProfileName: "meter", Protocols: protocols("192.168.0.3"), OperatingState: string(models.Up)},
+			{Name: "sensor-01", ProfileName: "sensor", Protocols: protocols("10.0.0.4"), OperatingState: string(models.Up)},
+		},
+		ProvisionWatchers: []dtos.ProvisionWatcher{dtos.FromProvisionWatcherModelToDTO(pw)},
+	}, dic)
+	resetTracker(false)
+
+	// the cached devices are tracked after the restart, the device marked down by the stale detection is stale
+	ctx := context.Background()
+	checkStaleDevices(ctx, dic)
+	tracker.mux.Lock()
+	require.Len(t, tracker.devices, 3)
+	assert.False(t, tracker.devices["meter-01"].stale)
+	assert.True(t, tracker.devices["meter-02"].stale)
+	assert.False(t, tracker.devices["meter-04"].stale)
+	tracker.mux.Unlock()
+
+	// the device down for another reason is not marked up when it is discovered
+	MarkDeviceSeen(ctx, "meter-04", pw, dic)
+	assert.Empty(t, updates)
+
+	checkStaleDevices(ctx, dic)
+	checkStaleDevices(ctx, dic)
+	require.Len(t, updates, 1, "only the device which was not stale nor seen is marked down")
+	assert.Equal(t, "meter-01", *updates[0].Name)
+	assert.Equal(t, string(models.Down), *updates[0].OperatingState)
+
+	// the device down before the restart is marked up when it is discovered again
+	MarkDeviceSeen(ctx, "meter-02", pw, dic)
+	require.Len(t, updates, 2)
+	assert.Equal(t, "meter-02", *updates[1].Name)
+	assert.Equal(t, string(models.Up), *updates[1].OperatingState)
+}
@@ -109,7 +109,7 @@ func readResource(e *Executor, dic *di.Container) (event *dtos.Event, err errors
 	vars[common.Name] = e.deviceName
 	vars[common.Command] = e.sourceName
 
-	res, err := application.GetCommand(context.Background(), e.deviceName, e.sourceName, "", true, dic)
+	res, err := application.GetCommand(context.Background(), e.deviceName, e.sourceName, "", true, "", dic)
 	if err != nil {
 		return event, err
 	}
@@ -0,0 +1,96 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
+)
+
+// deviceIndex indexes the device names by the field values, so the devices can be queried without scanning the cache
+type deviceIndex struct {
+	labels          map[string]map[string]struct{}
+	profiles        map[string]map[string]struct{}
+	adminStates     map[models.AdminState]map[string]struct{}
+	operatingStates map[models.OperatingState]map[string]struct{}
+	// key is protocolPropertyKey(protocol, property, value), every property is indexed both under its protocol and
+	// the empty protocol which matches any protocol
+	protocolProperties map[string]map[string]struct{}
+}
+
+func newDeviceIndex() *deviceIndex {
+	return &deviceIndex{
+		labels:             make(map[string]map[string]struct{}),
+		profiles:           make(map[string]map[string]struct{}),
+		adminStates:        make(map[models.AdminState]map[string]struct{}),
+		operatingStates:    make(map[models.OperatingState]map[string]struct{}),
+		protocolProperties: make(map[string]map[string]struct{}),
+	}
+}
+
+func protocolPropertyKey(protocol string, property string, value string) string {
+	return fmt.Sprintf("%s\x00%s\x00%s", protocol, property, value)
+}
+
+func addToIndex[K comparable](index map[K]map[string]struct{}, key K, name string) {
+	names, ok := index[key]
+	if !ok {
+		names = make(map[string]struct{})
+		index[key] = names
+	}
+	names[name] = struct{}{}
+}
+
+func removeFromIndex[K comparable](index map[K]map[string]struct{}, key K, name string) {
+	names, ok := index[key]
+	if !ok {
+		return
+	}
+	delete(names, name)
+	if len(names) == 0 {
+		delete(index, key)
+	}
+}
+
+func (i *deviceIndex) add(device *models.Device) {
+	i.update(device, addToIndex[string], addToIndex[models.AdminState], addToIndex[models.OperatingState])
+}
+
+func (i *deviceIndex) remove(device *models.Device) {
+	i.update(device, removeFromIndex[string], removeFromIndex[models.AdminState], removeFromIndex[models.OperatingState])
+}
+
+func (i *deviceIndex) update(device *models.Device,
+	updateString func(map[string]map[string]struct{}, string, string),
+	updateAdminState func(map[models.AdminState]map[string]struct{}, models.AdminState, string),
+	updateOperatingState func(map[models.OperatingState]map[string]struct{}, models.OperatingState, string)) {
+	for _, label := range device.Labels {
+		updateString(i.labels, label, device.Name)
+	}
+	updateString(i.profiles, device.ProfileName, device.Name)
+	updateAdminState(i.adminStates, device.AdminState, device.Name)
+	updateOperatingState(i.operatingStates, device.OperatingState, device.Name)
+	for protocol, properties := range device.Protocols {
+		for property, value := range properties {
+			v := fmt.Sprintf("%v", value)
+			updateString(i.protocolProperties, protocolPropertyKey(protocol, property, v), device.Name)
+			updateString(i.protocolProperties, protocolPropertyKey("", property, v), device.Name)
+		}
+	}
+}
+
+// sortedNames returns the names in the set sorted, so the query results are in a stable order
+func sortedNames(names map[string]struct{}) []string {
+	result := make([]string, 0, len(names))
+	for name := range names {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return result
+}
@@ -1,6 +1,6 @@
 // -*- Mode: Go; indent-tabs-mode: t -*-
 //
-// Copyright (C) 2020-2025 IOTech Ltd
+// Copyright (C) 2020-2026 IOTech Ltd
 //
 // SPDX-License-Identifier: Apache-2.0
 
@@ -18,6 +18,8 @@ import (
 	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
 
 	gometrics "github.com/rcrowley/go-metrics"
+
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v4/pkg/models"
 )
 
 const (
@@ -38,10 +40,16 @@ type DeviceCache interface {
 	UpdateAdminState(name string, state models.AdminState) errors.EdgeX
 	SetLastConnectedByName(name string)
 	GetLastConnectedByName(name string) int64
+	ForLabel(label string) []models.Device
+	ForProfileName(profileName string) []models.Device
+	ForAdminState(state models.AdminState) []models.Device
+	ForOperatingState(state models.OperatingState) []models.Device
+	ForProtocolProperty(protocol string, property string, value string) []models.Device
 }
 
 type deviceCache struct {
 	deviceMap     map[string]*models.Device // key is Device name
+	index         *deviceIndex
 	mutex         sync.RWMutex
 	dic           *di.Container
 	lastConnected map[string]gometrics.Gauge
@@ -50,10 +58,11 @@ type deviceCache struct {
 func newDeviceCache(devices []models.Device, dic *di.Container) DeviceCache {
 	defaultSize := len(devices)
 	dMap := make(map[string]*models.Device, defaultSize)
-	dc = &deviceCache{deviceMap: dMap, dic: dic}
+	dc = &deviceCache{deviceMap: dMap, index: newDeviceIndex(), dic: dic}
 	lastConnectedMetrics := make(map[string]gometrics.Gauge)
 	for _, d := range devices {
 		dMap[d.Name] = &d
+		dc.index.add(&d)
 		deviceMetric := gometrics.NewGauge()
 		registerMetric(d.Name, deviceMetric, dic)
 		lastConnectedMetrics[d.Name] = deviceMetric
@@ -114,10 +123,17 @@ func (d *deviceCache) All() []models.Device {
 // Add adds a new device to the cache. This method is used to populate the
 // device cache with pre-existing or recently-added devices from Core Metadata.
 func (d *deviceCache) Add(device models.Device) errors.EdgeX {
+	defer deviceWatchers.lockChanges()()
 	d.mutex.Lock()
-	defer d.mutex.Unlock()
+	err := d.add(device)
+	after := clonePtr(device)
+	d.mutex.Unlock()
 
-	return d.add(device)
+	if err != nil {
+		return err
+	}
+	deviceWatchers.notify(sdkModels.DeviceChange{Action: sdkModels.CacheChangeAdd, After: after})
+	return nil
 }
 
 func (d *deviceCache) add(device models.Device) errors.EdgeX {
@@ -127,6 +143,7 @@ func (d *deviceCache) add(device models.Device) errors.EdgeX {
 	}
 
 	d.deviceMap[device.Name] = &device
+	d.index.add(&device)
 
 	// register the lastConnected metric for the new added device
 	deviceMetric := gometrics.NewGauge()
@@ -137,30 +154,60 @@ func (d *deviceCache) add(device models.Device) errors.EdgeX {
 
 // Update updates the device in the cache
 func (d *deviceCache) Update(device models.Device) errors.EdgeX {
+	defer deviceWatchers.lockChanges()()
 	d.mutex.Lock()
-	defer d.mutex.Unlock()
+	before, err := d.clone(device.Name)
+	if err == nil {
+		err = d.removeByName(device.Name)
+	}
+	if err == nil {
+		err = d.add(device)
+	}
+	after := clonePtr(device)
+	d.mutex.Unlock()
 
-	if err := d.removeByName(device.Name); err != nil {
+	if err != nil {
 		return err
 	}
-	return d.add(device)
+	deviceWatchers.notify(sdkModels.DeviceChange{Action: sdkModels.CacheChangeUpdate, Before: before, After: after})
+	return nil
 }
 
 // RemoveByName removes the specified device by name from the cache.
 func (d *deviceCache) RemoveByName(name string) errors.EdgeX {
+	defer deviceWatchers.lockChanges()()
 	d.mutex.Lock()
-	defer d.mutex.Unlock()
+	before, err := d.clone(name)
+	if err == nil {
+		err = d.removeByName(name)
+	}
+	d.mutex.Unlock()
 
-	return d.removeByName(name)
+	if err != nil {
+		return err
+	}
+	deviceWatchers.notify(sdkModels.DeviceChange{Action: sdkModels.CacheChangeRemove, Before: before})
+	return nil
+}
+
+// clone returns the clone of the cached device, the lock must be held by the caller
+func (d *deviceCache) clone(name string) (*models.Device, errors.EdgeX) {
+	device, ok := d.deviceMap[name]
+	if !ok {
+		errMsg := fmt.Sprintf("failed to find Device %s in cache", name)
+		return nil, errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, errMsg, nil)
+	}
+	return clonePtr(*device), nil
 }
 
 func (d *deviceCache) removeByName(name string) errors.EdgeX {
-	_, ok := d.deviceMap[name]
+	device, ok := d.deviceMap[name]
 	if !ok {
 		errMsg := fmt.Sprintf("failed to find Device %s in cache", name)
 		return errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, errMsg, nil)
 	}
 
+	d.index.remove(device)
 	delete(d.deviceMap, name)
 
 	// unregister the lastConnected metric for the removed device
@@ -178,27 +225,81 @@ func (d *deviceCache) UpdateAdminState(name string, state models.AdminState) err
 		return errors.NewCommonEdgeX(errors.KindContractInvalid, "invalid AdminState", nil)
 	}
 
+	defer deviceWatchers.lockChanges()()
 	d.mutex.Lock()
-	defer d.mutex.Unlock()
-
-	_, ok := d.deviceMap[name]
-	if !ok {
-		errMsg := fmt.Sprintf("failed to find Device %s in cache", name)
-		return errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, errMsg, nil)
+	before, err := d.clone(name)
+	var after *models.Device
+	if err == nil {
+		device := d.deviceMap[name]
+		removeFromIndex(d.index.adminStates, device.AdminState, name)
+		device.AdminState = state
+		addToIndex(d.index.adminStates, device.AdminState, name)
+		after = clonePtr(*device)
 	}
+	d.mutex.Unlock()
 
-	d.deviceMap[name].AdminState = state
+	if err != nil {
+		return err
+	}
+	deviceWatchers.notify(sdkModels.DeviceChange{Action: sdkModels.CacheChangeUpdate, Before: before, After: after})
 	return nil
 }
 
-func CheckProfileNotUsed(profileName string) bool {
-	for _, device := range dc.deviceMap {
-		if device.ProfileName == profileName {
-			return false
-		}
+// ForLabel returns the devices with the given label.
+func (d *deviceCache) ForLabel(label string) []models.Device {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	return d.devicesFor(d.index.labels[label])
+}
+
+// ForProfileName returns the devices associated with the given profile.
+func (d *deviceCache) ForProfileName(profileName string) []models.Device {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	return d.devicesFor(d.index.profiles[profileName])
+}
+
+// ForAdminState returns the devices in the given admin state.
+func (d *deviceCache) ForAdminState(state models.AdminState) []models.Device {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	return d.devicesFor(d.index.adminStates[state])
+}
+
+// ForOperatingState returns the devices in the given operating state.
+func (d *deviceCache) ForOperatingState(state models.OperatingState) []models.Device {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	return d.devicesFor(d.index.operatingStates[state])
+}
+
+// ForProtocolProperty returns the devices whose protocol property has the given value, the values are compared in
+// their string form. The empty protocol matches the property of any protocol.
+func (d *deviceCache) ForProtocolProperty(protocol string, property string, value string) []models.Device {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	return d.devicesFor(d.index.protocolProperties[protocolPropertyKey(protocol, property, value)])
+}
+
+// devicesFor returns the clones of the devices in the name set, sorted by name
+func (d *deviceCache) devicesFor(names map[string]struct{}) []models.Device {
+	devices := make([]models.Device, 0, len(names))
+	for _, name := range sortedNames(names) {
+		devices = append(devices, d.deviceMap[name].Clone())
 	}
+	return devices
+}
+
+func CheckProfileNotUsed(profileName string) bool {
+	dc.mutex.RLock()
+	defer dc.mutex.RUnlock()
 
-	return true
+	return len(dc.index.profiles[profileName]) == 0
 }
 
 func Devices() DeviceCache {
@@ -185,3 +185,66 @@ func Test_deviceCache_GetLastConnectedByName(t *testing.T) {
 	lastConnectedTime := dc.GetLastConnectedByName(TestDevice)
 	require.Equal(t, int64(0), lastConnectedTime)
 }
+
+func Test_deviceCache_indexedQueries(t *testing.T) {
+	gateway1 := models.Device{
+		Name:           "gateway1-device",
+		ProfileName:    TestProfile,
+		Labels:         []string{"floor1", "hvac"},
+		AdminState:     models.Unlocked,
+		OperatingState: models.Up,
+		Protocols:      map[string]models.ProtocolProperties{"modbus-tcp": {"Address": "10.0.0.5", "UnitID": 1}},
+	}
+	gateway2 := models.Device{
+		Name:           "gateway2-device",
+		ProfileName:    TestProfile,
+		Labels:         []string{"floor2", "hvac"},
+		AdminState:     models.Locked,
+		OperatingState: models.Down,
+		Protocols:      map[string]models.ProtocolProperties{"bacnet-ip": {"Address": "10.0.0.5"}},
+	}
+	dic := mockDic()
+	newDeviceCache([]models.Device{gateway2, gateway1, testDevice}, dic)
+
+	names := func(devices []models.Device) []string {
+		result := make([]string, 0, len(devices))
+		for _, d := range devices {
+			result = append(result, d.Name)
+		}
+		return result
+	}
+
+	tests := []struct {
+		name     string
+		query    func() []models.Device
+		expected []string
+	}{
+		{"label", func() []models.Device { return dc.ForLabel("hvac") }, []string{gateway1.Name, gateway2.Name}},
+		{"label - not found", func() []models.Device { return dc.ForLabel("floor3") }, []string{}},
+		{"profile", func() []models.Device { return dc.ForProfileName(TestProfile) }, []string{gateway1.Name, gateway2.Name}},
+		{"admin state", func() []models.Device { return dc.ForAdminState(models.Unlocked) }, []string{gateway1.Name, TestDevice}},
+		{"operating state", func() []models.Device { return dc.ForOperatingState(models.Down) }, []string{gateway2.Name}},
+		{"protocol property", func() []models.Device { return dc.ForProtocolProperty("modbus-tcp", "Address", "10.0.0.5") }, []string{gateway1.Name}},
+		{"protocol property - any protocol", func() []models.Device { return dc.ForProtocolProperty("", "Address", "10.0.0.5") }, []string{gateway1.Name, gateway2.Name}},
+		{"protocol property - non-string value", func() []models.Device { return dc.ForProtocolProperty("modbus-tcp", "UnitID", "1") }, []string{gateway1.Name}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, names(tt.query()))
+		})
+	}
+
+	// the indexes follow the changes of the devices
+	updated := gateway1.Clone()
+	updated.Labels = []string{"floor1"}
+	updated.ProfileName = "newProfile"
+	require.NoError(t, dc.Update(updated))
+	require.NoError(t, dc.UpdateAdminState(gateway2.Name, models.Unlocked))
+	require.NoError(t, dc.RemoveByName(TestDevice))
+	assert.Equal(t, []string{gateway2.Name}, names(dc.ForLabel("hvac")))
+	assert.Equal(t, []string{gateway1.Name, gateway2.Name}, names(dc.ForAdminState(models.Unlocked)))
+	assert.Empty(t, dc.ForAdminState(models.Locked))
+	assert.False(t, CheckProfileNotUsed(TestProfile))
+	require.NoError(t, dc.RemoveByName(gateway2.Name))
+	assert.True(t, CheckProfileNotUsed(TestProfile))
+}
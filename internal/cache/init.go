@@ -19,22 +19,35 @@ import (
 
 // InitCache Init basic state for cache
 func InitCache(instanceName string, baseServiceName string, dic *di.Container) errors.EdgeX {
+	devices, profiles, pws, err := FetchMetadata(instanceName, baseServiceName, dic)
+	if err != nil {
+		return err
+	}
+	newDeviceCache(devices, dic)
+	newProfileCache(profiles)
+	newProvisionWatcherCache(pws)
+
+	return nil
+}
+
+// FetchMetadata retrieves the devices of the device service, their profiles and the provision watchers of the device
+// service from core-metadata
+func FetchMetadata(instanceName string, baseServiceName string, dic *di.Container) ([]models.Device, []models.DeviceProfile, []models.ProvisionWatcher, errors.EdgeX) {
 	dc := bootstrapContainer.DeviceClientFrom(dic.Get)
 	dpc := bootstrapContainer.DeviceProfileClientFrom(dic.Get)
 	pwc := bootstrapContainer.ProvisionWatcherClientFrom(dic.Get)
 
-	// init device cache
+	// devices
 	deviceRes, err := dc.DevicesByServiceName(context.Background(), instanceName, 0, -1)
 	if err != nil {
-		return err
+		return nil, nil, nil, err
 	}
 	devices := make([]models.Device, len(deviceRes.Devices))
 	for i := range deviceRes.Devices {
 		devices[i] = dtos.ToDeviceModel(deviceRes.Devices[i])
 	}
-	newDeviceCache(devices, dic)
 
-	// init profile cache
+	// profiles
 	profiles := make([]models.DeviceProfile, 0, len(devices))
 	for _, d := range devices {
 		if len(d.ProfileName) == 0 {
@@ -42,24 +55,22 @@ func InitCache(instanceName string, baseServiceName string, dic *di.Container) e
 		}
 		res, err := dpc.DeviceProfileByName(context.Background(), d.ProfileName)
 		if err != nil {
-			return err
+			return nil, nil, nil, err
 		}
 		profiles = append(profiles, dtos.ToDeviceProfileModel(res.Profile))
 	}
-	newProfileCache(profiles)
 
-	// init provision watcher cache
+	// provision watchers
 	// baseServiceName is the service name w/o the instance portion added when -i/--instance flag is used.
 	// Using baseServiceName here since ProvisionWatchers are used by all instances of the device service and thus have the ServiceName set to the baseServiceName.
 	pwRes, err := pwc.ProvisionWatchersByServiceName(context.Background(), baseServiceName, 0, -1)
 	if err != nil {
-		return err
+		return nil, nil, nil, err
 	}
 	pws := make([]models.ProvisionWatcher, len(pwRes.ProvisionWatchers))
 	for i := range pwRes.ProvisionWatchers {
 		pws[i] = dtos.ToProvisionWatcherModel(pwRes.ProvisionWatchers[i])
 	}
-	newProvisionWatcherCache(pws)
 
-	return nil
+	return devices, profiles, pws, nil
 }
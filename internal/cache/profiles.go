@@ -1,6 +1,6 @@
 // -*- Mode: Go; indent-tabs-mode: t -*-
 //
-// Copyright (C) 2020-2025 IOTech Ltd
+// Copyright (C) 2020-2026 IOTech Ltd
 //
 // SPDX-License-Identifier: Apache-2.0
 
@@ -13,6 +13,8 @@ import (
 
 	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
 	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
+
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v4/pkg/models"
 )
 
 var (
@@ -89,21 +91,35 @@ func (p *profileCache) All() []models.DeviceProfile {
 // Add adds a new profile to the cache. This method is used to populate the
 // profile cache with pre-existing or recently-added profiles from Core Metadata.
 func (p *profileCache) Add(profile models.DeviceProfile) errors.EdgeX {
+	defer profileWatchers.lockChanges()()
 	p.mutex.Lock()
-	defer p.mutex.Unlock()
+	err := p.add(profile)
+	after := clonePtr(profile)
+	p.mutex.Unlock()
 
-	return p.add(profile)
+	if err != nil {
+		return err
+	}
+	profileWatchers.notify(sdkModels.DeviceProfileChange{Action: sdkModels.CacheChangeAdd, After: after})
+	return nil
 }
 
 func (p *profileCache) CheckAndAdd(profile models.DeviceProfile) errors.EdgeX {
+	defer profileWatchers.lockChanges()()
 	p.mutex.Lock()
-	defer p.mutex.Unlock()
-
 	if _, ok := p.deviceProfileMap[profile.Name]; ok {
+		p.mutex.Unlock()
 		return nil
-	} else {
-		return p.add(profile)
 	}
+	err := p.add(profile)
+	after := clonePtr(profile)
+	p.mutex.Unlock()
+
+	if err != nil {
+		return err
+	}
+	profileWatchers.notify(sdkModels.DeviceProfileChange{Action: sdkModels.CacheChangeAdd, After: after})
+	return nil
 }
 
 func (p *profileCache) add(profile models.DeviceProfile) errors.EdgeX {
@@ -138,22 +154,50 @@ func deviceCommandSliceToMap(deviceCommands []models.DeviceCommand) map[string]m
 
 // Update updates the profile in the cache
 func (p *profileCache) Update(profile models.DeviceProfile) errors.EdgeX {
+	defer profileWatchers.lockChanges()()
 	p.mutex.Lock()
-	defer p.mutex.Unlock()
+	before, err := p.clone(profile.Name)
+	if err == nil {
+		err = p.removeByName(profile.Name)
+	}
+	if err == nil {
+		err = p.add(profile)
+	}
+	after := clonePtr(profile)
+	p.mutex.Unlock()
 
-	if err := p.removeByName(profile.Name); err != nil {
+	if err != nil {
 		return err
 	}
-
-	return p.add(profile)
+	profileWatchers.notify(sdkModels.DeviceProfileChange{Action: sdkModels.CacheChangeUpdate, Before: before, After: after})
+	return nil
 }
 
 // RemoveByName removes the specified profile by name from the cache.
 func (p *profileCache) RemoveByName(name string) errors.EdgeX {
+	defer profileWatchers.lockChanges()()
 	p.mutex.Lock()
-	defer p.mutex.Unlock()
+	before, err := p.clone(name)
+	if err == nil {
+		err = p.removeByName(name)
+	}
+	p.mutex.Unlock()
 
-	return p.removeByName(name)
+	if err != nil {
+		return err
+	}
+	profileWatchers.notify(sdkModels.DeviceProfileChange{Action: sdkModels.CacheChangeRemove, Before: before})
+	return nil
+}
+
+// clone returns the clone of the cached profile, the lock must be held by the caller
+func (p *profileCache) clone(name string) (*models.DeviceProfile, errors.EdgeX) {
+	profile, ok := p.deviceProfileMap[name]
+	if !ok {
+		errMsg := fmt.Sprintf("failed to find Profile %s in cache", name)
+		return nil, errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, errMsg, nil)
+	}
+	return clonePtr(*profile), nil
 }
 
 func (p *profileCache) removeByName(name string) errors.EdgeX {
@@ -11,6 +11,8 @@ import (
 
 	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
 	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
+
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v4/pkg/models"
 )
 
 var (
@@ -72,10 +74,17 @@ func (p *provisionWatcherCache) All() []models.ProvisionWatcher {
 
 // Add adds a new provision watcher to the cache.
 func (p *provisionWatcherCache) Add(watcher models.ProvisionWatcher) errors.EdgeX {
+	defer provisionWatcherWatchers.lockChanges()()
 	p.mutex.Lock()
-	defer p.mutex.Unlock()
+	err := p.add(watcher)
+	after := clonePtr(watcher)
+	p.mutex.Unlock()
 
-	return p.add(watcher)
+	if err != nil {
+		return err
+	}
+	provisionWatcherWatchers.notify(sdkModels.ProvisionWatcherChange{Action: sdkModels.CacheChangeAdd, After: after})
+	return nil
 }
 
 func (p *provisionWatcherCache) add(watcher models.ProvisionWatcher) errors.EdgeX {
@@ -90,21 +99,50 @@ func (p *provisionWatcherCache) add(watcher models.ProvisionWatcher) errors.Edge
 
 // Update updates the provision watcher in the cache
 func (p *provisionWatcherCache) Update(watcher models.ProvisionWatcher) errors.EdgeX {
+	defer provisionWatcherWatchers.lockChanges()()
 	p.mutex.Lock()
-	defer p.mutex.Unlock()
+	before, err := p.clone(watcher.Name)
+	if err == nil {
+		err = p.removeByName(watcher.Name)
+	}
+	if err == nil {
+		err = p.add(watcher)
+	}
+	after := clonePtr(watcher)
+	p.mutex.Unlock()
 
-	if err := p.removeByName(watcher.Name); err != nil {
+	if err != nil {
 		return err
 	}
-	return p.add(watcher)
+	provisionWatcherWatchers.notify(sdkModels.ProvisionWatcherChange{Action: sdkModels.CacheChangeUpdate, Before: before, After: after})
+	return nil
 }
 
 // RemoveByName removes the specified provision watcher by name from the cache.
 func (p *provisionWatcherCache) RemoveByName(name string) errors.EdgeX {
+	defer provisionWatcherWatchers.lockChanges()()
 	p.mutex.Lock()
-	defer p.mutex.Unlock()
+	before, err := p.clone(name)
+	if err == nil {
+		err = p.removeByName(name)
+	}
+	p.mutex.Unlock()
+
+	if err != nil {
+		return err
+	}
+	provisionWatcherWatchers.notify(sdkModels.ProvisionWatcherChange{Action: sdkModels.CacheChangeRemove, Before: before})
+	return nil
+}
 
-	return p.removeByName(name)
+// clone returns the clone of the cached provision watcher, the lock must be held by the caller
+func (p *provisionWatcherCache) clone(name string) (*models.ProvisionWatcher, errors.EdgeX) {
+	watcher, ok := p.pwMap[name]
+	if !ok {
+		errMsg := fmt.Sprintf("failed to find ProvisionWatcher %s in cache", name)
+		return nil, errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, errMsg, nil)
+	}
+	return clonePtr(*watcher), nil
 }
 
 func (p *provisionWatcherCache) removeByName(name string) errors.EdgeX {
@@ -124,16 +162,20 @@ func (p *provisionWatcherCache) UpdateAdminState(name string, state models.Admin
 		return errors.NewCommonEdgeX(errors.KindContractInvalid, "invalid AdminState", nil)
 	}
 
+	defer provisionWatcherWatchers.lockChanges()()
 	p.mutex.Lock()
-	defer p.mutex.Unlock()
-
-	_, ok := p.pwMap[name]
-	if !ok {
-		errMsg := fmt.Sprintf("failed to find ProvisionWatcher %s in cache", name)
-		return errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, errMsg, nil)
+	before, err := p.clone(name)
+	var after *models.ProvisionWatcher
+	if err == nil {
+		p.pwMap[name].AdminState = state
+		after = clonePtr(*p.pwMap[name])
 	}
+	p.mutex.Unlock()
 
-	p.pwMap[name].AdminState = state
+	if err != nil {
+		return err
+	}
+	provisionWatcherWatchers.notify(sdkModels.ProvisionWatcherChange{Action: sdkModels.CacheChangeUpdate, Before: before, After: after})
 	return nil
 }
 
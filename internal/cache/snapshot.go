@@ -0,0 +1,158 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v4/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v4/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
+)
+
+// Snapshot is the local copy of the device service and the device, profile and provision watcher caches, which
+// allows the device service to start when core-metadata is unavailable
+type Snapshot struct {
+	Created           int64
+	DeviceService     dtos.DeviceService
+	Devices           []dtos.Device
+	Profiles          []dtos.DeviceProfile
+	ProvisionWatchers []dtos.ProvisionWatcher
+}
+
+// NewSnapshot creates a Snapshot of the device service and the current caches, sorted by name
+func NewSnapshot(ds models.DeviceService) Snapshot {
+	devices := Devices().All()
+	sort.Slice(devices, func(i, j int) bool { return devices[i].Name < devices[j].Name })
+	profiles := Profiles().All()
+	sort.Slice(profiles, func(i, j int) bool { return profiles[i].Name < profiles[j].Name })
+	pws := ProvisionWatchers().All()
+	sort.Slice(pws, func(i, j int) bool { return pws[i].Name < pws[j].Name })
+
+	snapshot := Snapshot{
+		DeviceService:     dtos.FromDeviceServiceModelToDTO(ds),
+		Devices:           make([]dtos.Device, len(devices)),
+		Profiles:          make([]dtos.DeviceProfile, len(profiles)),
+		ProvisionWatchers: make([]dtos.ProvisionWatcher, len(pws)),
+	}
+	for i, d := range devices {
+		snapshot.Devices[i] = dtos.FromDeviceModelToDTO(d)
+	}
+	for i, p := range profiles {
+		snapshot.Profiles[i] = dtos.FromDeviceProfileModelToDTO(p)
+	}
+	for i, pw := range pws {
+		snapshot.ProvisionWatchers[i] = dtos.FromProvisionWatcherModelToDTO(pw)
+	}
+	return snapshot
+}
+
+// WriteSnapshot writes the Snapshot to the file, the file is replaced atomically so a power failure during the write
+// does not corrupt the previous snapshot
+func WriteSnapshot(path string, snapshot Snapshot) errors.EdgeX {
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, "failed to encode metadata snapshot", err)
+	}
+	if err = os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return errors.NewCommonEdgeX(errors.KindIOError, fmt.Sprintf("failed to create the directory of metadata snapshot %s", path), err)
+	}
+	tmpPath := path + ".tmp"
+	if err = os.WriteFile(tmpPath, data, 0600); err != nil {
+		return errors.NewCommonEdgeX(errors.KindIOError, fmt.Sprintf("failed to write metadata snapshot %s", path), err)
+	}
+	if err = os.Rename(tmpPath, path); err != nil {
+		return errors.NewCommonEdgeX(errors.KindIOError, fmt.Sprintf("failed to replace metadata snapshot %s", path), err)
+	}
+	return nil
+}
+
+// ReadSnapshot reads the Snapshot from the file
+func ReadSnapshot(path string) (Snapshot, errors.EdgeX) {
+	var snapshot Snapshot
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return snapshot, errors.NewCommonEdgeX(errors.KindIOError, fmt.Sprintf("failed to read metadata snapshot %s", path), err)
+	}
+	if err = json.Unmarshal(data, &snapshot); err != nil {
+		return snapshot, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("failed to decode metadata snapshot %s", path), err)
+	}
+	return snapshot, nil
+}
+
+// InitCacheFromSnapshot Init basic state for cache from the Snapshot, used when core-metadata is unavailable
+func InitCacheFromSnapshot(snapshot Snapshot, dic *di.Container) {
+	devices := make([]models.Device, len(snapshot.Devices))
+	for i := range snapshot.Devices {
+		devices[i] = dtos.ToDeviceModel(snapshot.Devices[i])
+	}
+	newDeviceCache(devices, dic)
+
+	profiles := make([]models.DeviceProfile, len(snapshot.Profiles))
+	for i := range snapshot.Profiles {
+		profiles[i] = dtos.ToDeviceProfileModel(snapshot.Profiles[i])
+	}
+	newProfileCache(profiles)
+
+	pws := make([]models.ProvisionWatcher, len(snapshot.ProvisionWatchers))
+	for i := range snapshot.ProvisionWatchers {
+		pws[i] = dtos.ToProvisionWatcherModel(snapshot.ProvisionWatchers[i])
+	}
+	newProvisionWatcherCache(pws)
+}
+
+// StartSnapshotSaver saves the snapshot of the caches to the file on every interval if the caches have changed, and
+// on the service shutdown
+func StartSnapshotSaver(ctx context.Context, wg *sync.WaitGroup, path string, interval time.Duration, deviceService func() models.DeviceService, dic *di.Container) {
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	var lastSaved []byte
+	save := func() {
+		snapshot := NewSnapshot(deviceService())
+		content, err := json.Marshal(snapshot)
+		if err != nil {
+			lc.Errorf("failed to encode metadata snapshot: %v", err)
+			return
+		}
+		if bytes.Equal(content, lastSaved) {
+			return
+		}
+		snapshot.Created = time.Now().UnixNano()
+		if err := WriteSnapshot(path, snapshot); err != nil {
+			lc.Errorf("failed to save metadata snapshot: %v", err)
+			return
+		}
+		lastSaved = content
+		lc.Debugf("metadata snapshot saved to %s", path)
+	}
+
+	save()
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				save()
+				return
+			case <-ticker.C:
+				save()
+			}
+		}
+	}()
+}
@@ -0,0 +1,103 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshot_writeAndRead(t *testing.T) {
+	dic := mockDic()
+	newDeviceCache([]models.Device{newDevice, testDevice}, dic)
+	newProfileCache([]models.DeviceProfile{testProfile})
+	newProvisionWatcherCache([]models.ProvisionWatcher{testProvisionWatcher})
+	ds := models.DeviceService{Name: "testService", AdminState: models.Unlocked}
+
+	snapshot := NewSnapshot(ds)
+	require.Len(t, snapshot.Devices, 2)
+	assert.Equal(t, newDevice.Name, snapshot.Devices[0].Name, "devices are not sorted by name")
+
+	path := filepath.Join(t.TempDir(), "snapshot", "metadata.json")
+	require.NoError(t, WriteSnapshot(path, snapshot))
+	_, err := os.Stat(path + ".tmp")
+	assert.True(t, os.IsNotExist(err), "temporary snapshot file is not removed")
+
+	res, edgexErr := ReadSnapshot(path)
+	require.NoError(t, edgexErr)
+	assert.Equal(t, snapshot.DeviceService.Name, res.DeviceService.Name)
+	assert.Len(t, res.Devices, 2)
+	assert.Len(t, res.Profiles, 1)
+	assert.Len(t, res.ProvisionWatchers, 1)
+
+	newDeviceCache(nil, dic)
+	newProfileCache(nil)
+	newProvisionWatcherCache(nil)
+	InitCacheFromSnapshot(res, dic)
+	_, ok := Devices().ForName(testDevice.Name)
+	assert.True(t, ok)
+	_, ok = Profiles().DeviceResource(testProfile.Name, testProfile.DeviceResources[0].Name)
+	assert.True(t, ok)
+	_, ok = ProvisionWatchers().ForName(testProvisionWatcher.Name)
+	assert.True(t, ok)
+}
+
+func TestReadSnapshot_invalid(t *testing.T) {
+	dir := t.TempDir()
+	invalid := filepath.Join(dir, "invalid.json")
+	require.NoError(t, os.WriteFile(invalid, []byte("{"), 0600))
+
+	tests := []struct {
+		name         string
+		path         string
+		expectedKind errors.ErrKind
+	}{
+		{"file not found", filepath.Join(dir, "nonexistent.json"), errors.KindIOError},
+		{"invalid content", invalid, errors.KindContractInvalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ReadSnapshot(tt.path)
+			require.Error(t, err)
+			assert.Equal(t, tt.expectedKind, errors.Kind(err))
+		})
+	}
+}
+
+func TestStartSnapshotSaver(t *testing.T) {
+	dic := mockDic()
+	newDeviceCache([]models.Device{testDevice}, dic)
+	newProfileCache(nil)
+	newProvisionWatcherCache(nil)
+	path := filepath.Join(t.TempDir(), "metadata.json")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	wg := &sync.WaitGroup{}
+	StartSnapshotSaver(ctx, wg, path, time.Hour, func() models.DeviceService {
+		return models.DeviceService{Name: "testService"}
+	}, dic)
+	snapshot, err := ReadSnapshot(path)
+	require.NoError(t, err)
+	assert.Len(t, snapshot.Devices, 1)
+
+	// the changes are saved on shutdown
+	require.NoError(t, Devices().Add(newDevice))
+	cancel()
+	wg.Wait()
+	snapshot, err = ReadSnapshot(path)
+	require.NoError(t, err)
+	assert.Len(t, snapshot.Devices, 2)
+}
@@ -0,0 +1,149 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"sync"
+
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v4/pkg/models"
+)
+
+var (
+	deviceWatchers           = newWatchers[sdkModels.DeviceChange]()
+	profileWatchers          = newWatchers[sdkModels.DeviceProfileChange]()
+	provisionWatcherWatchers = newWatchers[sdkModels.ProvisionWatcherChange]()
+)
+
+// watchers holds the subscriptions to the changes of a cache
+type watchers[T any] struct {
+	// changeMutex is held across a change of the cache and its notification, so the concurrent changes are queued in
+	// the order they are applied
+	changeMutex   sync.Mutex
+	mutex         sync.RWMutex
+	nextID        int
+	subscriptions map[int]*subscription[T]
+}
+
+// subscription delivers the changes to a handler from its own goroutine, in the order they are queued. The queue is
+// unbounded, so a slow handler delays only its own changes and never the changes of the cache.
+type subscription[T any] struct {
+	handler func(T)
+	mutex   sync.Mutex
+	queue   []T
+	// ready is signaled when a change is queued
+	ready chan struct{}
+	done  chan struct{}
+}
+
+func newWatchers[T any]() *watchers[T] {
+	return &watchers[T]{subscriptions: make(map[int]*subscription[T])}
+}
+
+// watch subscribes the handler and returns the function to cancel the subscription. The changes queued but not yet
+// delivered when the subscription is cancelled are dropped.
+func (w *watchers[T]) watch(handler func(T)) func() {
+	sub := &subscription[T]{handler: handler, ready: make(chan struct{}, 1), done: make(chan struct{})}
+	go sub.deliver()
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	id := w.nextID
+	w.nextID++
+	w.subscriptions[id] = sub
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			w.mutex.Lock()
+			delete(w.subscriptions, id)
+			w.mutex.Unlock()
+			close(sub.done)
+		})
+	}
+}
+
+// lockChanges serializes the changes of the cache with their notifications, it must be called before the cache lock is
+// taken and the returned function releases it after the change is notified
+func (w *watchers[T]) lockChanges() func() {
+	w.changeMutex.Lock()
+	return w.changeMutex.Unlock
+}
+
+// notify queues the change to every subscription without waiting for the handlers
+func (w *watchers[T]) notify(change T) {
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+	for _, sub := range w.subscriptions {
+		sub.push(change)
+	}
+}
+
+func (s *subscription[T]) push(change T) {
+	s.mutex.Lock()
+	s.queue = append(s.queue, change)
+	s.mutex.Unlock()
+	select {
+	case s.ready <- struct{}{}:
+	default:
+	}
+}
+
+// deliver calls the handler with the queued changes until the subscription is cancelled
+func (s *subscription[T]) deliver() {
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-s.ready:
+		}
+		for {
+			s.mutex.Lock()
+			if len(s.queue) == 0 {
+				s.mutex.Unlock()
+				break
+			}
+			change := s.queue[0]
+			var zero T
+			s.queue[0] = zero
+			s.queue = s.queue[1:]
+			s.mutex.Unlock()
+
+			select {
+			case <-s.done:
+				return
+			default:
+			}
+			s.handler(change)
+		}
+	}
+}
+
+// WatchDevices subscribes the handler to the changes of the device cache. The handler is called asynchronously from
+// its own goroutine, with the changes in the order they are applied to the cache, so a slow handler doesn't delay the
+// changes, and the handler may change the caches. Returns the function to cancel the subscription.
+func WatchDevices(handler func(sdkModels.DeviceChange)) func() {
+	return deviceWatchers.watch(handler)
+}
+
+// WatchDeviceProfiles subscribes the handler to the changes of the profile cache. The handler is called asynchronously
+// from its own goroutine, with the changes in the order they are applied to the cache. Returns the function to cancel
+// the subscription.
+func WatchDeviceProfiles(handler func(sdkModels.DeviceProfileChange)) func() {
+	return profileWatchers.watch(handler)
+}
+
+// WatchProvisionWatchers subscribes the handler to the changes of the provision watcher cache. The handler is called
+// asynchronously from its own goroutine, with the changes in the order they are applied to the cache. Returns the
+// function to cancel the subscription.
+func WatchProvisionWatchers(handler func(sdkModels.ProvisionWatcherChange)) func() {
+	return provisionWatcherWatchers.watch(handler)
+}
+
+// clonePtr returns a pointer to the clone of the value, so the notified snapshot is not shared with the cache
+func clonePtr[T interface{ Clone() T }](value T) *T {
+	c := value.Clone()
+	return &c
+}
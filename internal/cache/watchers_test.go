@@ -0,0 +1,186 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v4/pkg/models"
+)
+
+// changeRecorder records the changes delivered to the handler
+type changeRecorder[T any] struct {
+	mutex   sync.Mutex
+	changes []T
+}
+
+func (r *changeRecorder[T]) handle(change T) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.changes = append(r.changes, change)
+}
+
+// wait waits for the count of changes to be delivered and returns them
+func (r *changeRecorder[T]) wait(t *testing.T, count int) []T {
+	require.Eventually(t, func() bool {
+		r.mutex.Lock()
+		defer r.mutex.Unlock()
+		return len(r.changes) >= count
+	}, time.Second, 5*time.Millisecond)
+	// no further change is delivered
+	time.Sleep(20 * time.Millisecond)
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	require.Len(t, r.changes, count)
+	return r.changes
+}
+
+func TestWatchDevices(t *testing.T) {
+	newDeviceCache([]models.Device{testDevice}, mockDic())
+
+	var recorder changeRecorder[sdkModels.DeviceChange]
+	cancel := WatchDevices(func(change sdkModels.DeviceChange) {
+		_, ok := Devices().ForName(TestDevice)
+		assert.True(t, ok)
+		recorder.handle(change)
+	})
+
+	require.NoError(t, Devices().Add(newDevice))
+	require.Error(t, Devices().Add(newDevice))
+	updated := newDevice
+	updated.Labels = []string{"updated"}
+	require.NoError(t, Devices().Update(updated))
+	require.NoError(t, Devices().UpdateAdminState(newDevice.Name, models.Locked))
+	require.NoError(t, Devices().RemoveByName(newDevice.Name))
+	require.Error(t, Devices().RemoveByName(newDevice.Name))
+	changes := recorder.wait(t, 4)
+	cancel()
+	require.NoError(t, Devices().Add(newDevice))
+	recorder.wait(t, 4)
+
+	assert.Equal(t, sdkModels.CacheChangeAdd, changes[0].Action)
+	assert.Nil(t, changes[0].Before)
+	assert.Equal(t, newDevice.Name, changes[0].After.Name)
+	assert.Equal(t, sdkModels.CacheChangeUpdate, changes[1].Action)
+	assert.Empty(t, changes[1].Before.Labels)
+	assert.Equal(t, []string{"updated"}, changes[1].After.Labels)
+	assert.Equal(t, sdkModels.CacheChangeUpdate, changes[2].Action)
+	assert.Equal(t, models.AdminState(models.Unlocked), changes[2].Before.AdminState)
+	assert.Equal(t, models.AdminState(models.Locked), changes[2].After.AdminState)
+	assert.Equal(t, sdkModels.CacheChangeRemove, changes[3].Action)
+	assert.Equal(t, newDevice.Name, changes[3].Before.Name)
+	assert.Nil(t, changes[3].After)
+
+	// the snapshots are not shared with the cache
+	changes[1].After.Labels[0] = "modified"
+	assert.Empty(t, Devices().ForLabel("modified"))
+}
+
+func TestWatchDevices_order(t *testing.T) {
+	newDeviceCache([]models.Device{testDevice}, mockDic())
+
+	var recorder changeRecorder[sdkModels.DeviceChange]
+	cancel := WatchDevices(recorder.handle)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	for i := range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			updated := testDevice
+			updated.Description = fmt.Sprintf("update-%d", i)
+			assert.NoError(t, Devices().Update(updated))
+		}()
+	}
+	wg.Wait()
+
+	// each change is notified after the previous one, so the last notified device is the cached one
+	changes := recorder.wait(t, 50)
+	for i := 1; i < len(changes); i++ {
+		assert.Equal(t, changes[i-1].After.Description, changes[i].Before.Description)
+	}
+	cached, ok := Devices().ForName(TestDevice)
+	require.True(t, ok)
+	assert.Equal(t, cached.Description, changes[len(changes)-1].After.Description)
+}
+
+func TestWatchDevices_async(t *testing.T) {
+	newDeviceCache([]models.Device{testDevice}, mockDic())
+
+	// the slow handler doesn't delay the changes nor the other handlers
+	release := make(chan struct{})
+	cancelSlow := WatchDevices(func(sdkModels.DeviceChange) {
+		<-release
+	})
+	defer cancelSlow()
+	defer close(release)
+
+	// the handler may change the cache it watches
+	var recorder changeRecorder[sdkModels.DeviceChange]
+	cancel := WatchDevices(func(change sdkModels.DeviceChange) {
+		recorder.handle(change)
+		if change.Action == sdkModels.CacheChangeAdd {
+			assert.NoError(t, Devices().UpdateAdminState(change.After.Name, models.Locked))
+		}
+	})
+	defer cancel()
+
+	require.NoError(t, Devices().Add(newDevice))
+	changes := recorder.wait(t, 2)
+	assert.Equal(t, sdkModels.CacheChangeAdd, changes[0].Action)
+	assert.Equal(t, models.AdminState(models.Locked), changes[1].After.AdminState)
+}
+
+func TestWatchDeviceProfiles(t *testing.T) {
+	newProfileCache([]models.DeviceProfile{testProfile})
+
+	var recorder changeRecorder[sdkModels.DeviceProfileChange]
+	cancel := WatchDeviceProfiles(recorder.handle)
+	defer cancel()
+
+	require.NoError(t, Profiles().CheckAndAdd(newProfile))
+	require.NoError(t, Profiles().CheckAndAdd(newProfile))
+	updated := newProfile.Clone()
+	updated.DeviceResources = append(updated.DeviceResources, models.DeviceResource{Name: "addedResource"})
+	require.NoError(t, Profiles().Update(updated))
+	require.NoError(t, Profiles().RemoveByName(newProfile.Name))
+
+	changes := recorder.wait(t, 3)
+	assert.Equal(t, sdkModels.CacheChangeAdd, changes[0].Action)
+	assert.Equal(t, sdkModels.CacheChangeUpdate, changes[1].Action)
+	assert.Len(t, changes[1].Before.DeviceResources, len(newProfile.DeviceResources))
+	assert.Len(t, changes[1].After.DeviceResources, len(newProfile.DeviceResources)+1)
+	assert.Equal(t, sdkModels.CacheChangeRemove, changes[2].Action)
+	assert.Nil(t, changes[2].After)
+}
+
+func TestWatchProvisionWatchers(t *testing.T) {
+	newProvisionWatcherCache([]models.ProvisionWatcher{testProvisionWatcher})
+
+	var recorder changeRecorder[sdkModels.ProvisionWatcherChange]
+	cancel := WatchProvisionWatchers(recorder.handle)
+	defer cancel()
+
+	require.NoError(t, ProvisionWatchers().Add(newProvisionWatcher))
+	require.NoError(t, ProvisionWatchers().UpdateAdminState(newProvisionWatcher.Name, models.Locked))
+	require.Error(t, ProvisionWatchers().Update(models.ProvisionWatcher{Name: "nonexistent"}))
+	require.NoError(t, ProvisionWatchers().RemoveByName(newProvisionWatcher.Name))
+
+	changes := recorder.wait(t, 3)
+	assert.Equal(t, sdkModels.CacheChangeAdd, changes[0].Action)
+	assert.Equal(t, models.AdminState(models.Unlocked), changes[1].Before.AdminState)
+	assert.Equal(t, models.AdminState(models.Locked), changes[1].After.AdminState)
+	assert.Equal(t, sdkModels.CacheChangeRemove, changes[2].Action)
+}
@@ -1,7 +1,7 @@
 // -*- mode: Go; indent-tabs-mode: t -*-
 //
 // Copyright (C) 2017-2018 Canonical Ltd
-// Copyright (C) 2018-2022 IOTech Ltd
+// Copyright (C) 2018-2026 IOTech Ltd
 // Copyright (c) 2019 Intel Corporation
 //
 // SPDX-License-Identifier: Apache-2.0
@@ -13,6 +13,46 @@ import "github.com/edgexfoundry/go-mod-core-contracts/v4/common"
 const (
 	URLRawQuery       = "urlRawQuery"
 	SDKReservedPrefix = "ds-"
+	// TargetUnits is the query string to specify the comma-separated units the readings should be converted to
+	TargetUnits = "ds-units"
+	// SystemEventActionAssertion is the action of the device System Event published when an assertion fails
+	SystemEventActionAssertion = "assertion"
+	// SystemEventActionPendingApproval is the action of the device System Event published when a discovered device
+	// is put in the pending queue
+	SystemEventActionPendingApproval = "pendingApproval"
+	// SystemEventActionStale is the action of the device System Event published when a discovered device is not seen
+	// by the device discovery any more
+	SystemEventActionStale = "stale"
+	// RequireApprovalLabel is the provision watcher label which puts the discovered devices it matches in the pending
+	// queue instead of adding them
+	RequireApprovalLabel = SDKReservedPrefix + "require-approval"
+	// IdentityKeyLabelPrefix is the prefix of the provision watcher label which names the protocol property
+	// identifying the discovered devices, e.g. ds-identity-key=MACAddress
+	IdentityKeyLabelPrefix = SDKReservedPrefix + "identity-key="
+	// StaleAfterLabelPrefix is the prefix of the provision watcher label which opts in the stale detection of the
+	// discovered devices, after a number of discovery runs or a duration, e.g. ds-stale-after=3 or ds-stale-after=1h
+	StaleAfterLabelPrefix = SDKReservedPrefix + "stale-after="
+	// StaleActionLabelPrefix is the prefix of the provision watcher label which sets whether the stale devices are
+	// marked down, ds-stale-action=down, or only reported by the system event, ds-stale-action=event
+	StaleActionLabelPrefix = SDKReservedPrefix + "stale-action="
+	// StaleLabel is the label of the devices marked down by the stale detection, so only they are marked up when they
+	// are discovered again, also after a restart
+	StaleLabel = SDKReservedPrefix + "stale"
+	// ProvisionedLabel is the label of the Devices and ProvisionWatchers provisioned by the declarative provisioning,
+	// only the labeled entries absent from the provisioning files are deleted
+	ProvisionedLabel = SDKReservedPrefix + "provisioned"
+	// ApiProvisioningExportRoute is the route to export the cached metadata as the provisioning files
+	ApiProvisioningExportRoute = common.ApiBase + "/provisioning/export"
+	// ApiDiscoveryCandidatesRoute is the route to query the candidates of the last device discovery
+	ApiDiscoveryCandidatesRoute = common.ApiDiscoveryRoute + "/candidates"
+	// ApiDiscoveryPendingRoute is the route to query the discovered devices pending approval
+	ApiDiscoveryPendingRoute = common.ApiDiscoveryRoute + "/pending"
+	// ApiDiscoveryPendingByIdRoute is the route to reject a discovered device pending approval
+	ApiDiscoveryPendingByIdRoute = ApiDiscoveryPendingRoute + "/" + common.Id + "/:" + common.Id
+	// ApiDiscoveryPendingApproveRoute is the route to approve a discovered device pending approval
+	ApiDiscoveryPendingApproveRoute = ApiDiscoveryPendingByIdRoute + "/approve"
+	// ExportFormat is the query string to specify the format of the exported provisioning files, yaml or json
+	ExportFormat = "format"
 )
 
 // SDKVersion indicates the version of the SDK - will be overwritten by build
@@ -0,0 +1,62 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+// EquivalentJSON compares the DTOs by their JSON encodings, the null and empty values are considered equal as they are
+// not distinguished between the provisioning files, the metadata snapshot and core-metadata
+func EquivalentJSON(a, b any) bool {
+	x, errX := normalizedJSON(a)
+	y, errY := normalizedJSON(b)
+	return errX == nil && errY == nil && reflect.DeepEqual(x, y)
+}
+
+func normalizedJSON(v any) (any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var result any
+	if err = json.Unmarshal(data, &result); err != nil {
+		return nil, err
+	}
+	return dropEmpty(result), nil
+}
+
+// dropEmpty removes the null and empty values from the decoded JSON recursively
+func dropEmpty(v any) any {
+	switch value := v.(type) {
+	case map[string]any:
+		for k, e := range value {
+			e = dropEmpty(e)
+			if e == nil {
+				delete(value, k)
+			} else {
+				value[k] = e
+			}
+		}
+		if len(value) == 0 {
+			return nil
+		}
+	case []any:
+		for i, e := range value {
+			value[i] = dropEmpty(e)
+		}
+		if len(value) == 0 {
+			return nil
+		}
+	case string:
+		if value == "" {
+			return nil
+		}
+	}
+	return v
+}
@@ -0,0 +1,22 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/dtos"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEquivalentJSON(t *testing.T) {
+	assert.True(t, EquivalentJSON(dtos.Device{Name: "d", Properties: map[string]any{}}, dtos.Device{Name: "d"}))
+	assert.True(t, EquivalentJSON(dtos.Device{Name: "d", Labels: []string{}}, dtos.Device{Name: "d"}))
+	assert.True(t, EquivalentJSON(dtos.Device{Name: "d", Protocols: map[string]dtos.ProtocolProperties{"p": {"a": 1}}},
+		dtos.Device{Name: "d", Protocols: map[string]dtos.ProtocolProperties{"p": {"a": float64(1)}}}))
+	assert.False(t, EquivalentJSON(dtos.Device{Name: "d", Labels: []string{"a"}}, dtos.Device{Name: "d"}))
+}
@@ -29,6 +29,8 @@ import (
 const (
 	eventsSentName             = "EventsSent"
 	readingsSentName           = "ReadingsSent"
+	assertionsFailedName       = "AssertionsFailed"
+	metadataDriftsName         = "MetadataDriftsCorrected"
 	DeviceServiceEventPrefix   = "device"
 	BypassValidationQueryParam = "bypassValidation"
 )
@@ -36,6 +38,8 @@ const (
 // TODO: Refactor code in 3.0 to encapsulate this in a struct, factory func and
 var eventsSent gometrics.Counter
 var readingsSent gometrics.Counter
+var assertionsFailed gometrics.Counter
+var metadataDrifts gometrics.Counter
 
 func UpdateOperatingState(name string, state string, lc logger.LoggingClient, dc interfaces.DeviceClient) {
 	device := dtos.UpdateDevice{
@@ -93,6 +97,44 @@ func InitializeSentMetrics(lc logger.LoggingClient, dic *di.Container) {
 	}
 }
 
+func InitializeAssertionMetrics(lc logger.LoggingClient, dic *di.Container) {
+	assertionsFailed = gometrics.NewCounter()
+
+	metricsManager := bootstrapContainer.MetricsManagerFrom(dic.Get)
+	if metricsManager != nil {
+		registerMetric(metricsManager, lc, assertionsFailedName, assertionsFailed)
+	} else {
+		lc.Warn("MetricsManager not available to register Assertions Failed metric")
+	}
+}
+
+// IncAssertionsFailed counts a failed DeviceResource assertion
+func IncAssertionsFailed() {
+	if assertionsFailed != nil {
+		assertionsFailed.Inc(1)
+	}
+}
+
+// InitializeReconcileMetrics registers the metric of the cache differences corrected by the reconciliation with
+// core-metadata
+func InitializeReconcileMetrics(lc logger.LoggingClient, dic *di.Container) {
+	metadataDrifts = gometrics.NewCounter()
+
+	metricsManager := bootstrapContainer.MetricsManagerFrom(dic.Get)
+	if metricsManager != nil {
+		registerMetric(metricsManager, lc, metadataDriftsName, metadataDrifts)
+	} else {
+		lc.Warn("MetricsManager not available to register Metadata Drifts Corrected metric")
+	}
+}
+
+// IncMetadataDriftsCorrected counts a cache difference from core-metadata corrected by the reconciliation
+func IncMetadataDriftsCorrected() {
+	if metadataDrifts != nil {
+		metadataDrifts.Inc(1)
+	}
+}
+
 func registerMetric(metricsManager bootstrapInterfaces.MetricsManager, lc logger.LoggingClient, name string, metric interface{}) {
 	err := metricsManager.Register(name, metric, nil)
 	if err != nil {
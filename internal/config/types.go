@@ -1,7 +1,7 @@
 // -*- mode: Go; indent-tabs-mode: t -*-
 //
 // Copyright (C) 2017-2018 Canonical Ltd
-// Copyright (C) 2018-2025 IOTech Ltd
+// Copyright (C) 2018-2026 IOTech Ltd
 // Copyright (c) 2021 Intel Corporation
 //
 // SPDX-License-Identifier: Apache-2.0
@@ -25,6 +25,10 @@ type WritableInfo struct {
 type Reading struct {
 	// ReadingUnits specifies whether or not to indicate the units of measure for the value in the reading
 	ReadingUnits bool
+	// OverflowPolicy specifies how to handle the value which overflows its type after transformation or is NaN,
+	// i.e. null (default), clamp, drop, fail or raw. It can be overridden per DeviceResource by the overflowPolicy
+	// of the ResourceProperties.Optional.
+	OverflowPolicy string
 }
 
 // DeviceInfo is a struct which contains device specific configuration settings.
@@ -59,6 +63,14 @@ type DeviceInfo struct {
 	DeviceDownTimeout uint
 	// AutoEvents defines the configuration related to the generated auto events for the device service
 	AutoEvents AutoEventInfo
+	// Snapshot defines the configuration of the local metadata snapshot
+	Snapshot SnapshotInfo
+	// Reconciliation defines the configuration of the periodic reconciliation of the caches with core-metadata
+	Reconciliation ReconciliationInfo
+	// HotReload defines the configuration of the reloading of the provisioning directories on the file changes
+	HotReload HotReloadInfo
+	// Provisioning defines the configuration of the loading of the provisioning directories
+	Provisioning ProvisioningInfo
 }
 
 // DiscoveryInfo is a struct which contains configuration of device auto discovery.
@@ -68,6 +80,61 @@ type DiscoveryInfo struct {
 	// Interval indicates how often the discovery process will be triggered.
 	// It represents as a duration string.
 	Interval string
+	// RequireApproval controls whether the discovered devices matched by any provision watcher are put in the pending
+	// queue to be approved through the REST API instead of being added. It is also enabled per provision watcher with
+	// the ds-require-approval label.
+	RequireApproval bool
+}
+
+// SnapshotInfo is a struct which contains configuration of the local metadata snapshot.
+type SnapshotInfo struct {
+	// Enabled controls whether the device, profile and provision watcher caches are saved to the snapshot file, which
+	// is used to start the Device Service in degraded mode when core-metadata is unavailable. The Device Service starts
+	// in degraded mode as soon as core-metadata can't be connected, without waiting for the startup timer.
+	Enabled bool
+	// Path is the path of the snapshot file.
+	Path string
+	// SaveInterval indicates how often the changes of the caches are saved to the snapshot file.
+	// It represents as a duration string.
+	SaveInterval string
+	// RetryInterval indicates how often the Device Service in degraded mode retries connecting to core-metadata.
+	// It represents as a duration string.
+	RetryInterval string
+}
+
+// ReconciliationInfo is a struct which contains configuration of the periodic cache reconciliation.
+type ReconciliationInfo struct {
+	// Enabled controls whether or not the device, profile and provision watcher caches are periodically reconciled
+	// with core-metadata, to correct the differences caused by the missed metadata system events.
+	Enabled bool
+	// Interval indicates how often the reconciliation will be triggered.
+	// It represents as a duration string.
+	Interval string
+}
+
+// HotReloadInfo is a struct which contains configuration of the provisioning directories hot-reload.
+type HotReloadInfo struct {
+	// Enabled controls whether or not the ProfilesDir, DevicesDir and ProvisionWatchersDir are watched, so the added
+	// or changed files are loaded to core-metadata without restarting the Device Service. The URIs are not watched.
+	Enabled bool
+	// Debounce indicates how long the directories must be unchanged before the changed files are loaded, so a burst
+	// of file changes is loaded at once. It represents as a duration string.
+	Debounce string
+}
+
+// ProvisioningInfo is a struct which contains configuration of the provisioning directories loading.
+type ProvisioningInfo struct {
+	// Declarative controls whether the DevicesDir and ProvisionWatchersDir are the source of truth of the Devices and
+	// ProvisionWatchers of the Device Service. The changed entries are updated, and the entries provisioned from the
+	// files before, labeled with ds-provisioned, are deleted from core-metadata once they are absent from the files.
+	// The entries added by discovery or through the API are kept, and the plan deleting all the provisioned entries is
+	// refused when no file is read.
+	Declarative bool
+	// DryRun controls whether the declarative provisioning only reports the planned changes without applying them.
+	DryRun bool
+	// PublicKeyFile is the path of the PEM encoded public key which verifies the signatures of the index files of the
+	// provisioning URIs. If set, the index files must be signed and list the SHA-256 digest of every file.
+	PublicKeyFile string
 }
 
 // Telemetry provides metrics (on a given device service) to system management.
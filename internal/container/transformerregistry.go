@@ -0,0 +1,69 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package container
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v4/di"
+
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v4/pkg/models"
+)
+
+// TransformerRegistry holds the custom data transformers registered by name.
+type TransformerRegistry struct {
+	mutex        sync.RWMutex
+	transformers map[string]sdkModels.Transformer
+}
+
+// NewTransformerRegistry creates and initializes a new registry.
+func NewTransformerRegistry() *TransformerRegistry {
+	return &TransformerRegistry{
+		transformers: make(map[string]sdkModels.Transformer),
+	}
+}
+
+// Register adds the transformer with the given name.
+// Returns error if the name is empty or already registered, or the transformer has neither Read nor Write function.
+func (r *TransformerRegistry) Register(name string, transformer sdkModels.Transformer) error {
+	if name == "" {
+		return fmt.Errorf("transformer name cannot be empty")
+	}
+	if transformer.Read == nil && transformer.Write == nil {
+		return fmt.Errorf("transformer %s has neither Read nor Write function", name)
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if _, exists := r.transformers[name]; exists {
+		return fmt.Errorf("transformer %s already registered", name)
+	}
+	r.transformers[name] = transformer
+	return nil
+}
+
+// Get retrieves the transformer for a given name.
+func (r *TransformerRegistry) Get(name string) (sdkModels.Transformer, bool) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	transformer, exists := r.transformers[name]
+	return transformer, exists
+}
+
+// TransformerRegistryName contains the name of custom transformer registry in the DIC.
+var TransformerRegistryName = di.TypeInstanceToName(TransformerRegistry{})
+
+// TransformerRegistryFrom helper function queries the DIC and returns the custom transformer registry.
+// Returns nil if the registry is not in the DIC.
+func TransformerRegistryFrom(get di.Get) *TransformerRegistry {
+	registry, ok := get(TransformerRegistryName).(*TransformerRegistry)
+	if !ok {
+		return nil
+	}
+	return registry
+}
@@ -44,11 +44,17 @@ func (c *RestController) GetCommand(e echo.Context) error {
 		regexCmd = false
 	}
 
-	event, err := application.GetCommand(ctx, deviceName, commandName, queryParams, regexCmd, c.dic)
+	event, err := application.GetCommand(ctx, deviceName, commandName, queryParams, regexCmd, reserved.Get(sdkCommon.TargetUnits), c.dic)
 	if err != nil {
 		return c.sendEdgexError(w, r, err, common.ApiDeviceNameCommandNameRoute)
 	}
 
+	// no event is generated when all the readings are dropped, e.g. by the failed assertions
+	if event == nil {
+		w.WriteHeader(http.StatusOK)
+		return nil
+	}
+
 	// push event to CoreData if specified (default false)
 	if pushEvent := reserved.Get(common.PushEvent); pushEvent == common.ValueTrue {
 		go sdkCommon.SendEvent(event, correlationId, c.dic)
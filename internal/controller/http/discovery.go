@@ -1,6 +1,6 @@
 // -*- Mode: Go; indent-tabs-mode: t -*-
 //
-// Copyright (C) 2020-2024 IOTech Ltd
+// Copyright (C) 2020-2026 IOTech Ltd
 //
 // SPDX-License-Identifier: Apache-2.0
 
@@ -8,6 +8,7 @@ package http
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
@@ -19,12 +20,14 @@ import (
 	"github.com/edgexfoundry/device-sdk-go/v4/internal/application"
 	"github.com/edgexfoundry/device-sdk-go/v4/internal/autodiscovery"
 	"github.com/edgexfoundry/device-sdk-go/v4/internal/cache"
+	sdkCommon "github.com/edgexfoundry/device-sdk-go/v4/internal/common"
 	"github.com/edgexfoundry/device-sdk-go/v4/internal/container"
 	"github.com/edgexfoundry/device-sdk-go/v4/internal/controller/http/correlation"
 	"github.com/edgexfoundry/go-mod-bootstrap/v4/di"
 	"github.com/edgexfoundry/go-mod-core-contracts/v4/common"
 	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v4/dtos/common"
 	"github.com/edgexfoundry/go-mod-core-contracts/v4/dtos/requests"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/dtos/responses"
 	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
 	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
 )
@@ -195,3 +198,97 @@ func (c *RestController) StopProfileScan(e echo.Context) error {
 	res := commonDTO.NewBaseResponse("", "", http.StatusOK)
 	return c.sendResponse(writer, request, common.ApiProfileScanByDeviceNameRoute, res, http.StatusOK)
 }
+
+// discoveryCandidatesResponse is the response of the candidates of the last device discovery
+type discoveryCandidatesResponse struct {
+	commonDTO.BaseResponse `json:",inline"`
+	DiscoveryRun           autodiscovery.DiscoveryRun `json:"discoveryRun"`
+}
+
+// DiscoveryCandidates responds the devices found by the last device discovery, with the provision watchers evaluated
+// against each device, why they matched or not, and the resulting action
+func (c *RestController) DiscoveryCandidates(e echo.Context) error {
+	request := e.Request()
+	writer := e.Response()
+
+	run, ok := autodiscovery.LastDiscoveryRun()
+	if !ok {
+		edgexErr := errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, "no device has been discovered", nil)
+		return c.sendEdgexError(writer, request, edgexErr, sdkCommon.ApiDiscoveryCandidatesRoute)
+	}
+
+	response := discoveryCandidatesResponse{
+		BaseResponse: commonDTO.NewBaseResponse(run.RequestId, "", http.StatusOK),
+		DiscoveryRun: run,
+	}
+	return c.sendResponse(writer, request, sdkCommon.ApiDiscoveryCandidatesRoute, response, http.StatusOK)
+}
+
+// pendingDevicesResponse is the response of the discovered devices pending approval
+type pendingDevicesResponse struct {
+	commonDTO.BaseWithTotalCountResponse `json:",inline"`
+	PendingDevices                       []autodiscovery.PendingDevice `json:"pendingDevices"`
+}
+
+// PendingDevices responds the discovered devices waiting for the approval to be added
+func (c *RestController) PendingDevices(e echo.Context) error {
+	request := e.Request()
+	writer := e.Response()
+
+	devices := autodiscovery.PendingDevices()
+	response := pendingDevicesResponse{
+		BaseWithTotalCountResponse: commonDTO.NewBaseWithTotalCountResponse("", "", http.StatusOK, int64(len(devices))),
+		PendingDevices:             devices,
+	}
+	return c.sendResponse(writer, request, sdkCommon.ApiDiscoveryPendingRoute, response, http.StatusOK)
+}
+
+// ApprovePendingDevice adds the pending device of the id, the optional request body edits the name, profile name and
+// labels of the device
+func (c *RestController) ApprovePendingDevice(e echo.Context) error {
+	request := e.Request()
+	writer := e.Response()
+	ctx := request.Context()
+	if request.Body != nil {
+		defer func() { _ = request.Body.Close() }()
+	}
+
+	var edits autodiscovery.PendingDeviceEdits
+	body, err := io.ReadAll(request.Body)
+	if err != nil {
+		edgexErr := errors.NewCommonEdgeX(errors.KindServerError, "Failed to read request body", err)
+		return c.sendEdgexError(writer, request, edgexErr, sdkCommon.ApiDiscoveryPendingApproveRoute)
+	}
+	if len(body) > 0 {
+		if err = json.Unmarshal(body, &edits); err != nil {
+			edgexErr := errors.NewCommonEdgeX(errors.KindContractInvalid, "failed to parse request body", err)
+			return c.sendEdgexError(writer, request, edgexErr, sdkCommon.ApiDiscoveryPendingApproveRoute)
+		}
+	}
+	if (edits.Name != nil && *edits.Name == "") || (edits.ProfileName != nil && *edits.ProfileName == "") {
+		edgexErr := errors.NewCommonEdgeX(errors.KindContractInvalid, "name and profileName can't be empty", nil)
+		return c.sendEdgexError(writer, request, edgexErr, sdkCommon.ApiDiscoveryPendingApproveRoute)
+	}
+
+	device, edgexErr := autodiscovery.ApprovePendingDevice(ctx, e.Param(common.Id), edits, c.dic)
+	if edgexErr != nil {
+		return c.sendEdgexError(writer, request, edgexErr, sdkCommon.ApiDiscoveryPendingApproveRoute)
+	}
+
+	response := responses.NewDeviceResponse("", "", http.StatusCreated, device)
+	return c.sendResponse(writer, request, sdkCommon.ApiDiscoveryPendingApproveRoute, response, http.StatusCreated)
+}
+
+// RejectPendingDevice removes the pending device of the id from the queue
+func (c *RestController) RejectPendingDevice(e echo.Context) error {
+	request := e.Request()
+	writer := e.Response()
+
+	device, edgexErr := autodiscovery.RejectPendingDevice(e.Param(common.Id))
+	if edgexErr != nil {
+		return c.sendEdgexError(writer, request, edgexErr, sdkCommon.ApiDiscoveryPendingByIdRoute)
+	}
+
+	response := responses.NewDeviceResponse("", "", http.StatusOK, device)
+	return c.sendResponse(writer, request, sdkCommon.ApiDiscoveryPendingByIdRoute, response, http.StatusOK)
+}
@@ -0,0 +1,115 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/device-sdk-go/v4/internal/autodiscovery"
+	sdkCommon "github.com/edgexfoundry/device-sdk-go/v4/internal/common"
+)
+
+func TestRestController_DiscoveryCandidates(t *testing.T) {
+	e := echo.New()
+	dic := mockDic()
+	controller := NewRestController(e, dic, testService)
+
+	query := func() (int, discoveryCandidatesResponse) {
+		req := httptest.NewRequest(http.MethodGet, sdkCommon.ApiDiscoveryCandidatesRoute, http.NoBody)
+		recorder := httptest.NewRecorder()
+		err := controller.DiscoveryCandidates(e.NewContext(req, recorder))
+		require.NoError(t, err)
+
+		var res discoveryCandidatesResponse
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+		return recorder.Result().StatusCode, res
+	}
+
+	statusCode, res := query()
+	assert.Equal(t, http.StatusNotFound, statusCode, "HTTP status code not as expected")
+	assert.NotEmpty(t, res.Message, "Response message doesn't contain the error message")
+
+	autodiscovery.RecordCandidates("request-id", []autodiscovery.Candidate{{
+		Name:   testDevice,
+		Action: autodiscovery.CandidateActionNoMatch,
+		Evaluations: []autodiscovery.WatcherEvaluation{{
+			ProvisionWatcher: "test-watcher",
+			Result:           autodiscovery.WatcherResultBlocked,
+			Blocked:          &autodiscovery.BlockedIdentifier{Protocol: "http", Identifier: "port", Value: "399"},
+		}},
+	}})
+	statusCode, res = query()
+	assert.Equal(t, http.StatusOK, statusCode, "HTTP status code not as expected")
+	assert.Equal(t, "request-id", res.RequestId)
+	assert.Equal(t, "request-id", res.DiscoveryRun.RequestId)
+	require.Len(t, res.DiscoveryRun.Candidates, 1)
+	candidate := res.DiscoveryRun.Candidates[0]
+	assert.Equal(t, autodiscovery.CandidateActionNoMatch, candidate.Action)
+	require.Len(t, candidate.Evaluations, 1)
+	assert.Equal(t, "399", candidate.Evaluations[0].Blocked.Value)
+}
+
+func TestRestController_PendingDevices(t *testing.T) {
+	e := echo.New()
+	dic := mockDic()
+	controller := NewRestController(e, dic, testService)
+
+	id, ok := autodiscovery.EnqueuePendingDevice(context.Background(), models.Device{Name: "pending-device", ProfileName: testProfile}, "test-watcher", dic)
+	require.True(t, ok)
+
+	req := httptest.NewRequest(http.MethodGet, sdkCommon.ApiDiscoveryPendingRoute, http.NoBody)
+	recorder := httptest.NewRecorder()
+	require.NoError(t, controller.PendingDevices(e.NewContext(req, recorder)))
+	assert.Equal(t, http.StatusOK, recorder.Result().StatusCode, "HTTP status code not as expected")
+	var res pendingDevicesResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+	var found bool
+	for _, p := range res.PendingDevices {
+		found = found || p.Id == id
+	}
+	assert.True(t, found, "pending device is not listed")
+
+	tests := []struct {
+		name               string
+		method             string
+		id                 string
+		body               string
+		expectedStatusCode int
+	}{
+		{"invalid - approve with malformed body", http.MethodPost, id, `{"name": `, http.StatusBadRequest},
+		{"invalid - approve with empty name", http.MethodPost, id, `{"name": ""}`, http.StatusBadRequest},
+		{"invalid - approve unknown id", http.MethodPost, "unknown", "", http.StatusNotFound},
+		{"valid - reject", http.MethodDelete, id, "", http.StatusOK},
+		{"invalid - reject unknown id", http.MethodDelete, id, "", http.StatusNotFound},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			req := httptest.NewRequest(testCase.method, sdkCommon.ApiDiscoveryPendingRoute, strings.NewReader(testCase.body))
+			recorder := httptest.NewRecorder()
+			c := e.NewContext(req, recorder)
+			c.SetParamNames(common.Id)
+			c.SetParamValues(testCase.id)
+			if testCase.method == http.MethodPost {
+				require.NoError(t, controller.ApprovePendingDevice(c))
+			} else {
+				require.NoError(t, controller.RejectPendingDevice(c))
+			}
+			assert.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+		})
+	}
+}
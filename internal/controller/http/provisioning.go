@@ -0,0 +1,67 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"net/http"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
+	"github.com/labstack/echo/v4"
+
+	sdkCommon "github.com/edgexfoundry/device-sdk-go/v4/internal/common"
+	"github.com/edgexfoundry/device-sdk-go/v4/internal/provision"
+)
+
+const contentTypeZip = "application/zip"
+
+// ExportProvisioning responds the zip archive of the Device Profiles, Devices and ProvisionWatchers in the caches as
+// the provisioning files, the format of the files is yaml by default
+func (c *RestController) ExportProvisioning(e echo.Context) error {
+	request := e.Request()
+	writer := e.Response()
+
+	format := request.URL.Query().Get(sdkCommon.ExportFormat)
+	if format == "" {
+		format = provision.ExportFormatYAML
+	}
+	files, edgexErr := provision.Export(format)
+	if edgexErr != nil {
+		return c.sendEdgexError(writer, request, edgexErr, sdkCommon.ApiProvisioningExportRoute)
+	}
+
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	for _, file := range files {
+		w, err := archive.Create(file.Path)
+		if err == nil {
+			_, err = w.Write(file.Content)
+		}
+		if err != nil {
+			edgexErr = errors.NewCommonEdgeX(errors.KindServerError, fmt.Sprintf("failed to archive %s", file.Path), err)
+			return c.sendEdgexError(writer, request, edgexErr, sdkCommon.ApiProvisioningExportRoute)
+		}
+	}
+	if err := archive.Close(); err != nil {
+		edgexErr = errors.NewCommonEdgeX(errors.KindServerError, "failed to archive the provisioning files", err)
+		return c.sendEdgexError(writer, request, edgexErr, sdkCommon.ApiProvisioningExportRoute)
+	}
+
+	writer.Header().Set(common.CorrelationHeader, request.Header.Get(common.CorrelationHeader))
+	writer.Header().Set(common.ContentType, contentTypeZip)
+	writer.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", c.serviceName+"-provisioning.zip"))
+	writer.WriteHeader(http.StatusOK)
+	if _, err := writer.Write(buf.Bytes()); err != nil {
+		c.lc.Errorf("Unable to write %s response: %s", sdkCommon.ApiProvisioningExportRoute, err.Error())
+		writer.Committed = false
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/common"
+	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v4/dtos/common"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/device-sdk-go/v4/internal/cache"
+	sdkCommon "github.com/edgexfoundry/device-sdk-go/v4/internal/common"
+)
+
+func TestRestController_ExportProvisioning(t *testing.T) {
+	e := echo.New()
+	dic := mockDic()
+
+	edgexErr := cache.InitCache(testService, testService, dic)
+	require.NoError(t, edgexErr)
+
+	controller := NewRestController(e, dic, testService)
+	assert.NotNil(t, controller)
+
+	tests := []struct {
+		name               string
+		query              string
+		expectedStatusCode int
+		expectedFiles      []string
+	}{
+		{"valid - default format", "", http.StatusOK, []string{"devices/devices.yaml", "profiles/test-profile.yaml"}},
+		{"valid - json", "?format=json", http.StatusOK, []string{"devices/devices.json", "profiles/test-profile.json"}},
+		{"invalid - unsupported format", "?format=xml", http.StatusBadRequest, nil},
+	}
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, sdkCommon.ApiProvisioningExportRoute+testCase.query, http.NoBody)
+
+			// Act
+			recorder := httptest.NewRecorder()
+			c := e.NewContext(req, recorder)
+			err := controller.ExportProvisioning(c)
+			assert.NoError(t, err)
+
+			// Assert
+			assert.Equal(t, testCase.expectedStatusCode, recorder.Result().StatusCode, "HTTP status code not as expected")
+			if testCase.expectedStatusCode != http.StatusOK {
+				var res commonDTO.BaseResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+				assert.NotEmpty(t, res.Message, "Response message doesn't contain the error message")
+				return
+			}
+
+			assert.Equal(t, contentTypeZip, recorder.Header().Get(common.ContentType))
+			assert.Contains(t, recorder.Header().Get("Content-Disposition"), testService+"-provisioning.zip")
+			archive, err := zip.NewReader(bytes.NewReader(recorder.Body.Bytes()), int64(recorder.Body.Len()))
+			require.NoError(t, err)
+			var files []string
+			for _, file := range archive.File {
+				files = append(files, file.Name)
+			}
+			sort.Strings(files)
+			assert.Equal(t, testCase.expectedFiles, files)
+		})
+	}
+}
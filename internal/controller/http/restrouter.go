@@ -24,6 +24,8 @@ import (
 	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
 
 	"github.com/labstack/echo/v4"
+
+	sdkCommon "github.com/edgexfoundry/device-sdk-go/v4/internal/common"
 )
 
 type RestController struct {
@@ -62,9 +64,15 @@ func (c *RestController) InitRestRoutes(dic *di.Container) {
 	c.addReservedRoute(common.ApiDiscoveryRoute, c.StopDeviceDiscovery, http.MethodDelete, authenticationHook)
 	c.addReservedRoute(common.ApiDiscoveryByIdRoute, c.StopDeviceDiscovery, http.MethodDelete, authenticationHook)
 	c.addReservedRoute(common.ApiProfileScanByDeviceNameRoute, c.StopProfileScan, http.MethodDelete, authenticationHook)
+	c.addReservedRoute(sdkCommon.ApiDiscoveryCandidatesRoute, c.DiscoveryCandidates, http.MethodGet, authenticationHook)
+	c.addReservedRoute(sdkCommon.ApiDiscoveryPendingRoute, c.PendingDevices, http.MethodGet, authenticationHook)
+	c.addReservedRoute(sdkCommon.ApiDiscoveryPendingApproveRoute, c.ApprovePendingDevice, http.MethodPost, authenticationHook)
+	c.addReservedRoute(sdkCommon.ApiDiscoveryPendingByIdRoute, c.RejectPendingDevice, http.MethodDelete, authenticationHook)
 	// device command
 	c.addReservedRoute(common.ApiDeviceNameCommandNameRoute, c.GetCommand, http.MethodGet, authenticationHook)
 	c.addReservedRoute(common.ApiDeviceNameCommandNameRoute, c.SetCommand, http.MethodPut, authenticationHook)
+	// provisioning
+	c.addReservedRoute(sdkCommon.ApiProvisioningExportRoute, c.ExportProvisioning, http.MethodGet, authenticationHook)
 }
 
 func (c *RestController) addReservedRoute(route string, handler func(e echo.Context) error, method string,
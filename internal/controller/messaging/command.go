@@ -114,7 +114,7 @@ func getCommand(ctx context.Context, msgEnvelope types.MessageEnvelope, response
 
 	// TODO: fix properly in EdgeX 3.0
 	ctx = context.WithValue(ctx, common.CorrelationHeader, msgEnvelope.CorrelationID) // nolint: staticcheck
-	event, edgexErr := application.GetCommand(ctx, deviceName, commandName, rawQuery, reserved[common.RegexCommand], dic)
+	event, edgexErr := application.GetCommand(ctx, deviceName, commandName, rawQuery, reserved[common.RegexCommand], msgEnvelope.QueryParams[sdkCommon.TargetUnits], dic)
 	if edgexErr != nil {
 		lc.Errorf("Failed to process get device command %s for device %s: %s", commandName, deviceName, edgexErr.Error())
 		responseEnvelope = types.NewMessageEnvelopeWithError(msgEnvelope.RequestID, edgexErr.Error())
@@ -128,7 +128,8 @@ func getCommand(ctx context.Context, msgEnvelope types.MessageEnvelope, response
 	var err error
 	var encoding string
 	var eventResponse any
-	if reserved[common.ReturnEvent] {
+	// no event is generated when all the readings are dropped, e.g. by the failed assertions
+	if reserved[common.ReturnEvent] && event != nil {
 		resp := responses.NewEventResponse(msgEnvelope.RequestID, "", http.StatusOK, *event)
 		encoding = resp.GetEncodingContentType()
 		eventResponse = resp
@@ -159,7 +160,7 @@ func getCommand(ctx context.Context, msgEnvelope types.MessageEnvelope, response
 		return
 	}
 
-	if reserved[common.PushEvent] {
+	if reserved[common.PushEvent] && event != nil {
 		go sdkCommon.SendEvent(event, msgEnvelope.CorrelationID, dic)
 	}
 
@@ -18,6 +18,7 @@ type FileType int
 const (
 	YAML FileType = iota
 	JSON
+	CSV
 	OTHER
 )
 
@@ -27,6 +28,8 @@ func GetFileType(fullPath string) FileType {
 		return YAML
 	} else if strings.HasSuffix(res[0], ".json") {
 		return JSON
+	} else if strings.HasSuffix(res[0], csvExt) {
+		return CSV
 	} else {
 		return OTHER
 	}
@@ -0,0 +1,327 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provision
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"strings"
+
+	contractsCommon "github.com/edgexfoundry/go-mod-core-contracts/v4/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
+	"github.com/hashicorp/go-multierror"
+)
+
+const (
+	csvExt = ".csv"
+	// csvListSeparator separates the values of the list columns, e.g. labels
+	csvListSeparator = ";"
+	// csvValidationServiceName stands in for the service name, which is set when the Device is added, to validate the
+	// Devices of the rows
+	csvValidationServiceName = "csv-import"
+)
+
+// the column names of the CSV files, the dotted prefixes are followed by the key, e.g. "tags.location"
+const (
+	csvName                  = "name"
+	csvDescription           = "description"
+	csvParent                = "parent"
+	csvProfileName           = "profileName"
+	csvLabels                = "labels"
+	csvAutoEvents            = "autoEvents"
+	csvServiceName           = "serviceName"
+	csvAdminState            = "adminState"
+	csvTagsPrefix            = "tags."
+	csvPropertiesPrefix      = "properties."
+	csvIdentifiersPrefix     = "identifiers."
+	csvBlockingPrefix        = "blockingIdentifiers."
+	csvDiscoveredPrefix      = "discoveredDevice."
+	csvDiscoveredProperties  = csvDiscoveredPrefix + csvPropertiesPrefix
+	csvDiscoveredProfileName = csvDiscoveredPrefix + csvProfileName
+	csvDiscoveredAdminState  = csvDiscoveredPrefix + csvAdminState
+	csvDiscoveredAutoEvents  = csvDiscoveredPrefix + csvAutoEvents
+)
+
+// csvRow is a row of the CSV file by the column names, the empty cells are omitted
+type csvRow struct {
+	number int
+	cells  map[string]string
+}
+
+// readCSV reads the CSV content into the header and the non-empty rows, the rows are numbered from 1 for the header
+func readCSV(content []byte) ([]string, []csvRow, error) {
+	reader := csv.NewReader(bytes.NewReader(content))
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil, nil
+	}
+
+	header := make([]string, len(records[0]))
+	for i, name := range records[0] {
+		header[i] = strings.TrimSpace(name)
+	}
+	rows := make([]csvRow, 0, len(records)-1)
+	for i, record := range records[1:] {
+		row := csvRow{number: i + 2, cells: make(map[string]string, len(header))}
+		for j, value := range record {
+			if value = strings.TrimSpace(value); value != "" {
+				row.cells[header[j]] = value
+			}
+		}
+		if len(row.cells) > 0 {
+			rows = append(rows, row)
+		}
+	}
+	return header, rows, nil
+}
+
+// checkCSVHeader checks every column of the header is one of the columns or starts with one of the prefixes
+func checkCSVHeader(header []string, columns []string, prefixes []string, allowProtocols bool) error {
+	seen := make(map[string]struct{}, len(header))
+	for _, name := range header {
+		if _, ok := seen[name]; ok {
+			return fmt.Errorf("column %s is duplicated", name)
+		}
+		seen[name] = struct{}{}
+		if csvColumnKnown(name, columns, prefixes) {
+			continue
+		}
+		if protocol, key, ok := strings.Cut(name, "."); allowProtocols && ok && protocol != "" && key != "" {
+			continue
+		}
+		return fmt.Errorf("column %s is unknown", name)
+	}
+	if _, ok := seen[csvName]; !ok {
+		return fmt.Errorf("column %s is required", csvName)
+	}
+	return nil
+}
+
+func csvColumnKnown(name string, columns []string, prefixes []string) bool {
+	for _, column := range columns {
+		if name == column {
+			return true
+		}
+	}
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(name, prefix) && len(name) > len(prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func splitCSVList(value string) []string {
+	var result []string
+	for _, v := range strings.Split(value, csvListSeparator) {
+		if v = strings.TrimSpace(v); v != "" {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// parseCSVAutoEvents parses the list of the AutoEvents in the form of sourceName:interval[:onChange]
+func parseCSVAutoEvents(value string) ([]dtos.AutoEvent, error) {
+	var autoEvents []dtos.AutoEvent
+	for _, entry := range splitCSVList(value) {
+		parts := strings.Split(entry, ":")
+		if len(parts) < 2 || len(parts) > 3 {
+			return nil, fmt.Errorf("AutoEvent %s is not in the form of sourceName:interval[:onChange]", entry)
+		}
+		autoEvent := dtos.AutoEvent{SourceName: strings.TrimSpace(parts[0]), Interval: strings.TrimSpace(parts[1])}
+		if len(parts) == 3 {
+			onChange, err := strconv.ParseBool(strings.TrimSpace(parts[2]))
+			if err != nil {
+				return nil, fmt.Errorf("AutoEvent %s onChange is not a boolean", entry)
+			}
+			autoEvent.OnChange = onChange
+		}
+		autoEvents = append(autoEvents, autoEvent)
+	}
+	return autoEvents, nil
+}
+
+// csvImportError returns the error of the rows failed to import, nil if no row failed
+func csvImportError(kind string, displayPath string, rowErrs error) errors.EdgeX {
+	if rowErrs == nil {
+		return nil
+	}
+	return errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("Failed to import %s from %s", kind, displayPath), rowErrs)
+}
+
+// devicesFromCSV imports the Devices from the CSV file, one Device per row. The Devices of the valid rows are returned
+// along with the errors of the invalid rows.
+func devicesFromCSV(content []byte, displayPath string) ([]dtos.Device, errors.EdgeX) {
+	header, rows, err := readCSV(content)
+	if err == nil && header != nil {
+		err = checkCSVHeader(header,
+			[]string{csvName, csvDescription, csvParent, csvProfileName, csvLabels, csvAutoEvents},
+			[]string{csvTagsPrefix, csvPropertiesPrefix}, true)
+	}
+	if err != nil {
+		return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("Failed to CSV decode Devices from %s", displayPath), err)
+	}
+
+	var devices []dtos.Device
+	var rowErrs error
+	definedIn := make(map[string]int, len(rows))
+	for _, row := range rows {
+		device, err := deviceFromCSVRow(row)
+		if err == nil {
+			if number, ok := definedIn[device.Name]; ok {
+				err = fmt.Errorf("Device %s is already defined in row %d", device.Name, number)
+			}
+		}
+		if err != nil {
+			rowErrs = multierror.Append(rowErrs, fmt.Errorf("row %d: %w", row.number, err))
+			continue
+		}
+		definedIn[device.Name] = row.number
+		devices = append(devices, device)
+	}
+	return devices, csvImportError("Devices", displayPath, rowErrs)
+}
+
+func deviceFromCSVRow(row csvRow) (dtos.Device, error) {
+	device := dtos.Device{Protocols: make(map[string]dtos.ProtocolProperties)}
+	for column, value := range row.cells {
+		switch {
+		case column == csvName:
+			device.Name = value
+		case column == csvDescription:
+			device.Description = value
+		case column == csvParent:
+			device.Parent = value
+		case column == csvProfileName:
+			device.ProfileName = value
+		case column == csvLabels:
+			device.Labels = splitCSVList(value)
+		case column == csvAutoEvents:
+			autoEvents, err := parseCSVAutoEvents(value)
+			if err != nil {
+				return device, err
+			}
+			device.AutoEvents = autoEvents
+		case strings.HasPrefix(column, csvTagsPrefix):
+			if device.Tags == nil {
+				device.Tags = make(map[string]any)
+			}
+			device.Tags[strings.TrimPrefix(column, csvTagsPrefix)] = value
+		case strings.HasPrefix(column, csvPropertiesPrefix):
+			if device.Properties == nil {
+				device.Properties = make(map[string]any)
+			}
+			device.Properties[strings.TrimPrefix(column, csvPropertiesPrefix)] = value
+		default:
+			protocol, key, _ := strings.Cut(column, ".")
+			if device.Protocols[protocol] == nil {
+				device.Protocols[protocol] = make(dtos.ProtocolProperties)
+			}
+			device.Protocols[protocol][key] = value
+		}
+	}
+	if device.Name == "" {
+		return device, fmt.Errorf("%s is required", csvName)
+	}
+	// the service name and the states are set when the Device is added, the same as the Devices of the other files
+	validated := device
+	validated.ServiceName = csvValidationServiceName
+	validated.AdminState = models.Unlocked
+	validated.OperatingState = models.Up
+	if err := contractsCommon.Validate(validated); err != nil {
+		return device, err
+	}
+	return device, nil
+}
+
+// provisionWatchersFromCSV imports the ProvisionWatchers from the CSV file, one ProvisionWatcher per row. The
+// ProvisionWatchers of the valid rows are returned along with the errors of the invalid rows.
+func provisionWatchersFromCSV(content []byte, displayPath string) ([]dtos.ProvisionWatcher, errors.EdgeX) {
+	header, rows, err := readCSV(content)
+	if err == nil && header != nil {
+		err = checkCSVHeader(header,
+			[]string{csvName, csvServiceName, csvLabels, csvAdminState, csvDiscoveredProfileName, csvDiscoveredAdminState, csvDiscoveredAutoEvents},
+			[]string{csvIdentifiersPrefix, csvBlockingPrefix, csvDiscoveredProperties}, false)
+	}
+	if err != nil {
+		return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("Failed to CSV decode Provision Watchers from %s", displayPath), err)
+	}
+
+	var watchers []dtos.ProvisionWatcher
+	var rowErrs error
+	definedIn := make(map[string]int, len(rows))
+	for _, row := range rows {
+		watcher, err := provisionWatcherFromCSVRow(row)
+		if err == nil {
+			if number, ok := definedIn[watcher.Name]; ok {
+				err = fmt.Errorf("ProvisionWatcher %s is already defined in row %d", watcher.Name, number)
+			}
+		}
+		if err != nil {
+			rowErrs = multierror.Append(rowErrs, fmt.Errorf("row %d: %w", row.number, err))
+			continue
+		}
+		definedIn[watcher.Name] = row.number
+		watchers = append(watchers, watcher)
+	}
+	return watchers, csvImportError("Provision Watchers", displayPath, rowErrs)
+}
+
+func provisionWatcherFromCSVRow(row csvRow) (dtos.ProvisionWatcher, error) {
+	watcher := dtos.ProvisionWatcher{
+		AdminState:       models.Unlocked,
+		Identifiers:      make(map[string]string),
+		DiscoveredDevice: dtos.DiscoveredDevice{AdminState: models.Unlocked},
+	}
+	for column, value := range row.cells {
+		switch {
+		case column == csvName:
+			watcher.Name = value
+		case column == csvServiceName:
+			watcher.ServiceName = value
+		case column == csvLabels:
+			watcher.Labels = splitCSVList(value)
+		case column == csvAdminState:
+			watcher.AdminState = value
+		case column == csvDiscoveredProfileName:
+			watcher.DiscoveredDevice.ProfileName = value
+		case column == csvDiscoveredAdminState:
+			watcher.DiscoveredDevice.AdminState = value
+		case column == csvDiscoveredAutoEvents:
+			autoEvents, err := parseCSVAutoEvents(value)
+			if err != nil {
+				return watcher, err
+			}
+			watcher.DiscoveredDevice.AutoEvents = autoEvents
+		case strings.HasPrefix(column, csvIdentifiersPrefix):
+			watcher.Identifiers[strings.TrimPrefix(column, csvIdentifiersPrefix)] = value
+		case strings.HasPrefix(column, csvBlockingPrefix):
+			if watcher.BlockingIdentifiers == nil {
+				watcher.BlockingIdentifiers = make(map[string][]string)
+			}
+			watcher.BlockingIdentifiers[strings.TrimPrefix(column, csvBlockingPrefix)] = splitCSVList(value)
+		case strings.HasPrefix(column, csvDiscoveredProperties):
+			if watcher.DiscoveredDevice.Properties == nil {
+				watcher.DiscoveredDevice.Properties = make(map[string]any)
+			}
+			watcher.DiscoveredDevice.Properties[strings.TrimPrefix(column, csvDiscoveredProperties)] = value
+		}
+	}
+	if err := contractsCommon.Validate(watcher); err != nil {
+		return watcher, err
+	}
+	return watcher, nil
+}
@@ -0,0 +1,110 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provision
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/device-sdk-go/v4/internal/cache"
+)
+
+func Test_devicesFromCSV(t *testing.T) {
+	content := `name,profileName,labels,autoEvents,modbus-tcp.Address,modbus-tcp.Port,tags.room,properties.vendor
+Meter-1,Meter,"industrial; meter",Power:10s;Energy:1m:true,10.0.0.1,502,Lobby,Acme
+,,,,,,,
+Meter-2,Meter,,,10.0.0.2,502,,
+Meter-3,Meter,,Power,10.0.0.3,502,,
+,Meter,,,10.0.0.4,502,,
+Meter-1,Meter,,,10.0.0.5,502,,
+Meter-4,Meter,,Power:fast,10.0.0.6,502,,
+`
+	devices, err := devicesFromCSV([]byte(content), "meters.csv")
+	require.Error(t, err)
+	assert.Equal(t, errors.KindContractInvalid, errors.Kind(err))
+	assert.Contains(t, err.Error(), "row 5:")
+	assert.Contains(t, err.Error(), "row 6:")
+	assert.Contains(t, err.Error(), "row 7: Device Meter-1 is already defined in row 2")
+	assert.Contains(t, err.Error(), "row 8:", "Device of the row is validated")
+
+	require.Len(t, devices, 2)
+	expected := dtos.Device{
+		Name:        "Meter-1",
+		ProfileName: "Meter",
+		Labels:      []string{"industrial", "meter"},
+		AutoEvents: []dtos.AutoEvent{
+			{SourceName: "Power", Interval: "10s"},
+			{SourceName: "Energy", Interval: "1m", OnChange: true},
+		},
+		Protocols:  map[string]dtos.ProtocolProperties{"modbus-tcp": {"Address": "10.0.0.1", "Port": "502"}},
+		Tags:       map[string]any{"room": "Lobby"},
+		Properties: map[string]any{"vendor": "Acme"},
+	}
+	assert.Equal(t, expected, devices[0])
+	assert.Equal(t, "Meter-2", devices[1].Name)
+	assert.Nil(t, devices[1].Tags)
+}
+
+func Test_devicesFromCSV_invalidHeader(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"unknown column", "name,address\nd,a\n"},
+		{"missing name", "profileName\np\n"},
+		{"duplicated column", "name,name\nd,d\n"},
+		{"inconsistent columns", "name,profileName\nd\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			devices, err := devicesFromCSV([]byte(tt.content), "devices.csv")
+			require.Error(t, err)
+			assert.Nil(t, devices)
+		})
+	}
+}
+
+func Test_provisionWatchersFromCSV(t *testing.T) {
+	content := `name,serviceName,identifiers.Address,blockingIdentifiers.Port,discoveredDevice.profileName,discoveredDevice.properties.vendor,discoveredDevice.autoEvents
+Watcher-1,device-simple,10\.0\.0\..*,397;398,Meter,Acme,Power:15s
+Watcher-2,device-simple,,,Meter,,
+`
+	watchers, err := provisionWatchersFromCSV([]byte(content), "watchers.csv")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "row 3:")
+
+	require.Len(t, watchers, 1)
+	watcher := watchers[0]
+	assert.Equal(t, "Watcher-1", watcher.Name)
+	assert.Equal(t, models.Unlocked, watcher.AdminState)
+	assert.Equal(t, map[string]string{"Address": `10\.0\.0\..*`}, watcher.Identifiers)
+	assert.Equal(t, map[string][]string{"Port": {"397", "398"}}, watcher.BlockingIdentifiers)
+	assert.Equal(t, "Meter", watcher.DiscoveredDevice.ProfileName)
+	assert.Equal(t, models.Unlocked, watcher.DiscoveredDevice.AdminState)
+	assert.Equal(t, map[string]any{"vendor": "Acme"}, watcher.DiscoveredDevice.Properties)
+	assert.Equal(t, []dtos.AutoEvent{{SourceName: "Power", Interval: "15s"}}, watcher.DiscoveredDevice.AutoEvents)
+}
+
+func Test_processDevices_csv(t *testing.T) {
+	path := writeFiles(t, map[string]string{"devices.csv": "name,other.Address\nCsv-Device,simple01\n,simple02\n"})
+	lc := logger.NewMockClient()
+	dic, _ := NewMockDIC()
+	require.NoError(t, cache.InitCache(TestDeviceService, TestDeviceService, dic))
+
+	// the valid rows are imported even though some rows are invalid
+	addDevicesReq, _ := processDevices(filepath.Join(path, "devices.csv"), "devices.csv", TestDeviceService, false, nil, lc)
+	require.Len(t, addDevicesReq, 1)
+	assert.Equal(t, "Csv-Device", addDevicesReq[0].Device.Name)
+	assert.Equal(t, TestDeviceService, addDevicesReq[0].Device.ServiceName)
+}
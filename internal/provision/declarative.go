@@ -0,0 +1,302 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provision
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v4/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v4/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/dtos/requests"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
+	"github.com/google/uuid"
+
+	"github.com/edgexfoundry/device-sdk-go/v4/internal/cache"
+	"github.com/edgexfoundry/device-sdk-go/v4/internal/common"
+	"github.com/edgexfoundry/device-sdk-go/v4/internal/container"
+)
+
+// Plan is the set of changes which makes the entries of the device service in core-metadata match the provisioning
+// files in the declarative provisioning mode
+type Plan[A any, U any] struct {
+	Add    []A
+	Update []U
+	// Delete is the names of the entries provisioned from the files before, which are absent from the files now
+	Delete []string
+}
+
+// Empty returns true if the Plan has no change
+func (p Plan[A, U]) Empty() bool {
+	return len(p.Add) == 0 && len(p.Update) == 0 && len(p.Delete) == 0
+}
+
+// DevicesPlan is the Plan of the Devices
+type DevicesPlan = Plan[requests.AddDeviceRequest, requests.UpdateDeviceRequest]
+
+// ProvisionWatchersPlan is the Plan of the ProvisionWatchers
+type ProvisionWatchersPlan = Plan[requests.AddProvisionWatcherRequest, requests.UpdateProvisionWatcherRequest]
+
+// LoadDevicesDeclarative makes the Devices of the device service match the files in the directory. The Devices
+// provisioned from the files before and absent from them now are deleted, and the changed Devices are updated while
+// keeping their admin and operating states. The Devices added otherwise, e.g. by the device discovery or the REST API,
+// are kept. The planned changes are only reported if dryRun is true.
+func LoadDevicesDeclarative(path string, dryRun bool, dic *di.Container) errors.EdgeX {
+	if path == "" {
+		return nil
+	}
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+
+	plan, err := PlanDevices(path, dic)
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+	reportPlan("Device",
+		namesOf(plan.Add, func(r requests.AddDeviceRequest) string { return r.Device.Name }),
+		namesOf(plan.Update, func(r requests.UpdateDeviceRequest) string { return *r.Device.Name }),
+		plan.Delete, dryRun, lc)
+	if dryRun || plan.Empty() {
+		return nil
+	}
+
+	ctx := context.WithValue(context.Background(), common.CorrelationHeaderKey, uuid.NewString())
+	if err = addDevices(ctx, plan.Add, dic); err != nil {
+		return err
+	}
+	if err = updateDevices(ctx, plan.Update, dic); err != nil {
+		return err
+	}
+	dc := bootstrapContainer.DeviceClientFrom(dic.Get)
+	for _, name := range plan.Delete {
+		if _, err = dc.DeleteDeviceByName(ctx, name); err != nil {
+			return errors.NewCommonEdgeX(errors.Kind(err), fmt.Sprintf("failed to delete Device %s", name), err)
+		}
+	}
+	return nil
+}
+
+// PlanDevices compares the Devices in the directory with the Devices of the device service in cache
+func PlanDevices(path string, dic *di.Container) (DevicesPlan, errors.EdgeX) {
+	var plan DevicesPlan
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	serviceName := container.DeviceServiceFrom(dic.Get).Name
+
+	var devices []dtos.Device
+	read, err := readDirectory(path, "Devices", lc, func(fullPath string) errors.EdgeX {
+		d, err := readDevices(fullPath, fullPath, nil, lc)
+		devices = append(devices, d...)
+		return err
+	})
+	if err != nil {
+		return plan, err
+	}
+
+	names := make(map[string]struct{}, len(devices))
+	for _, device := range devices {
+		if _, ok := names[device.Name]; ok {
+			return plan, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("Device %s is defined more than once in %s", device.Name, path), nil)
+		}
+		names[device.Name] = struct{}{}
+		device.Labels = withProvisionedLabel(device.Labels)
+
+		cached, ok := cache.Devices().ForName(device.Name)
+		if !ok {
+			device.ServiceName = serviceName
+			device.AdminState = models.Unlocked
+			device.OperatingState = models.Up
+			plan.Add = append(plan.Add, requests.NewAddDeviceRequest(device))
+			continue
+		}
+		device.Id = cached.Id
+		device.ServiceName = cached.ServiceName
+		device.AdminState = string(cached.AdminState)
+		device.OperatingState = string(cached.OperatingState)
+		desired := dtos.ToDeviceModel(device)
+		desired.DBTimestamp = cached.DBTimestamp
+		if !common.EquivalentJSON(dtos.FromDeviceModelToDTO(desired), dtos.FromDeviceModelToDTO(cached)) {
+			plan.Update = append(plan.Update, requests.NewUpdateDeviceRequest(dtos.FromDeviceModelToUpdateDTO(desired)))
+		}
+	}
+	for _, d := range cache.Devices().ForLabel(common.ProvisionedLabel) {
+		if _, ok := names[d.Name]; !ok {
+			plan.Delete = append(plan.Delete, d.Name)
+		}
+	}
+	sort.Strings(plan.Delete)
+	if err = checkDeletesAll("Devices", path, read, plan.Delete); err != nil {
+		return DevicesPlan{}, err
+	}
+	return plan, nil
+}
+
+// LoadProvisionWatchersDeclarative makes the ProvisionWatchers of the device service match the files in the directory.
+// The ProvisionWatchers provisioned from the files before and absent from them now are deleted, and the changed
+// ProvisionWatchers are updated while keeping their admin states. The ProvisionWatchers added otherwise, e.g. by the
+// REST API, are kept. The planned changes are only reported if dryRun is true.
+func LoadProvisionWatchersDeclarative(path string, dryRun bool, dic *di.Container) errors.EdgeX {
+	if path == "" {
+		return nil
+	}
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+
+	plan, err := PlanProvisionWatchers(path, dic)
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+	reportPlan("ProvisionWatcher",
+		namesOf(plan.Add, func(r requests.AddProvisionWatcherRequest) string { return r.ProvisionWatcher.Name }),
+		namesOf(plan.Update, func(r requests.UpdateProvisionWatcherRequest) string { return *r.ProvisionWatcher.Name }),
+		plan.Delete, dryRun, lc)
+	if dryRun || plan.Empty() {
+		return nil
+	}
+
+	ctx := context.WithValue(context.Background(), common.CorrelationHeaderKey, uuid.NewString())
+	if err = addProvisionWatchers(ctx, plan.Add, dic); err != nil {
+		return err
+	}
+	if err = updateProvisionWatchers(ctx, plan.Update, dic); err != nil {
+		return err
+	}
+	pwc := bootstrapContainer.ProvisionWatcherClientFrom(dic.Get)
+	for _, name := range plan.Delete {
+		if _, err = pwc.DeleteProvisionWatcherByName(ctx, name); err != nil {
+			return errors.NewCommonEdgeX(errors.Kind(err), fmt.Sprintf("failed to delete ProvisionWatcher %s", name), err)
+		}
+	}
+	return nil
+}
+
+// PlanProvisionWatchers compares the ProvisionWatchers in the directory with the ProvisionWatchers of the device
+// service in cache
+func PlanProvisionWatchers(path string, dic *di.Container) (ProvisionWatchersPlan, errors.EdgeX) {
+	var plan ProvisionWatchersPlan
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+
+	var watchers []dtos.ProvisionWatcher
+	read, err := readDirectory(path, "Provision Watchers", lc, func(fullPath string) errors.EdgeX {
+		w, err := readProvisionWatchers(fullPath, fullPath, nil, lc)
+		watchers = append(watchers, w...)
+		return err
+	})
+	if err != nil {
+		return plan, err
+	}
+
+	names := make(map[string]struct{}, len(watchers))
+	for _, watcher := range watchers {
+		if _, ok := names[watcher.Name]; ok {
+			return plan, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("ProvisionWatcher %s is defined more than once in %s", watcher.Name, path), nil)
+		}
+		names[watcher.Name] = struct{}{}
+		watcher.Labels = withProvisionedLabel(watcher.Labels)
+
+		cached, ok := cache.ProvisionWatchers().ForName(watcher.Name)
+		if !ok {
+			plan.Add = append(plan.Add, requests.NewAddProvisionWatcherRequest(watcher))
+			continue
+		}
+		watcher.Id = cached.Id
+		watcher.AdminState = string(cached.AdminState)
+		desired := dtos.ToProvisionWatcherModel(watcher)
+		desired.DBTimestamp = cached.DBTimestamp
+		if !common.EquivalentJSON(dtos.FromProvisionWatcherModelToDTO(desired), dtos.FromProvisionWatcherModelToDTO(cached)) {
+			plan.Update = append(plan.Update, requests.NewUpdateProvisionWatcherRequest(dtos.FromProvisionWatcherModelToUpdateDTO(desired)))
+		}
+	}
+	for _, pw := range cache.ProvisionWatchers().All() {
+		if _, ok := names[pw.Name]; !ok && slices.Contains(pw.Labels, common.ProvisionedLabel) {
+			plan.Delete = append(plan.Delete, pw.Name)
+		}
+	}
+	sort.Strings(plan.Delete)
+	if err = checkDeletesAll("ProvisionWatchers", path, read, plan.Delete); err != nil {
+		return ProvisionWatchersPlan{}, err
+	}
+	return plan, nil
+}
+
+// withProvisionedLabel returns the labels with the ProvisionedLabel, so the entry is deleted once it is removed from
+// the files
+func withProvisionedLabel(labels []string) []string {
+	if slices.Contains(labels, common.ProvisionedLabel) {
+		return labels
+	}
+	return append(slices.Clip(labels), common.ProvisionedLabel)
+}
+
+// checkDeletesAll refuses the plan deleting the provisioned entries when no file is read from the directory, as it is
+// more likely the directory is mounted wrong than all the files are removed on purpose
+func checkDeletesAll(description string, path string, read int, deletes []string) errors.EdgeX {
+	if read > 0 || len(deletes) == 0 {
+		return nil
+	}
+	errMsg := fmt.Sprintf("no %s file is read from %s, refusing to delete all the %d provisioned %s", description, path, len(deletes), description)
+	return errors.NewCommonEdgeX(errors.KindContractInvalid, errMsg, nil)
+}
+
+// readDirectory reads every file in the local directory and returns the number of the provisioning files read. Unlike
+// the additive loading, any unreadable file fails the whole directory, so the entries defined in a broken file are not
+// deleted.
+func readDirectory(path string, description string, lc logger.LoggingClient, read func(fullPath string) errors.EdgeX) (int, errors.EdgeX) {
+	if parsedUrl, err := url.Parse(path); err == nil && (parsedUrl.Scheme == "http" || parsedUrl.Scheme == "https") {
+		return 0, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("declarative provisioning of %s from URI %s is not supported", description, parsedUrl.Redacted()), nil)
+	}
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return 0, errors.NewCommonEdgeX(errors.KindServerError, fmt.Sprintf("failed to create absolute path for %s", description), err)
+	}
+	files, err := os.ReadDir(absPath)
+	if err != nil {
+		return 0, errors.NewCommonEdgeX(errors.KindServerError, fmt.Sprintf("failed to read directory for %s", description), err)
+	}
+
+	lc.Infof("Reading declared %s from %s(%d files found)", description, absPath, len(files))
+	var count int
+	for _, f := range files {
+		if f.IsDir() {
+			continue
+		}
+		fullPath := filepath.Join(absPath, f.Name())
+		if err := read(fullPath); err != nil {
+			return 0, err
+		}
+		if GetFileType(fullPath) != OTHER {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// reportPlan logs the names of the entries to add, update and delete
+func reportPlan(kind string, adds []string, updates []string, deletes []string, dryRun bool, lc logger.LoggingClient) {
+	mode := "Declarative provisioning"
+	if dryRun {
+		mode = "Declarative provisioning dry-run"
+	}
+	if len(adds) == 0 && len(updates) == 0 && len(deletes) == 0 {
+		lc.Infof("%s: %s entries are up to date", mode, kind)
+		return
+	}
+	lc.Infof("%s: %s entries to add %v, to update %v, to delete %v", mode, kind, adds, updates, deletes)
+}
+
+func namesOf[T any](reqs []T, name func(T) string) []string {
+	names := make([]string, len(reqs))
+	for i, req := range reqs {
+		names[i] = name(req)
+	}
+	return names
+}
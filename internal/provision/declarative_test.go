@@ -0,0 +1,192 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provision
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v4/bootstrap/container"
+	clientMocks "github.com/edgexfoundry/go-mod-core-contracts/v4/clients/interfaces/mocks"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/dtos/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/device-sdk-go/v4/internal/cache"
+	sdkCommon "github.com/edgexfoundry/device-sdk-go/v4/internal/common"
+)
+
+const declaredDevices = `deviceList:
+  - name: testDeviceWithTags
+    profileName: testProfile
+    tags:
+      testDeviceTagName: testDeviceTagValue
+      testDuplicateTagName: testDeviceTagValue
+  - name: newDevice
+    profileName: testProfile
+    protocols:
+      other:
+        Address: simple01
+`
+
+func writeFiles(t *testing.T, files map[string]string) string {
+	dir := t.TempDir()
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0600))
+	}
+	return dir
+}
+
+// labelProvisioned labels the cached Devices as provisioned from the files before
+func labelProvisioned(t *testing.T, names ...string) {
+	for _, name := range names {
+		device, ok := cache.Devices().ForName(name)
+		require.True(t, ok)
+		device.Labels = append(device.Labels, sdkCommon.ProvisionedLabel)
+		require.NoError(t, cache.Devices().Update(device))
+	}
+}
+
+func TestPlanDevices(t *testing.T) {
+	provisioned := []string{TestDeviceWithTags, TestDeviceWithoutTags}
+	tests := []struct {
+		name           string
+		files          map[string]string
+		provisioned    []string
+		expectedAdd    []string
+		expectedUpdate []string
+		expectedDelete []string
+		expectedErr    bool
+	}{
+		{"add and delete", map[string]string{"devices.yaml": declaredDevices, "notes.txt": "ignored"}, provisioned,
+			[]string{"newDevice"}, nil, []string{TestDeviceWithoutTags}, false},
+		{"update changed device", map[string]string{"devices.yaml": declaredDevices,
+			"other.json": `[{"name": "testDeviceWithoutTags", "profileName": "testProfile", "description": "changed"}]`}, provisioned,
+			[]string{"newDevice"}, []string{TestDeviceWithoutTags}, nil, false},
+		{"device not provisioned from the files is kept", map[string]string{"devices.yaml": declaredDevices}, []string{TestDeviceWithTags},
+			[]string{"newDevice"}, nil, nil, false},
+		{"device not provisioned from the files is labeled", map[string]string{"devices.yaml": declaredDevices}, nil,
+			[]string{"newDevice"}, []string{TestDeviceWithTags}, nil, false},
+		{"empty directory without provisioned devices", map[string]string{"notes.txt": "ignored"}, nil, nil, nil, nil, false},
+		{"empty directory does not delete all", map[string]string{"notes.txt": "ignored"}, provisioned, nil, nil, nil, true},
+		{"invalid file", map[string]string{"devices.yaml": declaredDevices, "broken.json": "{"}, provisioned, nil, nil, nil, true},
+		{"duplicate device", map[string]string{"devices.yaml": declaredDevices, "copy.yml": declaredDevices}, provisioned, nil, nil, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dic, _ := NewMockDIC()
+			require.NoError(t, cache.InitCache(TestDeviceService, TestDeviceService, dic))
+			labelProvisioned(t, tt.provisioned...)
+
+			plan, err := PlanDevices(writeFiles(t, tt.files), dic)
+			if tt.expectedErr {
+				require.Error(t, err)
+				assert.Equal(t, errors.KindContractInvalid, errors.Kind(err))
+				return
+			}
+			require.NoError(t, err)
+
+			var added, updated []string
+			for _, r := range plan.Add {
+				added = append(added, r.Device.Name)
+				assert.Equal(t, TestDeviceService, r.Device.ServiceName)
+				assert.Contains(t, r.Device.Labels, sdkCommon.ProvisionedLabel)
+			}
+			for _, r := range plan.Update {
+				updated = append(updated, *r.Device.Name)
+			}
+			assert.Equal(t, tt.expectedAdd, added)
+			assert.Equal(t, tt.expectedUpdate, updated)
+			assert.Equal(t, tt.expectedDelete, plan.Delete)
+		})
+	}
+}
+
+func TestLoadDevicesDeclarative(t *testing.T) {
+	path := writeFiles(t, map[string]string{"devices.yaml": declaredDevices})
+
+	t.Run("dry-run", func(t *testing.T) {
+		dic, _ := NewMockDIC()
+		require.NoError(t, cache.InitCache(TestDeviceService, TestDeviceService, dic))
+		dcMock := bootstrapContainer.DeviceClientFrom(dic.Get).(*clientMocks.DeviceClient)
+
+		require.NoError(t, LoadDevicesDeclarative(path, true, dic))
+		dcMock.AssertNotCalled(t, "Add", mock.Anything, mock.Anything)
+		dcMock.AssertNotCalled(t, "DeleteDeviceByName", mock.Anything, mock.Anything)
+	})
+	t.Run("apply", func(t *testing.T) {
+		dic, _ := NewMockDIC()
+		require.NoError(t, cache.InitCache(TestDeviceService, TestDeviceService, dic))
+		labelProvisioned(t, TestDeviceWithTags, TestDeviceWithoutTags)
+		dcMock := bootstrapContainer.DeviceClientFrom(dic.Get).(*clientMocks.DeviceClient)
+		dcMock.On("Add", mock.Anything, mock.Anything).Return([]common.BaseWithIdResponse{{BaseResponse: common.BaseResponse{StatusCode: 201}}}, nil)
+		dcMock.On("DeleteDeviceByName", mock.Anything, TestDeviceWithoutTags).Return(common.BaseResponse{}, nil)
+
+		require.NoError(t, LoadDevicesDeclarative(path, false, dic))
+		dcMock.AssertNumberOfCalls(t, "Add", 1)
+		dcMock.AssertCalled(t, "DeleteDeviceByName", mock.Anything, TestDeviceWithoutTags)
+		dcMock.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+}
+
+func TestPlanProvisionWatchers(t *testing.T) {
+	dic, _ := NewMockDIC()
+	require.NoError(t, cache.InitCache(TestDeviceService, TestDeviceService, dic))
+	cached := dtos.ToProvisionWatcherModel(dtos.ProvisionWatcher{
+		Name:        "cachedWatcher",
+		ServiceName: TestDeviceService,
+		Identifiers: map[string]string{"Address": "10.0.0.1"},
+		Labels:      []string{sdkCommon.ProvisionedLabel},
+		AdminState:  models.Unlocked,
+	})
+	require.NoError(t, cache.ProvisionWatchers().Add(cached))
+	removed := cached
+	removed.Name = "removedWatcher"
+	require.NoError(t, cache.ProvisionWatchers().Add(removed))
+	// the watcher added by the REST API is kept
+	added := cached
+	added.Name = "addedWatcher"
+	added.Labels = nil
+	require.NoError(t, cache.ProvisionWatchers().Add(added))
+
+	path := writeFiles(t, map[string]string{
+		"new.yaml": "name: newWatcher\nserviceName: testDeviceService\nadminState: UNLOCKED\nidentifiers:\n  Address: 10.0.0.2\ndiscoveredDevice:\n  adminState: UNLOCKED\n",
+		// the admin state of the cached watcher is kept, only the identifiers are changed
+		"cached.json": `{"name": "cachedWatcher", "serviceName": "testDeviceService", "adminState": "LOCKED", "identifiers": {"Address": "10.0.0.3"}, "discoveredDevice": {"adminState": "UNLOCKED"}}`,
+	})
+
+	plan, err := PlanProvisionWatchers(path, dic)
+	require.NoError(t, err)
+	require.Len(t, plan.Add, 1)
+	assert.Equal(t, "newWatcher", plan.Add[0].ProvisionWatcher.Name)
+	require.Len(t, plan.Update, 1)
+	assert.Equal(t, "cachedWatcher", *plan.Update[0].ProvisionWatcher.Name)
+	assert.Equal(t, models.Unlocked, *plan.Update[0].ProvisionWatcher.AdminState)
+	assert.Equal(t, []string{"removedWatcher"}, plan.Delete)
+}
+
+func TestLoadProvisionWatchersDeclarative(t *testing.T) {
+	dic, _ := NewMockDIC()
+	require.NoError(t, cache.InitCache(TestDeviceService, TestDeviceService, dic))
+	pwcMock := bootstrapContainer.ProvisionWatcherClientFrom(dic.Get).(*clientMocks.ProvisionWatcherClient)
+	pwcMock.On("Add", mock.Anything, mock.Anything).Return([]common.BaseWithIdResponse{
+		{BaseResponse: common.BaseResponse{StatusCode: 400, Message: "invalid ProvisionWatcher"}},
+	}, nil)
+	path := writeFiles(t, map[string]string{
+		"new.yaml": "name: newWatcher\nserviceName: testDeviceService\nadminState: UNLOCKED\nidentifiers:\n  Address: 10.0.0.2\ndiscoveredDevice:\n  adminState: UNLOCKED\n",
+	})
+
+	// the failed ProvisionWatcher of the response fails the loading
+	err := LoadProvisionWatchersDeclarative(path, false, dic)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid ProvisionWatcher")
+}
@@ -1,7 +1,7 @@
 // -*- Mode: Go; indent-tabs-mode: t -*-
 //
 // Copyright (C) 2017-2018 Canonical Ltd
-// Copyright (C) 2018-2023 IOTech Ltd
+// Copyright (C) 2018-2026 IOTech Ltd
 // Copyright (C) 2023 Intel Corporation
 //
 // SPDX-License-Identifier: Apache-2.0
@@ -9,7 +9,9 @@
 package provision
 
 import (
+	"bytes"
 	"context"
+	"crypto"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -51,7 +53,12 @@ func LoadDevices(path string, overwrite bool, dic *di.Container) errors.EdgeX {
 	}
 	if parsedUrl.Scheme == "http" || parsedUrl.Scheme == "https" {
 		secretProvider := bootstrapContainer.SecretProviderFrom(dic.Get)
-		addDevicesReq, updateDevicesReq, edgexErr = loadDevicesFromURI(path, parsedUrl, serviceName, overwrite, secretProvider, lc)
+		var publicKey crypto.PublicKey
+		publicKey, edgexErr = provisioningPublicKey(dic)
+		if edgexErr != nil {
+			return edgexErr
+		}
+		addDevicesReq, updateDevicesReq, edgexErr = loadDevicesFromURI(path, parsedUrl, serviceName, overwrite, publicKey, secretProvider, lc)
 		if edgexErr != nil {
 			return edgexErr
 		}
@@ -62,17 +69,78 @@ func LoadDevices(path string, overwrite bool, dic *di.Container) errors.EdgeX {
 		}
 	}
 
+	return provisionDevices(addDevicesReq, updateDevicesReq, dic)
+}
+
+// LoadDeviceFiles loads the Devices of the given files only, e.g. the files changed in the DevicesDir. The removed
+// files are skipped, as their Devices are not deleted by the additive provisioning.
+func LoadDeviceFiles(files []string, overwrite bool, dic *di.Container) errors.EdgeX {
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	serviceName := container.DeviceServiceFrom(dic.Get).Name
+	var addDevicesReq []requests.AddDeviceRequest
+	var updateDevicesReq []requests.UpdateDeviceRequest
+	for _, fullPath := range existingFiles(files) {
+		lc.Infof("Loading pre-defined Devices from %s", fullPath)
+		processedDevicesReq, processedUpdateDevicesReq := processDevices(fullPath, fullPath, serviceName, overwrite, nil, lc)
+		addDevicesReq = append(addDevicesReq, processedDevicesReq...)
+		updateDevicesReq = append(updateDevicesReq, processedUpdateDevicesReq...)
+	}
+	return provisionDevices(addDevicesReq, updateDevicesReq, dic)
+}
+
+// provisionDevices sends the Devices loaded from the files to core-metadata
+func provisionDevices(addDevicesReq []requests.AddDeviceRequest, updateDevicesReq []requests.UpdateDeviceRequest, dic *di.Container) errors.EdgeX {
 	if len(addDevicesReq) == 0 && len(updateDevicesReq) == 0 {
 		return nil
 	}
-	dc := bootstrapContainer.DeviceClientFrom(dic.Get)
+	addDevicesReq, updateDevicesReq = skipDuplicateDeviceNames(addDevicesReq, updateDevicesReq, bootstrapContainer.LoggingClientFrom(dic.Get))
 	ctx := context.WithValue(context.Background(), common.CorrelationHeaderKey, uuid.NewString())
+	if edgexErr := addDevices(ctx, addDevicesReq, dic); edgexErr != nil {
+		return edgexErr
+	}
+	return updateDevices(ctx, updateDevicesReq, dic)
+}
+
+// skipDuplicateDeviceNames skips the Devices defined again in the other files, the first definition is kept as the
+// files are loaded in order. The duplicates in a single file, e.g. by overlapping device templates, fail the file.
+func skipDuplicateDeviceNames(addDevicesReq []requests.AddDeviceRequest, updateDevicesReq []requests.UpdateDeviceRequest, lc logger.LoggingClient) ([]requests.AddDeviceRequest, []requests.UpdateDeviceRequest) {
+	names := make(map[string]struct{}, len(addDevicesReq)+len(updateDevicesReq))
+	unique := func(name string) bool {
+		if _, ok := names[name]; ok {
+			lc.Errorf("Device %s is defined more than once in the Devices files, skipping the duplicate", name)
+			return false
+		}
+		names[name] = struct{}{}
+		return true
+	}
+	var uniqueAddReq []requests.AddDeviceRequest
+	for _, req := range addDevicesReq {
+		if unique(req.Device.Name) {
+			uniqueAddReq = append(uniqueAddReq, req)
+		}
+	}
+	var uniqueUpdateReq []requests.UpdateDeviceRequest
+	for _, req := range updateDevicesReq {
+		if unique(*req.Device.Name) {
+			uniqueUpdateReq = append(uniqueUpdateReq, req)
+		}
+	}
+	return uniqueAddReq, uniqueUpdateReq
+}
+
+// addDevices adds the Devices to core-metadata, the Devices owned by other device services are skipped
+func addDevices(ctx context.Context, addDevicesReq []requests.AddDeviceRequest, dic *di.Container) errors.EdgeX {
+	if len(addDevicesReq) == 0 {
+		return nil
+	}
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	dc := bootstrapContainer.DeviceClientFrom(dic.Get)
 	responses, edgexErr := dc.Add(ctx, addDevicesReq)
 	if edgexErr != nil {
 		return edgexErr
 	}
 
-	err = nil
+	var err error
 	for _, response := range responses {
 		if response.StatusCode != http.StatusCreated {
 			if response.StatusCode == http.StatusConflict {
@@ -87,13 +155,22 @@ func LoadDevices(path string, overwrite bool, dic *di.Container) errors.EdgeX {
 	if err != nil {
 		return errors.NewCommonEdgeXWrapper(err)
 	}
+	return nil
+}
 
+// updateDevices updates the Devices in core-metadata, the Devices owned by other device services are skipped
+func updateDevices(ctx context.Context, updateDevicesReq []requests.UpdateDeviceRequest, dic *di.Container) errors.EdgeX {
+	if len(updateDevicesReq) == 0 {
+		return nil
+	}
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	dc := bootstrapContainer.DeviceClientFrom(dic.Get)
 	updateResponses, edgexErr := dc.Update(ctx, updateDevicesReq)
 	if edgexErr != nil {
 		return edgexErr
 	}
 
-	err = nil
+	var err error
 	for _, response := range updateResponses {
 		if response.StatusCode != http.StatusOK {
 			if response.StatusCode == http.StatusConflict {
@@ -108,7 +185,6 @@ func LoadDevices(path string, overwrite bool, dic *di.Container) errors.EdgeX {
 	if err != nil {
 		return errors.NewCommonEdgeXWrapper(err)
 	}
-
 	return nil
 }
 
@@ -143,18 +219,21 @@ func loadDevicesFromFile(path, serviceName string, overwrite bool, lc logger.Log
 	return addDevicesReq, updateDevicesReq, nil
 }
 
-func loadDevicesFromURI(inputURI string, parsedURI *url.URL, serviceName string, overwrite bool, secretProvider interfaces.SecretProvider, lc logger.LoggingClient) ([]requests.AddDeviceRequest, []requests.UpdateDeviceRequest, errors.EdgeX) {
+func loadDevicesFromURI(inputURI string, parsedURI *url.URL, serviceName string, overwrite bool, publicKey crypto.PublicKey, secretProvider interfaces.SecretProvider, lc logger.LoggingClient) ([]requests.AddDeviceRequest, []requests.UpdateDeviceRequest, errors.EdgeX) {
 	// the input URI contains the index file containing the Device list to be loaded
 	bytes, err := file.Load(inputURI, secretProvider, lc)
 	if err != nil {
 		return nil, nil, errors.NewCommonEdgeX(errors.KindServerError, fmt.Sprintf("failed to load Devices list from URI %s", parsedURI.Redacted()), err)
 	}
 
-	var files []string
+	var files []indexEntry
 	err = json.Unmarshal(bytes, &files)
 	if err != nil {
 		return nil, nil, errors.NewCommonEdgeX(errors.KindServerError, "could not unmarshal Devices list contents", err)
 	}
+	if edgexErr := verifyIndex(bytes, parsedURI, files, publicKey, secretProvider, lc); edgexErr != nil {
+		return nil, nil, edgexErr
+	}
 
 	if len(files) == 0 {
 		lc.Infof("Index file %s for Devices list is empty", parsedURI.Redacted())
@@ -164,9 +243,26 @@ func loadDevicesFromURI(inputURI string, parsedURI *url.URL, serviceName string,
 	lc.Infof("Loading pre-defined devices from %s(%d files found)", parsedURI.Redacted(), len(files))
 	var addDevicesReq, processedDevicesReq []requests.AddDeviceRequest
 	var updateDevicesReq, processedUpdateDevicesReq []requests.UpdateDeviceRequest
-	for _, file := range files {
-		fullPath, redactedPath := GetFullAndRedactedURI(parsedURI, file, "Device", lc)
-		processedDevicesReq, processedUpdateDevicesReq = processDevices(fullPath, redactedPath, serviceName, overwrite, secretProvider, lc)
+	for _, entry := range files {
+		fullPath, redactedPath := GetFullAndRedactedURI(parsedURI, entry.File, "Device", lc)
+		if GetFileType(fullPath) == OTHER {
+			continue
+		}
+		content, edgexErr := loadIndexedFile(fullPath, redactedPath, entry, secretProvider, lc)
+		if edgexErr != nil {
+			// the files failed the digest verification abort the loading, so nothing from the index file is added
+			if errors.Kind(edgexErr) != errors.KindIOError {
+				return nil, nil, errors.NewCommonEdgeX(errors.KindContractInvalid, "failed to load Devices", edgexErr)
+			}
+			lc.Error(edgexErr.Error())
+			continue
+		}
+		// the devices of the valid rows of a csv file are still returned along with the errors of the invalid rows
+		devices, edgexErr := decodeDevices(content, fullPath, redactedPath, secretProvider, lc)
+		if edgexErr != nil {
+			lc.Error(edgexErr.Error())
+		}
+		processedDevicesReq, processedUpdateDevicesReq = deviceRequests(devices, serviceName, overwrite, lc)
 		if len(processedDevicesReq) > 0 {
 			addDevicesReq = append(addDevicesReq, processedDevicesReq...)
 		}
@@ -178,41 +274,18 @@ func loadDevicesFromURI(inputURI string, parsedURI *url.URL, serviceName string,
 }
 
 func processDevices(fullPath, displayPath, serviceName string, overwrite bool, secretProvider interfaces.SecretProvider, lc logger.LoggingClient) ([]requests.AddDeviceRequest, []requests.UpdateDeviceRequest) {
-	var devices []dtos.Device
-	var addDevicesReq []requests.AddDeviceRequest
-	var updateDevicesReq []requests.UpdateDeviceRequest
-
-	fileType := GetFileType(fullPath)
-
-	// if the file type is not yaml or json, it cannot be parsed - just return to not break the loop for other devices
-	if fileType == OTHER {
-		return nil, nil
-	}
-
-	content, err := file.Load(fullPath, secretProvider, lc)
+	// the devices of the valid rows of a csv file are still returned along with the errors of the invalid rows
+	devices, err := readDevices(fullPath, displayPath, secretProvider, lc)
 	if err != nil {
-		lc.Errorf("Failed to read Devices from %s: %v", displayPath, err)
-		return nil, nil
+		lc.Error(err.Error())
 	}
+	return deviceRequests(devices, serviceName, overwrite, lc)
+}
 
-	switch fileType {
-	case YAML:
-		d := struct {
-			DeviceList []dtos.Device `yaml:"deviceList"`
-		}{}
-		err = yaml.Unmarshal(content, &d)
-		if err != nil {
-			lc.Errorf("Failed to YAML decode Devices from %s: %v", displayPath, err)
-			return nil, nil
-		}
-		devices = d.DeviceList
-	case JSON:
-		err = json.Unmarshal(content, &devices)
-		if err != nil {
-			lc.Errorf("Failed to JSON decode Devices from %s: %v", displayPath, err)
-			return nil, nil
-		}
-	}
+// deviceRequests returns the requests to add the Devices not in the cache and to update the cached ones if overwrite
+func deviceRequests(devices []dtos.Device, serviceName string, overwrite bool, lc logger.LoggingClient) ([]requests.AddDeviceRequest, []requests.UpdateDeviceRequest) {
+	var addDevicesReq []requests.AddDeviceRequest
+	var updateDevicesReq []requests.UpdateDeviceRequest
 
 	for _, device := range devices {
 		if cachedDev, ok := cache.Devices().ForName(device.Name); ok {
@@ -240,3 +313,68 @@ func processDevices(fullPath, displayPath, serviceName string, overwrite bool, s
 	}
 	return addDevicesReq, updateDevicesReq
 }
+
+// readDevices reads the Devices from the YAML, JSON or CSV file, returns nil if the file is of other types. The
+// Devices of the valid rows of the CSV file are returned along with the errors of the invalid rows.
+func readDevices(fullPath, displayPath string, secretProvider interfaces.SecretProvider, lc logger.LoggingClient) ([]dtos.Device, errors.EdgeX) {
+	// if the file type is not yaml or json, it cannot be parsed - just return to not break the loop for other devices
+	if GetFileType(fullPath) == OTHER {
+		return nil, nil
+	}
+
+	content, err := file.Load(fullPath, secretProvider, lc)
+	if err != nil {
+		return nil, errors.NewCommonEdgeX(errors.KindIOError, fmt.Sprintf("Failed to read Devices from %s", displayPath), err)
+	}
+	return decodeDevices(content, fullPath, displayPath, secretProvider, lc)
+}
+
+// decodeDevices decodes the Devices from the content of the YAML, JSON or CSV file, the template CSV files are
+// loaded relative to the fullPath
+func decodeDevices(content []byte, fullPath, displayPath string, secretProvider interfaces.SecretProvider, lc logger.LoggingClient) ([]dtos.Device, errors.EdgeX) {
+	var devices []dtos.Device
+	var err error
+
+	fileType := GetFileType(fullPath)
+	if fileType == CSV {
+		return devicesFromCSV(content, displayPath)
+	}
+
+	// the YAML file and the JSON object contain the Devices and the device templates, the JSON array contains the Devices
+	d := struct {
+		DeviceList      []dtos.Device    `json:"deviceList" yaml:"deviceList"`
+		DeviceTemplates []DeviceTemplate `json:"deviceTemplates" yaml:"deviceTemplates"`
+	}{}
+	switch fileType {
+	case YAML:
+		err = yaml.Unmarshal(content, &d)
+		if err != nil {
+			return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("Failed to YAML decode Devices from %s", displayPath), err)
+		}
+	case JSON:
+		if trimmed := bytes.TrimSpace(content); len(trimmed) > 0 && trimmed[0] == '{' {
+			err = json.Unmarshal(content, &d)
+		} else {
+			err = json.Unmarshal(content, &d.DeviceList)
+		}
+		if err != nil {
+			return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("Failed to JSON decode Devices from %s", displayPath), err)
+		}
+	}
+	devices = d.DeviceList
+
+	expanded, edgexErr := expandTemplates(d.DeviceTemplates, fullPath, secretProvider, lc)
+	if edgexErr != nil {
+		return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("Failed to expand device templates from %s", displayPath), edgexErr)
+	}
+	devices = append(devices, expanded...)
+
+	names := make(map[string]struct{}, len(devices))
+	for _, device := range devices {
+		if _, ok := names[device.Name]; ok {
+			return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("Device %s is defined more than once in %s", device.Name, displayPath), nil)
+		}
+		names[device.Name] = struct{}{}
+	}
+	return devices, nil
+}
@@ -1,7 +1,7 @@
 // -*- Mode: Go; indent-tabs-mode: t -*-
 //
 // # Copyright (C) 2023 Intel Corporation
-// # Copyright (C) 2025 IOTech Ltd
+// # Copyright (C) 2025-2026 IOTech Ltd
 //
 // SPDX-License-Identifier: Apache-2.0
 package provision
@@ -14,6 +14,7 @@ import (
 	"github.com/edgexfoundry/device-sdk-go/v4/internal/cache"
 	"github.com/edgexfoundry/go-mod-bootstrap/v4/bootstrap/interfaces"
 	"github.com/edgexfoundry/go-mod-core-contracts/v4/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/dtos"
 	"github.com/edgexfoundry/go-mod-core-contracts/v4/dtos/requests"
 	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
 	"github.com/stretchr/testify/assert"
@@ -88,7 +89,7 @@ func Test_loadDevicesFromURI(t *testing.T) {
 			require.NoError(t, edgexErr)
 			parsedURI, err := url.Parse(tt.path)
 			require.NoError(t, err)
-			addDeviceReq, updateDeviceReq, edgexErr = loadDevicesFromURI(tt.path, parsedURI, tt.serviceName, false, tt.secretProvider, lc)
+			addDeviceReq, updateDeviceReq, edgexErr = loadDevicesFromURI(tt.path, parsedURI, tt.serviceName, false, nil, tt.secretProvider, lc)
 			assert.Equal(t, tt.expectedNumDevices, len(addDeviceReq))
 			assert.Equal(t, 0, len(updateDeviceReq))
 			if edgexErr != nil {
@@ -97,3 +98,21 @@ func Test_loadDevicesFromURI(t *testing.T) {
 		})
 	}
 }
+
+func Test_skipDuplicateDeviceNames(t *testing.T) {
+	addDevicesReq := []requests.AddDeviceRequest{
+		requests.NewAddDeviceRequest(dtos.Device{Name: "device-1", Description: "first"}),
+		requests.NewAddDeviceRequest(dtos.Device{Name: "device-2"}),
+		requests.NewAddDeviceRequest(dtos.Device{Name: "device-1", Description: "duplicate"}),
+	}
+	name := "device-2"
+	updateDevicesReq := []requests.UpdateDeviceRequest{
+		requests.NewUpdateDeviceRequest(dtos.UpdateDevice{Name: &name}),
+	}
+
+	add, update := skipDuplicateDeviceNames(addDevicesReq, updateDevicesReq, logger.NewMockClient())
+	require.Len(t, add, 2)
+	assert.Equal(t, "first", add[0].Device.Description)
+	assert.Equal(t, "device-2", add[1].Device.Name)
+	assert.Empty(t, update)
+}
@@ -0,0 +1,224 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provision
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
+	"gopkg.in/yaml.v3"
+
+	"github.com/edgexfoundry/device-sdk-go/v4/internal/cache"
+)
+
+// the formats of the exported provisioning files
+const (
+	ExportFormatYAML = "yaml"
+	ExportFormatJSON = "json"
+)
+
+// the directories of the exported provisioning files, in the layout of the example device service res directory
+const (
+	exportProfilesDir          = "profiles"
+	exportDevicesDir           = "devices"
+	exportProvisionWatchersDir = "provisionwatchers"
+	exportDevicesFileName      = "devices"
+)
+
+var unsafeFileNameChars = regexp.MustCompile(`[^A-Za-z0-9._-]`)
+
+// the fields assigned by core-metadata, which are not in the provisioning files
+var exportCommonOmittedFields = []string{"id", "created", "modified"}
+
+// the Device fields set by the device service when the Devices are loaded
+var exportDeviceOmittedFields = []string{"serviceName", "adminState", "operatingState"}
+
+// the fields which are kept even if empty as they are required
+var exportRequiredFields = map[string]struct{}{"protocols": {}}
+
+// ExportFile is a provisioning file exported from the caches, Path is relative to the root of the exported files
+type ExportFile struct {
+	Path    string
+	Content []byte
+}
+
+// Export exports the Device Profiles, Devices and ProvisionWatchers in the caches to the provisioning files, which
+// can be loaded from the ProfilesDir, DevicesDir and ProvisionWatchersDir. Each Device Profile and ProvisionWatcher is
+// exported to its own file and the Devices to one file. The fields assigned by core-metadata, the states and the
+// service name of the Devices and the empty fields are omitted.
+func Export(format string) ([]ExportFile, errors.EdgeX) {
+	if format != ExportFormatYAML && format != ExportFormatJSON {
+		return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("export format %s is not supported, use %s or %s", format, ExportFormatYAML, ExportFormatJSON), nil)
+	}
+
+	var files []ExportFile
+
+	profiles := cache.Profiles().All()
+	sort.Slice(profiles, func(i, j int) bool { return profiles[i].Name < profiles[j].Name })
+	fileNames := make(map[string]struct{}, len(profiles))
+	for _, profile := range profiles {
+		node, err := exportNode(dtos.FromDeviceProfileModelToDTO(profile), []string{"linkedDeviceCount"})
+		if err != nil {
+			return nil, errors.NewCommonEdgeX(errors.KindServerError, fmt.Sprintf("failed to export Device Profile %s", profile.Name), err)
+		}
+		file, err := exportFile(exportProfilesDir, profile.Name, fileNames, node, format)
+		if err != nil {
+			return nil, errors.NewCommonEdgeX(errors.KindServerError, fmt.Sprintf("failed to export Device Profile %s", profile.Name), err)
+		}
+		files = append(files, file)
+	}
+
+	devices := cache.Devices().All()
+	if len(devices) > 0 {
+		sort.Slice(devices, func(i, j int) bool { return devices[i].Name < devices[j].Name })
+		deviceList := &yaml.Node{Kind: yaml.SequenceNode}
+		for _, device := range devices {
+			node, err := exportNode(dtos.FromDeviceModelToDTO(device), exportDeviceOmittedFields)
+			if err != nil {
+				return nil, errors.NewCommonEdgeX(errors.KindServerError, fmt.Sprintf("failed to export Device %s", device.Name), err)
+			}
+			deviceList.Content = append(deviceList.Content, node)
+		}
+		// the YAML Devices file is an object of the deviceList, the JSON Devices file is an array of the Devices
+		node := deviceList
+		if format == ExportFormatYAML {
+			node = &yaml.Node{Kind: yaml.MappingNode, Content: []*yaml.Node{{Kind: yaml.ScalarNode, Tag: "!!str", Value: "deviceList"}, deviceList}}
+		}
+		file, err := exportFile(exportDevicesDir, exportDevicesFileName, map[string]struct{}{}, node, format)
+		if err != nil {
+			return nil, errors.NewCommonEdgeX(errors.KindServerError, "failed to export Devices", err)
+		}
+		files = append(files, file)
+	}
+
+	watchers := cache.ProvisionWatchers().All()
+	sort.Slice(watchers, func(i, j int) bool { return watchers[i].Name < watchers[j].Name })
+	fileNames = make(map[string]struct{}, len(watchers))
+	for _, watcher := range watchers {
+		node, err := exportNode(dtos.FromProvisionWatcherModelToDTO(watcher), nil)
+		if err != nil {
+			return nil, errors.NewCommonEdgeX(errors.KindServerError, fmt.Sprintf("failed to export ProvisionWatcher %s", watcher.Name), err)
+		}
+		file, err := exportFile(exportProvisionWatchersDir, watcher.Name, fileNames, node, format)
+		if err != nil {
+			return nil, errors.NewCommonEdgeX(errors.KindServerError, fmt.Sprintf("failed to export ProvisionWatcher %s", watcher.Name), err)
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+// exportNode converts the DTO into a YAML node in the order of the JSON fields, without the omitted and empty fields
+func exportNode(dto any, omitted []string) (*yaml.Node, error) {
+	data, err := json.Marshal(dto)
+	if err != nil {
+		return nil, err
+	}
+	// JSON is YAML, so the node keeps the order of the fields
+	var document yaml.Node
+	if err = yaml.Unmarshal(data, &document); err != nil {
+		return nil, err
+	}
+	node := document.Content[0]
+	node.Content = withoutFields(node.Content, append(exportCommonOmittedFields, omitted...))
+	pruneNode(node)
+	return node, nil
+}
+
+// withoutFields removes the fields from the key and value pairs of a mapping node
+func withoutFields(content []*yaml.Node, fields []string) []*yaml.Node {
+	result := make([]*yaml.Node, 0, len(content))
+	for i := 0; i+1 < len(content); i += 2 {
+		omit := false
+		for _, field := range fields {
+			if content[i].Value == field {
+				omit = true
+				break
+			}
+		}
+		if !omit {
+			result = append(result, content[i], content[i+1])
+		}
+	}
+	return result
+}
+
+// pruneNode removes the empty fields recursively and resets the JSON styles, so the node is presented as the block
+// YAML and the strings are only quoted when required
+func pruneNode(node *yaml.Node) {
+	node.Style = 0
+	switch node.Kind {
+	case yaml.MappingNode:
+		content := make([]*yaml.Node, 0, len(node.Content))
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			pruneNode(key)
+			pruneNode(value)
+			if _, required := exportRequiredFields[key.Value]; !required && isEmptyNode(value) {
+				continue
+			}
+			content = append(content, key, value)
+		}
+		node.Content = content
+	case yaml.SequenceNode:
+		for _, element := range node.Content {
+			pruneNode(element)
+		}
+	}
+}
+
+func isEmptyNode(node *yaml.Node) bool {
+	switch node.Kind {
+	case yaml.MappingNode, yaml.SequenceNode:
+		return len(node.Content) == 0
+	case yaml.ScalarNode:
+		return node.Tag == "!!null" || (node.Tag == "!!str" && node.Value == "")
+	}
+	return false
+}
+
+// exportFile encodes the node into the file of the directory named after the entry, the names which are unsafe as
+// the file names or already used are changed
+func exportFile(dir, name string, used map[string]struct{}, node *yaml.Node, format string) (ExportFile, error) {
+	fileName := unsafeFileNameChars.ReplaceAllString(name, "_")
+	for i := 2; ; i++ {
+		if _, ok := used[fileName]; !ok {
+			break
+		}
+		fileName = fmt.Sprintf("%s-%d", unsafeFileNameChars.ReplaceAllString(name, "_"), i)
+	}
+	used[fileName] = struct{}{}
+
+	var content []byte
+	var err error
+	switch format {
+	case ExportFormatYAML:
+		// the same indentation as the example provisioning files
+		var buf bytes.Buffer
+		encoder := yaml.NewEncoder(&buf)
+		encoder.SetIndent(2)
+		if err = encoder.Encode(node); err == nil {
+			err = encoder.Close()
+		}
+		content = buf.Bytes()
+	case ExportFormatJSON:
+		var value any
+		if err = node.Decode(&value); err == nil {
+			content, err = json.MarshalIndent(value, "", "  ")
+		}
+	}
+	if err != nil {
+		return ExportFile{}, err
+	}
+	return ExportFile{Path: path.Join(dir, fileName+"."+format), Content: content}, nil
+}
@@ -0,0 +1,149 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provision
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/device-sdk-go/v4/internal/cache"
+)
+
+func exportSnapshot() cache.Snapshot {
+	return cache.Snapshot{
+		Profiles: []dtos.DeviceProfile{{
+			DeviceProfileBasicInfo: dtos.DeviceProfileBasicInfo{
+				DBTimestamp:  dtos.DBTimestamp{Created: 1, Modified: 2},
+				Id:           "profile-id",
+				Name:         "Meter A/B",
+				Manufacturer: "IOTech",
+			},
+			DeviceResources: []dtos.DeviceResource{{
+				Name:       "Voltage",
+				Properties: dtos.ResourceProperties{ValueType: common.ValueTypeFloat64, ReadWrite: common.ReadWrite_R},
+			}},
+			DeviceCommands: []dtos.DeviceCommand{{
+				Name:               "Readings",
+				ReadWrite:          common.ReadWrite_R,
+				ResourceOperations: []dtos.ResourceOperation{{DeviceResource: "Voltage"}},
+			}},
+		}},
+		Devices: []dtos.Device{{
+			Id:             "device-id",
+			Name:           "Meter-02",
+			ServiceName:    TestDeviceService,
+			ProfileName:    "Meter A/B",
+			AdminState:     models.Locked,
+			OperatingState: models.Down,
+			Protocols:      map[string]dtos.ProtocolProperties{"other": {"Address": "meter02"}},
+		}, {
+			Id:          "device-id-1",
+			Name:        "Meter-01",
+			ServiceName: TestDeviceService,
+			ProfileName: "Meter A/B",
+			Labels:      []string{"meter"},
+			Protocols:   map[string]dtos.ProtocolProperties{"other": {"Address": "meter01", "Port": "true"}},
+			AutoEvents:  []dtos.AutoEvent{{Interval: "10s", SourceName: "Readings"}},
+		}},
+		ProvisionWatchers: []dtos.ProvisionWatcher{{
+			Id:          "watcher-id",
+			Name:        "Meter-Watcher",
+			ServiceName: TestDeviceService,
+			Identifiers: map[string]string{"Address": "meter[0-9]+"},
+			AdminState:  models.Unlocked,
+			DiscoveredDevice: dtos.DiscoveredDevice{
+				ProfileName: "Meter A/B",
+				AdminState:  models.Unlocked,
+			},
+		}},
+	}
+}
+
+func TestExport(t *testing.T) {
+	dic, _ := NewMockDIC()
+	cache.InitCacheFromSnapshot(exportSnapshot(), dic)
+	lc := logger.NewMockClient()
+
+	for _, format := range []string{ExportFormatYAML, ExportFormatJSON} {
+		t.Run(format, func(t *testing.T) {
+			files, err := Export(format)
+			require.NoError(t, err)
+			require.Len(t, files, 3)
+
+			// the exported files are loaded the same as the provisioning files
+			dir := t.TempDir()
+			paths := make([]string, len(files))
+			for i, file := range files {
+				paths[i] = filepath.Join(dir, filepath.FromSlash(file.Path))
+				require.NoError(t, os.MkdirAll(filepath.Dir(paths[i]), 0755))
+				require.NoError(t, os.WriteFile(paths[i], file.Content, 0644))
+			}
+			assert.Equal(t, filepath.Join(dir, "profiles", "Meter_A_B."+format), paths[0])
+			assert.Equal(t, filepath.Join(dir, "devices", "devices."+format), paths[1])
+			assert.Equal(t, filepath.Join(dir, "provisionwatchers", "Meter-Watcher."+format), paths[2])
+
+			document, err := readProfile(paths[0], paths[0], nil, lc)
+			require.NoError(t, err)
+			assert.Empty(t, document.profile.Id)
+			assert.Equal(t, "Meter A/B", document.profile.Name)
+			assert.Equal(t, "IOTech", document.profile.Manufacturer)
+			assert.Equal(t, int64(0), document.profile.Created)
+			require.Len(t, document.profile.DeviceCommands, 1)
+			assert.Equal(t, "Voltage", document.profile.DeviceCommands[0].ResourceOperations[0].DeviceResource)
+
+			devices, err := readDevices(paths[1], paths[1], nil, lc)
+			require.NoError(t, err)
+			require.Len(t, devices, 2)
+			assert.Equal(t, "Meter-01", devices[0].Name, "devices are not sorted by name")
+			assert.Empty(t, devices[0].Id)
+			assert.Empty(t, devices[0].ServiceName)
+			assert.Empty(t, devices[1].AdminState)
+			assert.Equal(t, "true", devices[0].Protocols["other"]["Port"])
+			assert.Equal(t, []string{"meter"}, devices[0].Labels)
+			assert.Equal(t, "Readings", devices[0].AutoEvents[0].SourceName)
+
+			watchers, err := readProvisionWatchers(paths[2], paths[2], nil, lc)
+			require.NoError(t, err)
+			require.Len(t, watchers, 1)
+			assert.Empty(t, watchers[0].Id)
+			assert.Equal(t, "meter[0-9]+", watchers[0].Identifiers["Address"])
+			assert.Equal(t, "Meter A/B", watchers[0].DiscoveredDevice.ProfileName)
+		})
+	}
+}
+
+func TestExport_fileNameCollision(t *testing.T) {
+	dic, _ := NewMockDIC()
+	snapshot := exportSnapshot()
+	snapshot.Devices = nil
+	snapshot.ProvisionWatchers = nil
+	collision := snapshot.Profiles[0]
+	collision.Name = "Meter A_B"
+	snapshot.Profiles = append(snapshot.Profiles, collision)
+	cache.InitCacheFromSnapshot(snapshot, dic)
+
+	files, err := Export(ExportFormatYAML)
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+	assert.Equal(t, "profiles/Meter_A_B.yaml", files[0].Path)
+	assert.Equal(t, "profiles/Meter_A_B-2.yaml", files[1].Path)
+}
+
+func TestExport_invalidFormat(t *testing.T) {
+	_, err := Export("xml")
+	require.Error(t, err)
+	assert.Equal(t, errors.KindContractInvalid, errors.Kind(err))
+}
@@ -0,0 +1,266 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provision
+
+import (
+	"fmt"
+	"strings"
+
+	contractsCommon "github.com/edgexfoundry/go-mod-core-contracts/v4/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// ProfileComposition is the inheritance and composition of a Device Profile file. The Device Resources and Device
+// Commands are merged from the base profile, then from the mixins in order, then the removals are applied, and the
+// profile's own Device Resources and Device Commands override the merged ones of the same name.
+type ProfileComposition struct {
+	// Extends is the name of the base profile, the empty basic info fields of the profile are inherited from it too
+	Extends string `json:"extends,omitempty" yaml:"extends,omitempty"`
+	// Mixins are the names of the profiles whose Device Resources and Device Commands are merged after the base
+	Mixins []string `json:"mixins,omitempty" yaml:"mixins,omitempty"`
+	// Remove is the inherited Device Resources and Device Commands which are removed from the profile
+	Remove ProfileRemovals `json:"remove,omitempty" yaml:"remove,omitempty"`
+	// Abstract profiles are only used as the base or mixins of the other profiles and not sent to core-metadata
+	Abstract bool `json:"abstract,omitempty" yaml:"abstract,omitempty"`
+}
+
+// ProfileRemovals is the names of the inherited Device Resources and Device Commands to remove
+type ProfileRemovals struct {
+	DeviceResources []string `json:"deviceResources,omitempty" yaml:"deviceResources,omitempty"`
+	DeviceCommands  []string `json:"deviceCommands,omitempty" yaml:"deviceCommands,omitempty"`
+}
+
+func (c ProfileComposition) isEmpty() bool {
+	return c.Extends == "" && len(c.Mixins) == 0 && len(c.Remove.DeviceResources) == 0 && len(c.Remove.DeviceCommands) == 0 && !c.Abstract
+}
+
+// profileDocument is a Device Profile as it is read from the file, before the composition is resolved
+type profileDocument struct {
+	profile     dtos.DeviceProfile
+	composition ProfileComposition
+	displayPath string
+}
+
+// profileLookup returns the flat Device Profile which is not in the files, e.g. from core-metadata
+type profileLookup func(name string) (dtos.DeviceProfile, errors.EdgeX)
+
+// profileFailure is a profile document which failed to resolve
+type profileFailure struct {
+	document profileDocument
+	err      errors.EdgeX
+}
+
+// resolveProfiles resolves the profile documents into the flat Device Profiles, the abstract profiles are omitted.
+// The base profiles and mixins are looked up in the documents first, then by the lookup if it is not nil. The profiles
+// failed to resolve are returned as the failures, so are the profiles depending on them.
+func resolveProfiles(documents []profileDocument, lookup profileLookup) ([]dtos.DeviceProfile, []profileFailure) {
+	r := profileResolver{
+		documents: make(map[string]profileDocument, len(documents)),
+		resolved:  make(map[string]dtos.DeviceProfile, len(documents)),
+		resolving: make(map[string]struct{}),
+		lookup:    lookup,
+	}
+	var failures []profileFailure
+	var names []string
+	for _, document := range documents {
+		name := document.profile.Name
+		if existing, ok := r.documents[name]; ok {
+			err := errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("Device Profile %s is already defined in %s", name, existing.displayPath), nil)
+			failures = append(failures, profileFailure{document: document, err: err})
+			continue
+		}
+		r.documents[name] = document
+		names = append(names, name)
+	}
+
+	var profiles []dtos.DeviceProfile
+	for _, name := range names {
+		document := r.documents[name]
+		profile, err := r.resolve(name)
+		if err != nil {
+			failures = append(failures, profileFailure{document: document, err: err})
+			continue
+		}
+		if document.composition.Abstract {
+			continue
+		}
+		if !document.composition.isEmpty() {
+			if err := profile.Validate(); err != nil {
+				failures = append(failures, profileFailure{document: document, err: errors.NewCommonEdgeX(errors.KindContractInvalid, "resolved Device Profile is invalid", err)})
+				continue
+			}
+		}
+		profiles = append(profiles, profile)
+	}
+	return profiles, failures
+}
+
+type profileResolver struct {
+	documents map[string]profileDocument
+	resolved  map[string]dtos.DeviceProfile
+	resolving map[string]struct{}
+	lookup    profileLookup
+}
+
+func (r *profileResolver) resolve(name string) (dtos.DeviceProfile, errors.EdgeX) {
+	if profile, ok := r.resolved[name]; ok {
+		return profile, nil
+	}
+	document, ok := r.documents[name]
+	if !ok {
+		if r.lookup == nil {
+			return dtos.DeviceProfile{}, errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, fmt.Sprintf("Device Profile %s not found", name), nil)
+		}
+		profile, err := r.lookup(name)
+		if err != nil {
+			return dtos.DeviceProfile{}, errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, fmt.Sprintf("Device Profile %s not found", name), err)
+		}
+		r.resolved[name] = profile
+		return profile, nil
+	}
+	if _, ok := r.resolving[name]; ok {
+		return dtos.DeviceProfile{}, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("Device Profile %s inherits from itself", name), nil)
+	}
+	r.resolving[name] = struct{}{}
+	defer delete(r.resolving, name)
+
+	profile := document.profile
+	composition := document.composition
+	var resources []dtos.DeviceResource
+	var commands []dtos.DeviceCommand
+	if composition.Extends != "" {
+		base, err := r.resolve(composition.Extends)
+		if err != nil {
+			return dtos.DeviceProfile{}, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("failed to resolve base profile %s", composition.Extends), err)
+		}
+		inheritBasicInfo(&profile.DeviceProfileBasicInfo, base.DeviceProfileBasicInfo)
+		if profile.ApiVersion == "" {
+			profile.ApiVersion = base.ApiVersion
+		}
+		resources = mergeByName(resources, base.DeviceResources, deviceResourceName)
+		commands = mergeByName(commands, base.DeviceCommands, deviceCommandName)
+	}
+	for _, mixinName := range composition.Mixins {
+		mixin, err := r.resolve(mixinName)
+		if err != nil {
+			return dtos.DeviceProfile{}, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("failed to resolve mixin %s", mixinName), err)
+		}
+		resources = mergeByName(resources, mixin.DeviceResources, deviceResourceName)
+		commands = mergeByName(commands, mixin.DeviceCommands, deviceCommandName)
+	}
+
+	var err errors.EdgeX
+	if resources, err = removeByName(resources, composition.Remove.DeviceResources, deviceResourceName); err != nil {
+		return dtos.DeviceProfile{}, errors.NewCommonEdgeX(errors.KindContractInvalid, "failed to remove Device Resource", err)
+	}
+	if commands, err = removeByName(commands, composition.Remove.DeviceCommands, deviceCommandName); err != nil {
+		return dtos.DeviceProfile{}, errors.NewCommonEdgeX(errors.KindContractInvalid, "failed to remove Device Command", err)
+	}
+	profile.DeviceResources = mergeByName(resources, profile.DeviceResources, deviceResourceName)
+	profile.DeviceCommands = mergeByName(commands, profile.DeviceCommands, deviceCommandName)
+
+	r.resolved[name] = profile
+	return profile, nil
+}
+
+// decodeComposedProfileYAML decodes the Device Profile of a file with the composition from YAML. Unlike the YAML
+// decoding of the Device Profile DTO, the profile is not validated as it is partial before the composition is
+// resolved, the value types are still normalized.
+func decodeComposedProfileYAML(content []byte) (dtos.DeviceProfile, error) {
+	var alias struct {
+		dtos.DeviceProfileBasicInfo `yaml:",inline"`
+		DeviceResources             []dtos.DeviceResource `yaml:"deviceResources"`
+		DeviceCommands              []dtos.DeviceCommand  `yaml:"deviceCommands"`
+		ApiVersion                  string                `yaml:"apiVersion"`
+	}
+	if err := yaml.Unmarshal(content, &alias); err != nil {
+		return dtos.DeviceProfile{}, err
+	}
+	profile := dtos.DeviceProfile(alias)
+	for i, resource := range profile.DeviceResources {
+		valueType, err := contractsCommon.NormalizeValueType(resource.Properties.ValueType)
+		if err != nil {
+			return dtos.DeviceProfile{}, fmt.Errorf("Device Resource %s: %w", resource.Name, err)
+		}
+		profile.DeviceResources[i].Properties.ValueType = valueType
+	}
+	return profile, nil
+}
+
+// inheritBasicInfo fills the empty basic info fields from the base profile, the name is never inherited
+func inheritBasicInfo(info *dtos.DeviceProfileBasicInfo, base dtos.DeviceProfileBasicInfo) {
+	if info.Manufacturer == "" {
+		info.Manufacturer = base.Manufacturer
+	}
+	if info.Description == "" {
+		info.Description = base.Description
+	}
+	if info.Model == "" {
+		info.Model = base.Model
+	}
+	if len(info.Labels) == 0 {
+		info.Labels = base.Labels
+	}
+}
+
+func deviceResourceName(r dtos.DeviceResource) string { return r.Name }
+
+func deviceCommandName(c dtos.DeviceCommand) string { return c.Name }
+
+// mergeByName returns a new list of the inherited items overridden in place by the items of the same name, the other
+// items are appended in order
+func mergeByName[T any](inherited []T, items []T, nameOf func(T) string) []T {
+	if len(inherited) == 0 && len(items) == 0 {
+		return items
+	}
+	merged := make([]T, len(inherited), len(inherited)+len(items))
+	copy(merged, inherited)
+	index := make(map[string]int, len(merged))
+	for i, item := range merged {
+		index[nameOf(item)] = i
+	}
+	for _, item := range items {
+		if i, ok := index[nameOf(item)]; ok {
+			merged[i] = item
+			continue
+		}
+		index[nameOf(item)] = len(merged)
+		merged = append(merged, item)
+	}
+	return merged
+}
+
+// removeByName removes the named items, every name must be inherited
+func removeByName[T any](items []T, names []string, nameOf func(T) string) ([]T, errors.EdgeX) {
+	if len(names) == 0 {
+		return items, nil
+	}
+	remove := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		remove[name] = struct{}{}
+	}
+	result := make([]T, 0, len(items))
+	for _, item := range items {
+		if _, ok := remove[nameOf(item)]; ok {
+			delete(remove, nameOf(item))
+			continue
+		}
+		result = append(result, item)
+	}
+	if len(remove) > 0 {
+		var missing []string
+		for _, name := range names {
+			if _, ok := remove[name]; ok {
+				missing = append(missing, name)
+			}
+		}
+		return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("%s is not inherited", strings.Join(missing, ", ")), nil)
+	}
+	return result, nil
+}
@@ -0,0 +1,199 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provision
+
+import (
+	"context"
+	goErrors "errors"
+	"testing"
+
+	clientMocks "github.com/edgexfoundry/go-mod-core-contracts/v4/clients/interfaces/mocks"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/dtos/responses"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const baseMeterProfile = `
+name: Meter-Base
+abstract: true
+manufacturer: Meter Corp.
+model: M-01
+labels: [meter]
+deviceResources:
+  - name: Voltage
+    properties: {valueType: Float32, readWrite: R}
+  - name: Current
+    properties: {valueType: Float32, readWrite: R}
+  - name: Power
+    properties: {valueType: Float32, readWrite: R}
+deviceCommands:
+  - name: Readings
+    readWrite: R
+    resourceOperations:
+      - deviceResource: Voltage
+      - deviceResource: Current
+`
+
+const diagnosticsMixin = `
+name: Diagnostics
+abstract: true
+deviceResources:
+  - name: Uptime
+    properties: {valueType: Uint64, readWrite: R}
+`
+
+func resourceNames(profile dtos.DeviceProfile) []string {
+	var names []string
+	for _, r := range profile.DeviceResources {
+		names = append(names, r.Name)
+	}
+	return names
+}
+
+func Test_loadProfilesFromFile_inheritance(t *testing.T) {
+	tests := []struct {
+		name              string
+		files             map[string]string
+		expectedProfiles  []string
+		expectedResources [][]string
+	}{
+		{"extends base",
+			map[string]string{"base.yaml": baseMeterProfile, "meter.yaml": "name: Meter-A\nextends: Meter-Base\n"},
+			[]string{"Meter-A"}, [][]string{{"Voltage", "Current", "Power"}}},
+		{"extends base with mixin, override and removal",
+			map[string]string{"base.yaml": baseMeterProfile, "diagnostics.yaml": diagnosticsMixin, "meter.yaml": `
+name: Meter-B
+extends: Meter-Base
+mixins: [Diagnostics]
+remove:
+  deviceResources: [Power]
+deviceResources:
+  - name: Voltage
+    properties: {valueType: Float64, readWrite: R}
+  - name: Frequency
+    properties: {valueType: Float32, readWrite: R}
+`},
+			[]string{"Meter-B"}, [][]string{{"Voltage", "Current", "Uptime", "Frequency"}}},
+		{"command references inherited resource",
+			map[string]string{"base.yaml": baseMeterProfile, "meter.yaml": `
+name: Meter-D
+extends: Meter-Base
+deviceCommands:
+  - name: PowerReadings
+    readWrite: R
+    resourceOperations:
+      - deviceResource: Power
+`},
+			[]string{"Meter-D"}, [][]string{{"Voltage", "Current", "Power"}}},
+		{"multi-level inheritance",
+			map[string]string{"base.yaml": baseMeterProfile, "a.yaml": "name: Meter-A\nextends: Meter-Base\n", "b.yaml": "name: Meter-C\nextends: Meter-A\nremove:\n  deviceResources: [Power]\n"},
+			[]string{"Meter-A", "Meter-C"}, [][]string{{"Voltage", "Current", "Power"}, {"Voltage", "Current"}}},
+		{"base not found",
+			map[string]string{"meter.yaml": "name: Meter-A\nextends: Meter-Base\n"},
+			nil, nil},
+		{"inheritance cycle",
+			map[string]string{"a.yaml": "name: Meter-A\nextends: Meter-C\n", "c.yaml": "name: Meter-C\nextends: Meter-A\n"},
+			nil, nil},
+		{"removal not inherited",
+			map[string]string{"base.yaml": baseMeterProfile, "meter.yaml": "name: Meter-A\nextends: Meter-Base\nremove:\n  deviceResources: [Unknown]\n"},
+			nil, nil},
+		{"removal breaks inherited command",
+			map[string]string{"base.yaml": baseMeterProfile, "meter.yaml": "name: Meter-A\nextends: Meter-Base\nremove:\n  deviceResources: [Voltage]\n"},
+			nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := writeFiles(t, tt.files)
+			dpcMock := &clientMocks.DeviceProfileClient{}
+			dpcMock.On("DeviceProfileByName", context.Background(), mock.Anything).Return(responses.DeviceProfileResponse{}, errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, "not found", nil))
+
+			add, update, err := loadProfilesFromFile(dir, nil, false, dpcMock, logger.NewMockClient())
+			require.NoError(t, err)
+			assert.Empty(t, update)
+			require.Len(t, add, len(tt.expectedProfiles))
+			for i, req := range add {
+				assert.Equal(t, tt.expectedProfiles[i], req.Profile.Name)
+				assert.Equal(t, tt.expectedResources[i], resourceNames(req.Profile))
+				assert.Equal(t, "Meter Corp.", req.Profile.Manufacturer)
+			}
+		})
+	}
+}
+
+func Test_resolveProfiles_override(t *testing.T) {
+	dir := writeFiles(t, map[string]string{"base.yaml": baseMeterProfile, "meter.yaml": `
+name: Meter-B
+extends: Meter-Base
+manufacturer: Other Corp.
+deviceResources:
+  - name: Voltage
+    properties: {valueType: Float64, readWrite: R}
+`})
+	lc := logger.NewMockClient()
+	base, err := readProfile(dir+"/base.yaml", "base.yaml", nil, lc)
+	require.NoError(t, err)
+	meter, err := readProfile(dir+"/meter.yaml", "meter.yaml", nil, lc)
+	require.NoError(t, err)
+
+	profiles, failures := resolveProfiles([]profileDocument{*base, *meter}, nil)
+	assert.Empty(t, failures)
+	require.Len(t, profiles, 1)
+	assert.Equal(t, "Other Corp.", profiles[0].Manufacturer)
+	assert.Equal(t, "M-01", profiles[0].Model)
+	assert.Equal(t, []string{"meter"}, profiles[0].Labels)
+	assert.Equal(t, "Float64", profiles[0].DeviceResources[0].Properties.ValueType)
+	require.Len(t, profiles[0].DeviceCommands, 1)
+}
+
+func Test_resolveProfiles_lookup(t *testing.T) {
+	document := profileDocument{
+		profile:     dtos.DeviceProfile{DeviceProfileBasicInfo: dtos.DeviceProfileBasicInfo{Name: "Meter-A"}},
+		composition: ProfileComposition{Extends: "Simple-Device"},
+	}
+	base := dtos.DeviceProfile{
+		DeviceProfileBasicInfo: dtos.DeviceProfileBasicInfo{Name: "Simple-Device", Manufacturer: "Simple Corp."},
+		DeviceResources: []dtos.DeviceResource{
+			{Name: "SwitchButton", Properties: dtos.ResourceProperties{ValueType: "Bool", ReadWrite: "RW"}},
+		},
+	}
+	lookup := func(name string) (dtos.DeviceProfile, errors.EdgeX) {
+		if name == base.Name {
+			return base, nil
+		}
+		return dtos.DeviceProfile{}, errors.NewCommonEdgeXWrapper(goErrors.New("could not find profile"))
+	}
+
+	profiles, failures := resolveProfiles([]profileDocument{document}, lookup)
+	assert.Empty(t, failures)
+	require.Len(t, profiles, 1)
+	assert.Equal(t, "Meter-A", profiles[0].Name)
+	assert.Equal(t, "Simple Corp.", profiles[0].Manufacturer)
+	assert.Equal(t, []string{"SwitchButton"}, resourceNames(profiles[0]))
+}
+
+func Test_changedProfiles(t *testing.T) {
+	document := func(name, file string, composition ProfileComposition) profileDocument {
+		return profileDocument{profile: dtos.DeviceProfile{DeviceProfileBasicInfo: dtos.DeviceProfileBasicInfo{Name: name}}, composition: composition, displayPath: file}
+	}
+	documents := []profileDocument{
+		document("base", "/profiles/base.yaml", ProfileComposition{Abstract: true}),
+		document("mixin", "/profiles/mixin.yaml", ProfileComposition{Abstract: true}),
+		document("meter", "/profiles/meter.yaml", ProfileComposition{Extends: "base"}),
+		document("meter-v2", "/profiles/meter-v2.yaml", ProfileComposition{Extends: "meter"}),
+		document("sensor", "/profiles/sensor.yaml", ProfileComposition{Mixins: []string{"mixin"}}),
+		document("standalone", "/profiles/standalone.yaml", ProfileComposition{}),
+	}
+
+	assert.Equal(t, map[string]struct{}{"base": {}, "meter": {}, "meter-v2": {}},
+		changedProfiles(documents, []string{"/profiles/base.yaml"}))
+	assert.Equal(t, map[string]struct{}{"mixin": {}, "sensor": {}, "standalone": {}},
+		changedProfiles(documents, []string{"/profiles/mixin.yaml", "/profiles/standalone.yaml", "/profiles/removed.yaml"}))
+}
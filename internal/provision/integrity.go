@@ -0,0 +1,167 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provision
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/url"
+	"path"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v4/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v4/bootstrap/file"
+	"github.com/edgexfoundry/go-mod-bootstrap/v4/bootstrap/interfaces"
+	"github.com/edgexfoundry/go-mod-bootstrap/v4/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
+
+	"github.com/edgexfoundry/device-sdk-go/v4/internal/container"
+)
+
+// the extension of the detached signature file of an index file
+const signatureExt = ".sig"
+
+// indexEntry is a file listed in the index file of a provisioning URI. The entry is either the file name, or an
+// object of the file name and the hex encoded SHA-256 digest of the file.
+type indexEntry struct {
+	File   string `json:"file"`
+	SHA256 string `json:"sha256,omitempty"`
+}
+
+func (e *indexEntry) UnmarshalJSON(data []byte) error {
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '"' {
+		e.SHA256 = ""
+		return json.Unmarshal(data, &e.File)
+	}
+	type alias indexEntry
+	return json.Unmarshal(data, (*alias)(e))
+}
+
+// indexEntries returns the entries of the index file of the named files
+func indexEntries(files map[string]indexEntry) []indexEntry {
+	entries := make([]indexEntry, 0, len(files))
+	for _, entry := range files {
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// provisioningPublicKey loads the public key of the Provisioning configuration, nil if it is not set
+func provisioningPublicKey(dic *di.Container) (crypto.PublicKey, errors.EdgeX) {
+	publicKeyFile := container.ConfigurationFrom(dic.Get).Device.Provisioning.PublicKeyFile
+	if publicKeyFile == "" {
+		return nil, nil
+	}
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	content, err := file.Load(publicKeyFile, nil, lc)
+	if err != nil {
+		return nil, errors.NewCommonEdgeX(errors.KindIOError, fmt.Sprintf("failed to read provisioning public key from %s", publicKeyFile), err)
+	}
+	publicKey, err := parsePublicKey(content)
+	if err != nil {
+		return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("failed to parse provisioning public key from %s", publicKeyFile), err)
+	}
+	return publicKey, nil
+}
+
+// parsePublicKey parses the PEM encoded PKIX public key, which is Ed25519, ECDSA or RSA
+func parsePublicKey(content []byte) (crypto.PublicKey, error) {
+	block, _ := pem.Decode(content)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM block found")
+	}
+	publicKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	switch publicKey.(type) {
+	case ed25519.PublicKey, *ecdsa.PublicKey, *rsa.PublicKey:
+		return publicKey, nil
+	default:
+		return nil, fmt.Errorf("public key type %T is not supported", publicKey)
+	}
+}
+
+// verifyIndex verifies the index file against its detached signature, which is the base64 encoded signature in the
+// file of the index file name with the .sig extension. Every entry must have the digest, as the signature only
+// covers the files through their digests. Nothing is verified if the public key is nil.
+func verifyIndex(content []byte, parsedURI *url.URL, entries []indexEntry, publicKey crypto.PublicKey, secretProvider interfaces.SecretProvider, lc logger.LoggingClient) errors.EdgeX {
+	if publicKey == nil {
+		return nil
+	}
+	signatureURI, redactedSignatureURI := GetFullAndRedactedURI(parsedURI, path.Base(parsedURI.Path)+signatureExt, "index signature", lc)
+	encoded, err := file.Load(signatureURI, secretProvider, lc)
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.KindIOError, fmt.Sprintf("failed to load index signature from %s", redactedSignatureURI), err)
+	}
+	signature, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace(encoded)))
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("failed to base64 decode index signature from %s", redactedSignatureURI), err)
+	}
+	if err = verifySignature(publicKey, content, signature); err != nil {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("index file %s failed the signature verification", parsedURI.Redacted()), err)
+	}
+	for _, entry := range entries {
+		if entry.SHA256 == "" {
+			return errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("file %s in the signed index file %s has no sha256 digest", entry.File, parsedURI.Redacted()), nil)
+		}
+	}
+	lc.Infof("Index file %s signature verified", parsedURI.Redacted())
+	return nil
+}
+
+// verifySignature verifies the Ed25519 signature of the content, or the ECDSA ASN.1 or RSA PKCS #1 v1.5 signature of
+// the SHA-256 digest of the content
+func verifySignature(publicKey crypto.PublicKey, content []byte, signature []byte) error {
+	digest := sha256.Sum256(content)
+	switch key := publicKey.(type) {
+	case ed25519.PublicKey:
+		if !ed25519.Verify(key, content, signature) {
+			return fmt.Errorf("invalid Ed25519 signature")
+		}
+	case *ecdsa.PublicKey:
+		if !ecdsa.VerifyASN1(key, digest[:], signature) {
+			return fmt.Errorf("invalid ECDSA signature")
+		}
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature)
+	default:
+		return fmt.Errorf("public key type %T is not supported", publicKey)
+	}
+	return nil
+}
+
+// loadIndexedFile loads the file listed in the index file and verifies its digest if the entry has one, so the
+// tampered or truncated files are rejected before they are decoded
+func loadIndexedFile(fullPath, displayPath string, entry indexEntry, secretProvider interfaces.SecretProvider, lc logger.LoggingClient) ([]byte, errors.EdgeX) {
+	content, err := file.Load(fullPath, secretProvider, lc)
+	if err != nil {
+		return nil, errors.NewCommonEdgeX(errors.KindIOError, fmt.Sprintf("failed to load %s", displayPath), err)
+	}
+	if entry.SHA256 == "" {
+		return content, nil
+	}
+	expected, err := hex.DecodeString(entry.SHA256)
+	if err != nil || len(expected) != sha256.Size {
+		return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("sha256 digest %s of %s is not a hex encoded SHA-256 digest", entry.SHA256, displayPath), nil)
+	}
+	actual := sha256.Sum256(content)
+	if !bytes.Equal(expected, actual[:]) {
+		return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("sha256 digest of %s does not match the index file, the file may be tampered or truncated", displayPath), nil)
+	}
+	return content, nil
+}
@@ -0,0 +1,177 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provision
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/clients/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/device-sdk-go/v4/internal/cache"
+)
+
+const meterDevice01 = `
+deviceList:
+  - name: Meter-01
+    profileName: Meter-A
+    protocols:
+      other:
+        Address: meter01
+`
+
+const meterDevice02 = `{"deviceList": [{"name": "Meter-02", "profileName": "Meter-A", "protocols": {"other": {"Address": "meter02"}}}]}`
+
+func sha256Hex(content string) string {
+	digest := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(digest[:])
+}
+
+func TestLoadDevicesFromURI_integrity(t *testing.T) {
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	publicKey := privateKey.Public()
+	sign := func(index string) string {
+		return base64.StdEncoding.EncodeToString(ed25519.Sign(privateKey, []byte(index)))
+	}
+
+	digestIndex := fmt.Sprintf(`[{"file": "meter01.yaml", "sha256": "%s"}, {"file": "meter02.json", "sha256": "%s"}]`, sha256Hex(meterDevice01), sha256Hex(meterDevice02))
+	tamperedIndex := fmt.Sprintf(`[{"file": "meter01.yaml", "sha256": "%s"}, {"file": "meter02.json", "sha256": "%s"}]`, sha256Hex(meterDevice01), sha256Hex(meterDevice02[:20]))
+	mixedIndex := fmt.Sprintf(`["meter01.yaml", {"file": "meter02.json", "sha256": "%s"}]`, sha256Hex(meterDevice02))
+
+	tests := []struct {
+		name               string
+		index              string
+		signature          string
+		publicKey          crypto.PublicKey
+		expectedNumDevices int
+		expectedErr        bool
+	}{
+		{"valid - no digests", `["meter01.yaml", "meter02.json"]`, "", nil, 2, false},
+		{"valid - digests", digestIndex, "", nil, 2, false},
+		{"valid - some digests", mixedIndex, "", nil, 2, false},
+		{"valid - signed", digestIndex, sign(digestIndex), publicKey, 2, false},
+		{"invalid - digest mismatch", tamperedIndex, "", nil, 0, true},
+		{"invalid - malformed digest", `[{"file": "meter01.yaml", "sha256": "xyz"}]`, "", nil, 0, true},
+		{"invalid - signed digest mismatch", tamperedIndex, sign(tamperedIndex), publicKey, 0, true},
+		{"invalid - signature of other index", digestIndex, sign(mixedIndex), publicKey, 0, true},
+		{"invalid - signature missing", digestIndex, "", publicKey, 0, true},
+		{"invalid - signed without digests", mixedIndex, sign(mixedIndex), publicKey, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files := map[string]string{"index.json": tt.index, "meter01.yaml": meterDevice01, "meter02.json": meterDevice02}
+			if tt.signature != "" {
+				files["index.json.sig"] = tt.signature
+			}
+			server := httptest.NewServer(http.FileServer(http.Dir(writeFiles(t, files))))
+			defer server.Close()
+
+			dic, _ := NewMockDIC()
+			require.NoError(t, cache.InitCache(TestDeviceService, TestDeviceService, dic))
+			uri := server.URL + "/index.json"
+			parsedURI, err := url.Parse(uri)
+			require.NoError(t, err)
+
+			addDeviceReq, _, edgexErr := loadDevicesFromURI(uri, parsedURI, TestDeviceService, false, tt.publicKey, nil, logger.NewMockClient())
+			if tt.expectedErr {
+				require.Error(t, edgexErr)
+			} else {
+				require.NoError(t, edgexErr)
+			}
+			assert.Len(t, addDeviceReq, tt.expectedNumDevices)
+		})
+	}
+}
+
+func TestLoadProvisionWatchersFromURI_integrity(t *testing.T) {
+	index := fmt.Sprintf(`{"Meter-Watcher": {"file": "watcher.yaml", "sha256": "%s"}}`, sha256Hex(meterProvisionWatcher))
+	for _, content := range []string{meterProvisionWatcher, meterProvisionWatcher[:40]} {
+		files := map[string]string{"index.json": index, "watcher.yaml": content}
+		server := httptest.NewServer(http.FileServer(http.Dir(writeFiles(t, files))))
+
+		dic, _ := NewMockDIC()
+		require.NoError(t, cache.InitCache(TestDeviceService, TestDeviceService, dic))
+		uri := server.URL + "/index.json"
+		parsedURI, err := url.Parse(uri)
+		require.NoError(t, err)
+
+		addReq, _, edgexErr := loadProvisionWatchersFromURI(uri, parsedURI, false, nil, nil, logger.NewMockClient())
+		if content == meterProvisionWatcher {
+			require.NoError(t, edgexErr)
+			assert.Len(t, addReq, 1)
+		} else {
+			require.Error(t, edgexErr, "truncated file is not rejected")
+			assert.Empty(t, addReq)
+		}
+		server.Close()
+	}
+}
+
+func TestVerifySignature(t *testing.T) {
+	content := []byte(`["meter01.yaml"]`)
+	digest := sha256.Sum256(content)
+
+	_, ed25519Key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	ecdsaSignature, err := ecdsa.SignASN1(rand.Reader, ecdsaKey, digest[:])
+	require.NoError(t, err)
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	rsaSignature, err := rsa.SignPKCS1v15(rand.Reader, rsaKey, crypto.SHA256, digest[:])
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		publicKey crypto.PublicKey
+		signature []byte
+	}{
+		{"Ed25519", ed25519Key.Public(), ed25519.Sign(ed25519Key, content)},
+		{"ECDSA", ecdsaKey.Public(), ecdsaSignature},
+		{"RSA", rsaKey.Public(), rsaSignature},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			der, err := x509.MarshalPKIXPublicKey(tt.publicKey)
+			require.NoError(t, err)
+			publicKey, err := parsePublicKey(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+			require.NoError(t, err)
+
+			assert.NoError(t, verifySignature(publicKey, content, tt.signature))
+			assert.Error(t, verifySignature(publicKey, append(content, ' '), tt.signature), "modified content is verified")
+		})
+	}
+}
+
+func TestIndexEntry_UnmarshalJSON(t *testing.T) {
+	var entries []indexEntry
+	require.NoError(t, json.Unmarshal([]byte(`["a.yaml", {"file": "b.yaml", "sha256": "00ff"}]`), &entries))
+	assert.Equal(t, []indexEntry{{File: "a.yaml"}, {File: "b.yaml", SHA256: "00ff"}}, entries)
+
+	var named map[string]indexEntry
+	require.NoError(t, json.Unmarshal([]byte(`{"A": "a.yaml", "B": {"file": "b.yaml"}}`), &named))
+	assert.Equal(t, map[string]indexEntry{"A": {File: "a.yaml"}, "B": {File: "b.yaml"}}, named)
+}
@@ -1,7 +1,7 @@
 // -*- Mode: Go; indent-tabs-mode: t -*-
 //
 // Copyright (C) 2017-2018 Canonical Ltd
-// Copyright (C) 2018-2023 IOTech Ltd
+// Copyright (C) 2018-2026 IOTech Ltd
 // Copyright (C) 2023 Intel Corporation
 //
 // SPDX-License-Identifier: Apache-2.0
@@ -10,6 +10,7 @@ package provision
 
 import (
 	"context"
+	"crypto"
 	"encoding/json"
 	"fmt"
 	"net/url"
@@ -53,22 +54,45 @@ func LoadProfiles(path string, overwrite bool, dic *di.Container) errors.EdgeX {
 
 	if parsedUrl.Scheme == "http" || parsedUrl.Scheme == "https" {
 		secretProvider := bootstrapContainer.SecretProviderFrom(dic.Get)
-		addProfilesReq, updateProfilesReq, edgexErr = loadProfilesFromURI(path, parsedUrl, overwrite, dpc, secretProvider, lc)
+		var publicKey crypto.PublicKey
+		publicKey, edgexErr = provisioningPublicKey(dic)
+		if edgexErr != nil {
+			return edgexErr
+		}
+		addProfilesReq, updateProfilesReq, edgexErr = loadProfilesFromURI(path, parsedUrl, overwrite, dpc, publicKey, secretProvider, lc)
 		if edgexErr != nil {
 			return edgexErr
 		}
 	} else {
-		addProfilesReq, updateProfilesReq, edgexErr = loadProfilesFromFile(path, overwrite, dpc, lc)
+		addProfilesReq, updateProfilesReq, edgexErr = loadProfilesFromFile(path, nil, overwrite, dpc, lc)
 		if edgexErr != nil {
 			return edgexErr
 		}
 	}
 
+	return provisionProfiles(addProfilesReq, updateProfilesReq, dpc)
+}
+
+// LoadProfileFiles loads the Device Profiles of the given files in the ProfilesDir only, e.g. the changed files, and
+// the Device Profiles of the directory extending them or using them as mixins. The whole directory is read to resolve
+// the inheritance. The removed files are skipped, as their Device Profiles are not deleted.
+func LoadProfileFiles(path string, files []string, overwrite bool, dic *di.Container) errors.EdgeX {
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	dpc := bootstrapContainer.DeviceProfileClientFrom(dic.Get)
+	addProfilesReq, updateProfilesReq, edgexErr := loadProfilesFromFile(path, files, overwrite, dpc, lc)
+	if edgexErr != nil {
+		return edgexErr
+	}
+	return provisionProfiles(addProfilesReq, updateProfilesReq, dpc)
+}
+
+// provisionProfiles sends the Device Profiles loaded from the files to core-metadata
+func provisionProfiles(addProfilesReq, updateProfilesReq []requests.DeviceProfileRequest, dpc interfaces.DeviceProfileClient) errors.EdgeX {
 	if len(addProfilesReq) == 0 && len(updateProfilesReq) == 0 {
 		return nil
 	}
 	ctx := context.WithValue(context.Background(), common.CorrelationHeader, uuid.NewString()) // nolint:staticcheck
-	_, edgexErr = dpc.Add(ctx, addProfilesReq)
+	_, edgexErr := dpc.Add(ctx, addProfilesReq)
 	if edgexErr != nil {
 		return edgexErr
 	}
@@ -78,8 +102,9 @@ func LoadProfiles(path string, overwrite bool, dic *di.Container) errors.EdgeX {
 	return edgexErr
 }
 
-func loadProfilesFromFile(path string, overwrite bool, dpc interfaces.DeviceProfileClient, lc logger.LoggingClient) ([]requests.DeviceProfileRequest, []requests.DeviceProfileRequest, errors.EdgeX) {
-	var edgexErr errors.EdgeX
+// loadProfilesFromFile loads the Device Profiles of the directory, or only of the changed files and their dependants
+// if the changed files are given
+func loadProfilesFromFile(path string, changed []string, overwrite bool, dpc interfaces.DeviceProfileClient, lc logger.LoggingClient) ([]requests.DeviceProfileRequest, []requests.DeviceProfileRequest, errors.EdgeX) {
 	absPath, err := filepath.Abs(path)
 	if err != nil {
 		return nil, nil, errors.NewCommonEdgeX(errors.KindServerError, "failed to create absolute path for profiles", err)
@@ -95,122 +120,210 @@ func loadProfilesFromFile(path string, overwrite bool, dpc interfaces.DeviceProf
 	}
 
 	lc.Infof("Loading pre-defined Device Profiles from %s(%d files found)", absPath, len(files))
-	var addProfilesReq, processedProfilesReq []requests.DeviceProfileRequest
-	var updateProfilesReq, processedUpdateProfilesReq []requests.DeviceProfileRequest
+	var documents []profileDocument
 	for _, file := range files {
 		fullPath := filepath.Join(absPath, file.Name())
-		processedProfilesReq, processedUpdateProfilesReq, edgexErr = processProfiles(fullPath, fullPath, overwrite, nil, lc, dpc)
+		document, edgexErr := readProfile(fullPath, fullPath, nil, lc)
 		if edgexErr != nil {
-			return nil, nil, edgexErr
+			lc.Error(edgexErr.Error())
+			continue
 		}
-		if len(processedProfilesReq) > 0 {
-			addProfilesReq = append(addProfilesReq, processedProfilesReq...)
+		if document != nil {
+			documents = append(documents, *document)
 		}
+	}
+	var selected map[string]struct{}
+	if changed != nil {
+		selected = changedProfiles(documents, changed)
+	}
+	return profileRequests(documents, selected, overwrite, dpc, lc)
+}
 
-		if len(processedUpdateProfilesReq) > 0 {
-			updateProfilesReq = append(updateProfilesReq, processedUpdateProfilesReq...)
+// changedProfiles returns the names of the Device Profiles in the changed files and of the Device Profiles depending on
+// them by the inheritance
+func changedProfiles(documents []profileDocument, changed []string) map[string]struct{} {
+	changedFiles := make(map[string]struct{}, len(changed))
+	for _, file := range changed {
+		changedFiles[file] = struct{}{}
+	}
+	affected := make(map[string]struct{})
+	for _, document := range documents {
+		if _, ok := changedFiles[document.displayPath]; ok {
+			affected[document.profile.Name] = struct{}{}
 		}
 	}
-	return addProfilesReq, updateProfilesReq, nil
+	// the profiles extending the affected ones or using them as mixins are affected too
+	for found := true; found; {
+		found = false
+		for _, document := range documents {
+			if _, ok := affected[document.profile.Name]; ok {
+				continue
+			}
+			for _, base := range append([]string{document.composition.Extends}, document.composition.Mixins...) {
+				if _, ok := affected[base]; ok && base != "" {
+					affected[document.profile.Name] = struct{}{}
+					found = true
+					break
+				}
+			}
+		}
+	}
+	return affected
 }
 
-func loadProfilesFromURI(inputURI string, parsedURI *url.URL, overwrite bool, dpc interfaces.DeviceProfileClient, secretProvider bootstrapInterfaces.SecretProvider, lc logger.LoggingClient) ([]requests.DeviceProfileRequest, []requests.DeviceProfileRequest, errors.EdgeX) {
+func loadProfilesFromURI(inputURI string, parsedURI *url.URL, overwrite bool, dpc interfaces.DeviceProfileClient, publicKey crypto.PublicKey, secretProvider bootstrapInterfaces.SecretProvider, lc logger.LoggingClient) ([]requests.DeviceProfileRequest, []requests.DeviceProfileRequest, errors.EdgeX) {
 	// the input URI contains the index file containing the Profile list to be loaded
 	bytes, err := file.Load(inputURI, secretProvider, lc)
 	if err != nil {
 		return nil, nil, errors.NewCommonEdgeX(errors.KindServerError, fmt.Sprintf("failed to load Device Profile list from URI %s", parsedURI.Redacted()), err)
 	}
 
-	var files map[string]string
+	var files map[string]indexEntry
 	err = json.Unmarshal(bytes, &files)
 	if err != nil {
 		return nil, nil, errors.NewCommonEdgeX(errors.KindServerError, "could not unmarshal Profile list contents", err)
 	}
+	if edgexErr := verifyIndex(bytes, parsedURI, indexEntries(files), publicKey, secretProvider, lc); edgexErr != nil {
+		return nil, nil, edgexErr
+	}
 	if len(files) == 0 {
 		lc.Infof("Index file %s for Device Profiles list is empty", parsedURI.Redacted())
 		return nil, nil, nil
 	}
 
 	lc.Infof("Loading pre-defined Device Profiles from %s(%d files found)", parsedURI.Redacted(), len(files))
-	var addProfilesReq, processedProfilesReq []requests.DeviceProfileRequest
-	var updateProfilesReq, processedUpdateProfilesReq []requests.DeviceProfileRequest
-	for name, file := range files {
-		add, update, edgexErr := checkDeviceProfile(name, overwrite, dpc, lc)
-		if !add && !update {
-			if edgexErr != nil {
-				return addProfilesReq, updateProfilesReq, edgexErr
-			}
-		} else if add || update {
-			fullPath, redactedPath := GetFullAndRedactedURI(parsedURI, file, "Device Profile", lc)
-			processedProfilesReq, updateProfilesReq, edgexErr = processProfiles(fullPath, redactedPath, overwrite, secretProvider, lc, dpc)
-			if edgexErr != nil {
-				return nil, nil, edgexErr
-			}
-			if len(processedProfilesReq) > 0 {
-				addProfilesReq = append(addProfilesReq, processedProfilesReq...)
-			}
-
-			if len(processedUpdateProfilesReq) > 0 {
-				updateProfilesReq = append(updateProfilesReq, processedUpdateProfilesReq...)
+	// the existing profiles are skipped by profileRequests, after the inheritance is resolved as they may be the base
+	// profiles or mixins of the others
+	var documents []profileDocument
+	for _, entry := range files {
+		fullPath, redactedPath := GetFullAndRedactedURI(parsedURI, entry.File, "Device Profile", lc)
+		fileType := GetFileType(fullPath)
+		if fileType == OTHER || fileType == CSV {
+			continue
+		}
+		content, edgexErr := loadIndexedFile(fullPath, redactedPath, entry, secretProvider, lc)
+		if edgexErr != nil {
+			// the files failed the digest verification abort the loading, so nothing from the index file is added
+			if errors.Kind(edgexErr) != errors.KindIOError {
+				return nil, nil, errors.NewCommonEdgeX(errors.KindContractInvalid, "failed to load Device Profiles", edgexErr)
 			}
+			lc.Error(edgexErr.Error())
+			continue
+		}
+		document, edgexErr := decodeProfile(content, fileType, redactedPath)
+		if edgexErr != nil {
+			lc.Error(edgexErr.Error())
+			continue
 		}
+		documents = append(documents, *document)
 	}
-	return addProfilesReq, updateProfilesReq, nil
+	return profileRequests(documents, nil, overwrite, dpc, lc)
 }
 
+// processProfiles processes a single Device Profile file, its base profile and mixins are looked up in core-metadata
 func processProfiles(fullPath, displayPath string, overwrite bool, secretProvider bootstrapInterfaces.SecretProvider, lc logger.LoggingClient, dpc interfaces.DeviceProfileClient) ([]requests.DeviceProfileRequest, []requests.DeviceProfileRequest, errors.EdgeX) {
-	var profile dtos.DeviceProfile
-	var addProfilesReq []requests.DeviceProfileRequest
-	var updateProfilesReq []requests.DeviceProfileRequest
+	document, edgexErr := readProfile(fullPath, displayPath, secretProvider, lc)
+	if edgexErr != nil {
+		lc.Error(edgexErr.Error())
+		return nil, nil, nil
+	}
+	if document == nil {
+		return nil, nil, nil
+	}
+	return profileRequests([]profileDocument{*document}, nil, overwrite, dpc, lc)
+}
 
+// readProfile reads the Device Profile and its composition from the file, nil if the file type is not supported
+func readProfile(fullPath, displayPath string, secretProvider bootstrapInterfaces.SecretProvider, lc logger.LoggingClient) (*profileDocument, errors.EdgeX) {
 	fileType := GetFileType(fullPath)
 
-	// if the file type is not yaml or json, it cannot be parsed - just return to not break the loop for other devices
-	if fileType == OTHER {
-		return nil, nil, nil
+	// if the file type is not yaml or json, it cannot be parsed - just return to not break the loop for other devices.
+	// the profiles are not supported in csv as they are not flat
+	if fileType == OTHER || fileType == CSV {
+		return nil, nil
 	}
 
 	content, err := file.Load(fullPath, secretProvider, lc)
 	if err != nil {
-		lc.Errorf("Failed to read Device Profile from %s: %v", displayPath, err)
-		return nil, nil, nil
+		return nil, errors.NewCommonEdgeX(errors.KindIOError, fmt.Sprintf("Failed to read Device Profile from %s", displayPath), err)
 	}
+	return decodeProfile(content, fileType, displayPath)
+}
+
+// decodeProfile decodes the Device Profile and its composition from the content of the YAML or JSON file
+func decodeProfile(content []byte, fileType FileType, displayPath string) (*profileDocument, errors.EdgeX) {
+	var profile dtos.DeviceProfile
+	var composition ProfileComposition
+	var err error
 
 	switch fileType {
 	case YAML:
-		err = yaml.Unmarshal(content, &profile)
+		err = yaml.Unmarshal(content, &composition)
+		if err == nil {
+			if composition.isEmpty() {
+				err = yaml.Unmarshal(content, &profile)
+			} else {
+				profile, err = decodeComposedProfileYAML(content)
+			}
+		}
 		if err != nil {
-			lc.Errorf("Failed to YAML decode Device Profile from %s: %v", displayPath, err)
-			return nil, nil, nil
+			return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("Failed to YAML decode Device Profile from %s", displayPath), err)
 		}
 	case JSON:
 		err = json.Unmarshal(content, &profile)
+		if err == nil {
+			err = json.Unmarshal(content, &composition)
+		}
 		if err != nil {
-			lc.Errorf("Failed to JSON decode Device Profile from %s: %v", displayPath, err)
-			return nil, nil, nil
+			return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("Failed to JSON decode Device Profile from %s", displayPath), err)
 		}
 	}
 
-	add, update, edgexErr := checkDeviceProfile(profile.Name, overwrite, dpc, lc)
-	if add {
-		lc.Infof("Device Profile %s not found in Metadata, adding it ...", profile.Name)
-		req := requests.NewDeviceProfileRequest(profile)
-		addProfilesReq = append(addProfilesReq, req)
-	} else if update {
-		res, err := dpc.DeviceProfileByName(context.Background(), profile.Name)
+	return &profileDocument{profile: profile, composition: composition, displayPath: displayPath}, nil
+}
+
+// profileRequests resolves the profile documents and returns the requests to add and update the Device Profiles, only
+// of the selected Device Profiles if selected is not nil
+func profileRequests(documents []profileDocument, selected map[string]struct{}, overwrite bool, dpc interfaces.DeviceProfileClient, lc logger.LoggingClient) ([]requests.DeviceProfileRequest, []requests.DeviceProfileRequest, errors.EdgeX) {
+	var addProfilesReq []requests.DeviceProfileRequest
+	var updateProfilesReq []requests.DeviceProfileRequest
 
+	lookup := func(name string) (dtos.DeviceProfile, errors.EdgeX) {
+		res, err := dpc.DeviceProfileByName(context.Background(), name)
 		if err != nil {
-			lc.Errorf("Failed to overwrite Device Profile %s: %v", profile.Name, err)
-			return nil, nil, err
+			return dtos.DeviceProfile{}, err
 		}
+		return res.Profile, nil
+	}
+	profiles, failures := resolveProfiles(documents, lookup)
+	for _, failure := range failures {
+		lc.Errorf("Failed to resolve Device Profile %s from %s: %v", failure.document.profile.Name, failure.document.displayPath, failure.err)
+	}
+	for _, profile := range profiles {
+		if _, ok := selected[profile.Name]; selected != nil && !ok {
+			continue
+		}
+		add, update, edgexErr := checkDeviceProfile(profile.Name, overwrite, dpc, lc)
+		if add {
+			lc.Infof("Device Profile %s not found in Metadata, adding it ...", profile.Name)
+			req := requests.NewDeviceProfileRequest(profile)
+			addProfilesReq = append(addProfilesReq, req)
+		} else if update {
+			res, err := dpc.DeviceProfileByName(context.Background(), profile.Name)
 
-		profile.Id = res.Profile.Id
-		profile.DBTimestamp = res.Profile.DBTimestamp
-		req := requests.NewDeviceProfileRequest(profile)
-		updateProfilesReq = append(updateProfilesReq, req)
-	} else {
-		if edgexErr != nil {
-			return addProfilesReq, updateProfilesReq, edgexErr
+			if err != nil {
+				lc.Errorf("Failed to overwrite Device Profile %s: %v", profile.Name, err)
+				return nil, nil, err
+			}
+
+			profile.Id = res.Profile.Id
+			profile.DBTimestamp = res.Profile.DBTimestamp
+			req := requests.NewDeviceProfileRequest(profile)
+			updateProfilesReq = append(updateProfilesReq, req)
+		} else {
+			if edgexErr != nil {
+				return addProfilesReq, updateProfilesReq, edgexErr
+			}
 		}
 	}
 	return addProfilesReq, updateProfilesReq, nil
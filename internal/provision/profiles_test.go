@@ -232,7 +232,7 @@ func Test_loadProfilesFromURI(t *testing.T) {
 			true,
 			[]responses.DeviceProfileResponse{simpleProfile, simpleProfile},
 			[]errors.EdgeX{nil, nil},
-			1, ""},
+			2, ""},
 		{"valid load from uri, profile does not exist in metadata",
 			"https://raw.githubusercontent.com/edgexfoundry/device-sdk-go/main/internal/provision/uri-test-files/profiles/index.json",
 			nil,
@@ -272,7 +272,7 @@ func Test_loadProfilesFromURI(t *testing.T) {
 			require.NoError(t, edgexErr)
 			parsedURI, err := url.Parse(tt.path)
 			require.NoError(t, err)
-			addProfilesReq, updateProfilesReq, edgexErr = loadProfilesFromURI(tt.path, parsedURI, tt.overwrite, dpcMock, tt.secretProvider, lc)
+			addProfilesReq, updateProfilesReq, edgexErr = loadProfilesFromURI(tt.path, parsedURI, tt.overwrite, dpcMock, nil, tt.secretProvider, lc)
 			if tt.overwrite {
 				assert.Equal(t, len(updateProfilesReq), tt.expectedNumProfiles)
 			} else {
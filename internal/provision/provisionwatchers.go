@@ -1,5 +1,5 @@
 //
-// Copyright (C) 2023 IOTech Ltd
+// Copyright (C) 2023-2026 IOTech Ltd
 // Copyright (C) 2023 Intel Corporation
 //
 // SPDX-License-Identifier: Apache-2.0
@@ -8,8 +8,10 @@ package provision
 
 import (
 	"context"
+	"crypto"
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"net/url"
 	"os"
 	"path/filepath"
@@ -26,11 +28,13 @@ import (
 	"github.com/edgexfoundry/go-mod-core-contracts/v4/dtos/requests"
 	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
 	"github.com/google/uuid"
+	"github.com/hashicorp/go-multierror"
 	"gopkg.in/yaml.v3"
 )
 
-func LoadProvisionWatchers(path string, dic *di.Container) errors.EdgeX {
+func LoadProvisionWatchers(path string, overwrite bool, dic *di.Container) errors.EdgeX {
 	var addProvisionWatchersReq []requests.AddProvisionWatcherRequest
+	var updateProvisionWatchersReq []requests.UpdateProvisionWatcherRequest
 	var edgexErr errors.EdgeX
 	if path == "" {
 		return nil
@@ -44,131 +48,286 @@ func LoadProvisionWatchers(path string, dic *di.Container) errors.EdgeX {
 	}
 	if parsedUrl.Scheme == "http" || parsedUrl.Scheme == "https" {
 		secretProvider := container.SecretProviderFrom(dic.Get)
-		addProvisionWatchersReq, edgexErr = loadProvisionWatchersFromURI(path, parsedUrl, secretProvider, lc)
+		var publicKey crypto.PublicKey
+		publicKey, edgexErr = provisioningPublicKey(dic)
+		if edgexErr != nil {
+			return edgexErr
+		}
+		addProvisionWatchersReq, updateProvisionWatchersReq, edgexErr = loadProvisionWatchersFromURI(path, parsedUrl, overwrite, publicKey, secretProvider, lc)
 		if edgexErr != nil {
 			return edgexErr
 		}
 	} else {
-		addProvisionWatchersReq, edgexErr = loadProvisionWatchersFromFile(path, lc)
+		addProvisionWatchersReq, updateProvisionWatchersReq, edgexErr = loadProvisionWatchersFromFile(path, overwrite, lc)
 		if edgexErr != nil {
 			return edgexErr
 		}
 	}
+	return provisionProvisionWatchers(addProvisionWatchersReq, updateProvisionWatchersReq, dic)
+}
+
+// LoadProvisionWatcherFiles loads the ProvisionWatchers of the given files only, e.g. the files changed in the
+// ProvisionWatchersDir. The removed files are skipped, as their ProvisionWatchers are not deleted by the additive
+// provisioning.
+func LoadProvisionWatcherFiles(files []string, overwrite bool, dic *di.Container) errors.EdgeX {
+	lc := container.LoggingClientFrom(dic.Get)
+	var addProvisionWatchersReq []requests.AddProvisionWatcherRequest
+	var updateProvisionWatchersReq []requests.UpdateProvisionWatcherRequest
+	for _, fullPath := range existingFiles(files) {
+		lc.Infof("Loading pre-defined Provision Watchers from %s", fullPath)
+		processedProvisionWatchersReq, processedUpdateProvisionWatchersReq := processProvisionWatcherFile(fullPath, fullPath, overwrite, nil, lc)
+		addProvisionWatchersReq = append(addProvisionWatchersReq, processedProvisionWatchersReq...)
+		updateProvisionWatchersReq = append(updateProvisionWatchersReq, processedUpdateProvisionWatchersReq...)
+	}
+	return provisionProvisionWatchers(addProvisionWatchersReq, updateProvisionWatchersReq, dic)
+}
+
+// provisionProvisionWatchers sends the ProvisionWatchers loaded from the files to core-metadata
+func provisionProvisionWatchers(addProvisionWatchersReq []requests.AddProvisionWatcherRequest, updateProvisionWatchersReq []requests.UpdateProvisionWatcherRequest, dic *di.Container) errors.EdgeX {
+	if len(addProvisionWatchersReq) == 0 && len(updateProvisionWatchersReq) == 0 {
+		return nil
+	}
+
+	ctx := context.WithValue(context.Background(), common.CorrelationHeaderKey, uuid.NewString())
+	if edgexErr := addProvisionWatchers(ctx, addProvisionWatchersReq, dic); edgexErr != nil {
+		return edgexErr
+	}
+	return updateProvisionWatchers(ctx, updateProvisionWatchersReq, dic)
+}
+
+// addProvisionWatchers adds the ProvisionWatchers to core-metadata, the ProvisionWatchers owned by other device
+// services are skipped
+func addProvisionWatchers(ctx context.Context, addProvisionWatchersReq []requests.AddProvisionWatcherRequest, dic *di.Container) errors.EdgeX {
 	if len(addProvisionWatchersReq) == 0 {
 		return nil
 	}
+	lc := container.LoggingClientFrom(dic.Get)
+	pwc := container.ProvisionWatcherClientFrom(dic.Get)
+	responses, edgexErr := pwc.Add(ctx, addProvisionWatchersReq)
+	if edgexErr != nil {
+		return edgexErr
+	}
+
+	var err error
+	for _, response := range responses {
+		if response.StatusCode != http.StatusCreated {
+			if response.StatusCode == http.StatusConflict {
+				lc.Warnf("%s. ProvisionWatcher may be owned by other Device service instance.", response.Message)
+				continue
+			}
+
+			err = multierror.Append(err, fmt.Errorf("add ProvisionWatcher failed: %s", response.Message))
+		}
+	}
+
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+	return nil
+}
 
+// updateProvisionWatchers updates the ProvisionWatchers in core-metadata, the ProvisionWatchers owned by other device
+// services are skipped
+func updateProvisionWatchers(ctx context.Context, updateProvisionWatchersReq []requests.UpdateProvisionWatcherRequest, dic *di.Container) errors.EdgeX {
+	if len(updateProvisionWatchersReq) == 0 {
+		return nil
+	}
+	lc := container.LoggingClientFrom(dic.Get)
 	pwc := container.ProvisionWatcherClientFrom(dic.Get)
-	ctx := context.WithValue(context.Background(), common.CorrelationHeaderKey, uuid.NewString())
-	_, edgexErr = pwc.Add(ctx, addProvisionWatchersReq)
-	return edgexErr
+	updateResponses, edgexErr := pwc.Update(ctx, updateProvisionWatchersReq)
+	if edgexErr != nil {
+		return edgexErr
+	}
+
+	var err error
+	for _, response := range updateResponses {
+		if response.StatusCode != http.StatusOK {
+			if response.StatusCode == http.StatusConflict {
+				lc.Warnf("%s. ProvisionWatcher may be owned by other Device service instance.", response.Message)
+				continue
+			}
+
+			err = multierror.Append(err, fmt.Errorf("update ProvisionWatcher failed: %s", response.Message))
+		}
+	}
+
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+	return nil
 }
 
-func loadProvisionWatchersFromFile(path string, lc logger.LoggingClient) ([]requests.AddProvisionWatcherRequest, errors.EdgeX) {
+func loadProvisionWatchersFromFile(path string, overwrite bool, lc logger.LoggingClient) ([]requests.AddProvisionWatcherRequest, []requests.UpdateProvisionWatcherRequest, errors.EdgeX) {
 	absPath, err := filepath.Abs(path)
 	if err != nil {
-		return nil, errors.NewCommonEdgeX(errors.KindServerError, "failed to create absolute path for Provision Watchers", err)
+		return nil, nil, errors.NewCommonEdgeX(errors.KindServerError, "failed to create absolute path for Provision Watchers", err)
 	}
 	files, err := os.ReadDir(path)
 	if err != nil {
-		return nil, errors.NewCommonEdgeX(errors.KindServerError, "failed to read directory for Provision Watchers", err)
+		return nil, nil, errors.NewCommonEdgeX(errors.KindServerError, "failed to read directory for Provision Watchers", err)
 	}
 
 	if len(files) == 0 {
-		return nil, nil
+		return nil, nil, nil
 	}
 
 	lc.Infof("Loading pre-defined Provision Watchers from %s(%d files found)", absPath, len(files))
 	var addProvisionWatchersReq, processedProvisionWatchersReq []requests.AddProvisionWatcherRequest
+	var updateProvisionWatchersReq, processedUpdateProvisionWatchersReq []requests.UpdateProvisionWatcherRequest
 	for _, f := range files {
 		fullPath := filepath.Join(absPath, f.Name())
-		processedProvisionWatchersReq = processProvisionWatcherFile(fullPath, fullPath, nil, lc)
+		processedProvisionWatchersReq, processedUpdateProvisionWatchersReq = processProvisionWatcherFile(fullPath, fullPath, overwrite, nil, lc)
 		if len(processedProvisionWatchersReq) > 0 {
 			addProvisionWatchersReq = append(addProvisionWatchersReq, processedProvisionWatchersReq...)
 		}
+		if len(processedUpdateProvisionWatchersReq) > 0 {
+			updateProvisionWatchersReq = append(updateProvisionWatchersReq, processedUpdateProvisionWatchersReq...)
+		}
 	}
-	return addProvisionWatchersReq, nil
+	return addProvisionWatchersReq, updateProvisionWatchersReq, nil
 }
 
-func loadProvisionWatchersFromURI(inputURI string, parsedURI *url.URL, secretProvider interfaces.SecretProvider, lc logger.LoggingClient) ([]requests.AddProvisionWatcherRequest, errors.EdgeX) {
+func loadProvisionWatchersFromURI(inputURI string, parsedURI *url.URL, overwrite bool, publicKey crypto.PublicKey, secretProvider interfaces.SecretProvider, lc logger.LoggingClient) ([]requests.AddProvisionWatcherRequest, []requests.UpdateProvisionWatcherRequest, errors.EdgeX) {
 	// the input URI contains the index file containing the Provision Watcher list to be loaded
 	bytes, err := file.Load(inputURI, secretProvider, lc)
 	if err != nil {
-		return nil, errors.NewCommonEdgeX(errors.KindServerError, fmt.Sprintf("failed to load Provision Watchers list from URI %s", parsedURI.Redacted()), err)
+		return nil, nil, errors.NewCommonEdgeX(errors.KindServerError, fmt.Sprintf("failed to load Provision Watchers list from URI %s", parsedURI.Redacted()), err)
 	}
 
-	var files map[string]string
+	var files map[string]indexEntry
 
 	err = json.Unmarshal(bytes, &files)
 	if err != nil {
-		return nil, errors.NewCommonEdgeX(errors.KindServerError, "could not unmarshal Provision Watcher list contents", err)
+		return nil, nil, errors.NewCommonEdgeX(errors.KindServerError, "could not unmarshal Provision Watcher list contents", err)
+	}
+	if edgexErr := verifyIndex(bytes, parsedURI, indexEntries(files), publicKey, secretProvider, lc); edgexErr != nil {
+		return nil, nil, edgexErr
 	}
 
 	if len(files) == 0 {
 		lc.Infof("Index file %s for Provision Watchers list is empty", parsedURI.Redacted())
-		return nil, nil
+		return nil, nil, nil
 	}
 
 	lc.Infof("Loading pre-defined Provision Watchers from %s(%d files found)", parsedURI.Redacted(), len(files))
 	var addProvisionWatchersReq, processedProvisionWatchersReq []requests.AddProvisionWatcherRequest
-	for name, f := range files {
-		if _, ok := cache.ProvisionWatchers().ForName(name); ok {
+	var updateProvisionWatchersReq, processedUpdateProvisionWatchersReq []requests.UpdateProvisionWatcherRequest
+	for name, entry := range files {
+		if _, ok := cache.ProvisionWatchers().ForName(name); ok && !overwrite {
 			lc.Infof("ProvisionWatcher %s exists, using the existing one", name)
 		} else {
-			fullPath, redactedPath := GetFullAndRedactedURI(parsedURI, f, "Provison Watcher", lc)
-			processedProvisionWatchersReq = processProvisionWatcherFile(fullPath, redactedPath, secretProvider, lc)
+			fullPath, redactedPath := GetFullAndRedactedURI(parsedURI, entry.File, "Provison Watcher", lc)
+			fileType := GetFileType(fullPath)
+			if fileType == OTHER {
+				continue
+			}
+			content, edgexErr := loadIndexedFile(fullPath, redactedPath, entry, secretProvider, lc)
+			if edgexErr != nil {
+				// the files failed the digest verification abort the loading, so nothing from the index file is added
+				if errors.Kind(edgexErr) != errors.KindIOError {
+					return nil, nil, errors.NewCommonEdgeX(errors.KindContractInvalid, "failed to load Provision Watchers", edgexErr)
+				}
+				lc.Error(edgexErr.Error())
+				continue
+			}
+			// the watchers of the valid rows of a csv file are still returned along with the errors of the invalid rows
+			watchers, edgexErr := decodeProvisionWatchers(content, fileType, redactedPath)
+			if edgexErr != nil {
+				lc.Error(edgexErr.Error())
+			}
+			processedProvisionWatchersReq, processedUpdateProvisionWatchersReq = provisionWatcherRequests(watchers, overwrite, lc)
 			if len(processedProvisionWatchersReq) > 0 {
 				addProvisionWatchersReq = append(addProvisionWatchersReq, processedProvisionWatchersReq...)
 			}
+			if len(processedUpdateProvisionWatchersReq) > 0 {
+				updateProvisionWatchersReq = append(updateProvisionWatchersReq, processedUpdateProvisionWatchersReq...)
+			}
 		}
 	}
-	return addProvisionWatchersReq, nil
+	return addProvisionWatchersReq, updateProvisionWatchersReq, nil
 }
 
-func processProvisionWatcherFile(fullPath, displayPath string, secretProvider interfaces.SecretProvider, lc logger.LoggingClient) []requests.AddProvisionWatcherRequest {
-	var watcher dtos.ProvisionWatcher
+func processProvisionWatcherFile(fullPath, displayPath string, overwrite bool, secretProvider interfaces.SecretProvider, lc logger.LoggingClient) ([]requests.AddProvisionWatcherRequest, []requests.UpdateProvisionWatcherRequest) {
+	// the watchers of the valid rows of a csv file are still returned along with the errors of the invalid rows
+	watchers, err := readProvisionWatchers(fullPath, displayPath, secretProvider, lc)
+	if err != nil {
+		lc.Error(err.Error())
+	}
+	return provisionWatcherRequests(watchers, overwrite, lc)
+}
+
+// provisionWatcherRequests returns the requests to add the ProvisionWatchers not in the cache and to update the cached
+// ones if overwrite
+func provisionWatcherRequests(watchers []dtos.ProvisionWatcher, overwrite bool, lc logger.LoggingClient) ([]requests.AddProvisionWatcherRequest, []requests.UpdateProvisionWatcherRequest) {
 	var addProvisionWatchersReq []requests.AddProvisionWatcherRequest
+	var updateProvisionWatchersReq []requests.UpdateProvisionWatcherRequest
 
+	for _, watcher := range watchers {
+		if cachedWatcher, ok := cache.ProvisionWatchers().ForName(watcher.Name); ok {
+			if overwrite {
+				lc.Infof("Overwriting existing ProvisionWatcher %s with one in local files", watcher.Name)
+
+				// the admin state is changed at runtime, so it is kept like the one of the Devices
+				watcher.Id = cachedWatcher.Id
+				watcher.ServiceName = cachedWatcher.ServiceName
+				watcher.AdminState = string(cachedWatcher.AdminState)
+
+				update := dtos.FromProvisionWatcherModelToUpdateDTO(dtos.ToProvisionWatcherModel(watcher))
+				req := requests.NewUpdateProvisionWatcherRequest(update)
+				updateProvisionWatchersReq = append(updateProvisionWatchersReq, req)
+			} else {
+				lc.Infof("ProvisionWatcher %s exists, using the existing one", watcher.Name)
+			}
+		} else {
+			lc.Infof("ProvisionWatcher %s not found in Metadata, adding it...", watcher.Name)
+			req := requests.NewAddProvisionWatcherRequest(watcher)
+			addProvisionWatchersReq = append(addProvisionWatchersReq, req)
+		}
+	}
+	return addProvisionWatchersReq, updateProvisionWatchersReq
+}
+
+// readProvisionWatchers reads and validates the ProvisionWatcher from the YAML or JSON file, or the ProvisionWatchers
+// from the CSV file, returns nil if the file is of other types. The ProvisionWatchers of the valid rows of the CSV file
+// are returned along with the errors of the invalid rows.
+func readProvisionWatchers(fullPath, displayPath string, secretProvider interfaces.SecretProvider, lc logger.LoggingClient) ([]dtos.ProvisionWatcher, errors.EdgeX) {
 	fileType := GetFileType(fullPath)
 
 	// if the file type is not yaml or json, it cannot be parsed - just return to not break the loop for other devices
 	if fileType == OTHER {
-		return nil
+		return nil, nil
 	}
 
 	content, err := file.Load(fullPath, secretProvider, lc)
 	if err != nil {
-		lc.Errorf("Failed to read Provision Watcher from %s: %v", displayPath, err)
-		return nil
+		return nil, errors.NewCommonEdgeX(errors.KindIOError, fmt.Sprintf("Failed to read Provision Watcher from %s", displayPath), err)
 	}
+	return decodeProvisionWatchers(content, fileType, displayPath)
+}
+
+// decodeProvisionWatchers decodes and validates the ProvisionWatchers from the content of the YAML, JSON or CSV file
+func decodeProvisionWatchers(content []byte, fileType FileType, displayPath string) ([]dtos.ProvisionWatcher, errors.EdgeX) {
+	var watcher dtos.ProvisionWatcher
+	var err error
 
 	switch fileType {
+	case CSV:
+		return provisionWatchersFromCSV(content, displayPath)
 	case YAML:
 		err = yaml.Unmarshal(content, &watcher)
 		if err != nil {
-			lc.Errorf("Failed to YAML decode Provision Watcher from %s: %v", displayPath, err)
-			return nil
+			return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("Failed to YAML decode Provision Watcher from %s", displayPath), err)
 		}
 	case JSON:
 		err = json.Unmarshal(content, &watcher)
 		if err != nil {
-			lc.Errorf("Failed to JSON decode Provision Watcher from %s: %v", displayPath, err)
-			return nil
+			return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("Failed to JSON decode Provision Watcher from %s", displayPath), err)
 		}
 	}
 
 	err = contractsCommon.Validate(watcher)
 	if err != nil {
-		lc.Errorf("ProvisionWatcher %s validation failed: %v", watcher.Name, err)
-		return nil
-	}
-
-	if _, ok := cache.ProvisionWatchers().ForName(watcher.Name); ok {
-		lc.Infof("ProvisionWatcher %s exists, using the existing one", watcher.Name)
-	} else {
-		lc.Infof("ProvisionWatcher %s not found in Metadata, adding it...", watcher.Name)
-		req := requests.NewAddProvisionWatcherRequest(watcher)
-		addProvisionWatchersReq = append(addProvisionWatchersReq, req)
+		return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("ProvisionWatcher %s validation failed", watcher.Name), err)
 	}
-	return addProvisionWatchersReq
+	return []dtos.ProvisionWatcher{watcher}, nil
 }
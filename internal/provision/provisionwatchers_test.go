@@ -11,6 +11,7 @@ import (
 	"github.com/edgexfoundry/go-mod-core-contracts/v4/clients/logger"
 	"github.com/edgexfoundry/go-mod-core-contracts/v4/dtos"
 	"github.com/edgexfoundry/go-mod-core-contracts/v4/dtos/requests"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"net/url"
@@ -20,26 +21,44 @@ import (
 
 func Test_processProvisionWatcherFile(t *testing.T) {
 	tests := []struct {
-		name                         string
-		path                         string
-		secretProvider               interfaces.SecretProvider
-		expectedNumProvisionWatchers int
+		name                               string
+		path                               string
+		overwrite                          bool
+		secretProvider                     interfaces.SecretProvider
+		expectedNumProvisionWatchers       int
+		expectedUpdateNumProvisionWatchers int
 	}{
-		{"valid load provision watcher from file", path.Join("..", "..", "example", "cmd", "device-simple", "res", "provisionwatchers", "Simple-Provision-Watcher.yml"), nil, 1},
-		{"valid load provision watcher from valid uri", "https://raw.githubusercontent.com/edgexfoundry/device-sdk-go/main/example/cmd/device-simple/res/provisionwatchers/Simple-Provision-Watcher.yml", nil, 1},
-		{"invalid load provision watcher empty path", "", nil, 0},
-		{"invalid load provision watcher from file", path.Join("..", "..", "example", "cmd", "device-simple", "res", "provisionwatchers", "bogus.yml"), nil, 0},
-		{"invalid load provision watcher from invalid uri", "https://raw.githubusercontent.com/edgexfoundry/device-sdk-go/main/example/cmd/device-simple/res/provisionwatchers/bogus.yml", nil, 0},
+		{"valid load provision watcher from file", path.Join("..", "..", "example", "cmd", "device-simple", "res", "provisionwatchers", "Simple-Provision-Watcher.yml"), false, nil, 1, 0},
+		{"valid load provision watcher from valid uri", "https://raw.githubusercontent.com/edgexfoundry/device-sdk-go/main/example/cmd/device-simple/res/provisionwatchers/Simple-Provision-Watcher.yml", false, nil, 1, 0},
+		{"valid overwrite provision watcher from file", path.Join("..", "..", "example", "cmd", "device-simple", "res", "provisionwatchers", "Simple-Provision-Watcher.yml"), true, nil, 0, 1},
+		{"invalid load provision watcher empty path", "", false, nil, 0, 0},
+		{"invalid load provision watcher from file", path.Join("..", "..", "example", "cmd", "device-simple", "res", "provisionwatchers", "bogus.yml"), false, nil, 0, 0},
+		{"invalid load provision watcher from invalid uri", "https://raw.githubusercontent.com/edgexfoundry/device-sdk-go/main/example/cmd/device-simple/res/provisionwatchers/bogus.yml", false, nil, 0, 0},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			var addProvisionWatchersReq []requests.AddProvisionWatcherRequest
 			lc := logger.MockLogger{}
 			dic, _ := NewMockDIC()
 			err := cache.InitCache(TestDeviceService, TestDeviceService, dic)
 			require.NoError(t, err)
-			addProvisionWatchersReq = processProvisionWatcherFile(tt.path, tt.path, tt.secretProvider, lc)
+			if tt.overwrite {
+				err := cache.ProvisionWatchers().Add(models.ProvisionWatcher{
+					Id:          "6a5e7b43-4e9c-4a4d-92c1-3a3d5a9b6f01",
+					Name:        "Simple-Provision-Watcher",
+					ServiceName: TestDeviceService,
+					AdminState:  models.Locked,
+				})
+				require.NoError(t, err)
+			}
+			addProvisionWatchersReq, updateProvisionWatchersReq := processProvisionWatcherFile(tt.path, tt.path, tt.overwrite, tt.secretProvider, lc)
 			assert.Equal(t, tt.expectedNumProvisionWatchers, len(addProvisionWatchersReq))
+			require.Equal(t, tt.expectedUpdateNumProvisionWatchers, len(updateProvisionWatchersReq))
+			for _, req := range updateProvisionWatchersReq {
+				assert.Equal(t, "6a5e7b43-4e9c-4a4d-92c1-3a3d5a9b6f01", *req.ProvisionWatcher.Id)
+				assert.Equal(t, TestDeviceService, *req.ProvisionWatcher.ServiceName)
+				assert.Equal(t, models.Locked, *req.ProvisionWatcher.AdminState)
+				assert.Equal(t, "Simple-Device", *req.ProvisionWatcher.DiscoveredDevice.ProfileName)
+			}
 		})
 	}
 }
@@ -83,7 +102,7 @@ func Test_loadProvisionWatchersFromURI(t *testing.T) {
 			}
 			parsedURI, err := url.Parse(tt.path)
 			require.NoError(t, err)
-			addProvisionWatchersReq, edgexErr = loadProvisionWatchersFromURI(tt.path, parsedURI, tt.secretProvider, lc)
+			addProvisionWatchersReq, _, edgexErr = loadProvisionWatchersFromURI(tt.path, parsedURI, false, nil, tt.secretProvider, lc)
 			assert.Equal(t, tt.expectedNumProvisionWatchers, len(addProvisionWatchersReq))
 			if edgexErr != nil {
 				assert.Contains(t, edgexErr.Error(), tt.expectedEdgexErrMsg)
@@ -0,0 +1,293 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provision
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v4/bootstrap/file"
+	"github.com/edgexfoundry/go-mod-bootstrap/v4/bootstrap/interfaces"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
+)
+
+// maxTemplateDevices is the maximum number of the Devices a device template expands into, so a mistyped range can't
+// exhaust the memory
+const maxTemplateDevices = 10000
+
+var placeholderRegex = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)}`)
+
+// typedFields are the Device fields of any type, where a value which is only a placeholder takes the type of the
+// parameter, e.g. a numeric protocol property
+var typedFields = map[string]struct{}{"protocols": {}, "properties": {}, "tags": {}, "location": {}}
+
+// DeviceTemplate is a Device skeleton in the Devices file which is expanded into a Device for each parameter set.
+// The ${name} placeholders in the string values of the skeleton are replaced by the parameters. In the protocols,
+// properties, tags and location, a value which is only a placeholder takes the type of the parameter. The parameter
+// sets are given by exactly one of Parameters, CSV, CSVFile or Ranges.
+type DeviceTemplate struct {
+	Device map[string]any `json:"device" yaml:"device"`
+	// Parameters is the list of the parameter sets
+	Parameters []map[string]any `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+	// CSV is the parameter sets in CSV, the header row gives the parameter names
+	CSV string `json:"csv,omitempty" yaml:"csv,omitempty"`
+	// CSVFile is the path or URI of the parameter sets in CSV, relative to the Devices file. The file must not be in
+	// the DevicesDir itself, where the CSV files are imported as Devices.
+	CSVFile string `json:"csvFile,omitempty" yaml:"csvFile,omitempty"`
+	// Ranges is the integer ranges of the parameters, e.g. "1..64" or "1..4,8,10..12". The parameter sets are the
+	// cartesian product of the ranges, which must not exceed 10000 sets.
+	Ranges map[string]string `json:"ranges,omitempty" yaml:"ranges,omitempty"`
+}
+
+// Expand expands the template into the Devices, the CSVFile must be loaded into CSV beforehand
+func (t DeviceTemplate) Expand() ([]dtos.Device, errors.EdgeX) {
+	sources := 0
+	for _, set := range []bool{len(t.Parameters) > 0, t.CSV != "", len(t.Ranges) > 0} {
+		if set {
+			sources++
+		}
+	}
+	if sources != 1 {
+		return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, "device template requires exactly one of parameters, csv, csvFile or ranges", nil)
+	}
+
+	var parameterSets []map[string]any
+	var err errors.EdgeX
+	switch {
+	case len(t.Parameters) > 0:
+		parameterSets = t.Parameters
+	case t.CSV != "":
+		parameterSets, err = parseCSVParameters(t.CSV)
+	default:
+		parameterSets, err = expandRanges(t.Ranges)
+	}
+	if err != nil {
+		return nil, errors.NewCommonEdgeXWrapper(err)
+	}
+
+	devices := make([]dtos.Device, 0, len(parameterSets))
+	names := make(map[string]struct{}, len(parameterSets))
+	for i, parameters := range parameterSets {
+		expanded := make(map[string]any, len(t.Device))
+		var err errors.EdgeX
+		for field, value := range t.Device {
+			_, typed := typedFields[field]
+			if expanded[field], err = substitute(value, parameters, typed); err != nil {
+				break
+			}
+		}
+		if err != nil {
+			return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("failed to expand device template with parameter set %d", i+1), err)
+		}
+		data, e := json.Marshal(expanded)
+		if e != nil {
+			return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, "failed to encode expanded device template", e)
+		}
+		var device dtos.Device
+		if e = json.Unmarshal(data, &device); e != nil {
+			return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("failed to decode device template expanded with parameter set %d", i+1), e)
+		}
+		if device.Name == "" {
+			return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("device template expanded with parameter set %d has no name", i+1), nil)
+		}
+		if _, ok := names[device.Name]; ok {
+			return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("device template expands to duplicate Device name %s", device.Name), nil)
+		}
+		names[device.Name] = struct{}{}
+		devices = append(devices, device)
+	}
+	return devices, nil
+}
+
+// substitute replaces the placeholders in the string values of the skeleton recursively, the value which is only a
+// placeholder takes the type of the parameter if typed is true
+func substitute(value any, parameters map[string]any, typed bool) (any, errors.EdgeX) {
+	switch v := value.(type) {
+	case string:
+		if match := placeholderRegex.FindStringSubmatch(v); typed && match != nil && match[0] == v {
+			p, ok := parameters[match[1]]
+			if !ok {
+				return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("parameter %s is not defined", match[1]), nil)
+			}
+			return p, nil
+		}
+		var err errors.EdgeX
+		result := placeholderRegex.ReplaceAllStringFunc(v, func(placeholder string) string {
+			name := placeholderRegex.FindStringSubmatch(placeholder)[1]
+			p, ok := parameters[name]
+			if !ok {
+				err = errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("parameter %s is not defined", name), nil)
+				return placeholder
+			}
+			return fmt.Sprintf("%v", p)
+		})
+		return result, err
+	case map[string]any:
+		result := make(map[string]any, len(v))
+		for key, element := range v {
+			expanded, err := substitute(element, parameters, typed)
+			if err != nil {
+				return nil, err
+			}
+			result[key] = expanded
+		}
+		return result, nil
+	case []any:
+		result := make([]any, len(v))
+		for i, element := range v {
+			expanded, err := substitute(element, parameters, typed)
+			if err != nil {
+				return nil, err
+			}
+			result[i] = expanded
+		}
+		return result, nil
+	}
+	return value, nil
+}
+
+func parseCSVParameters(content string) ([]map[string]any, errors.EdgeX) {
+	reader := csv.NewReader(strings.NewReader(content))
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, "failed to parse device template CSV parameters", err)
+	}
+	if len(records) < 2 {
+		return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, "device template CSV parameters require a header row and at least one parameter row", nil)
+	}
+
+	header := records[0]
+	parameterSets := make([]map[string]any, 0, len(records)-1)
+	for _, record := range records[1:] {
+		parameters := make(map[string]any, len(header))
+		for i, name := range header {
+			parameters[strings.TrimSpace(name)] = strings.TrimSpace(record[i])
+		}
+		parameterSets = append(parameterSets, parameters)
+	}
+	return parameterSets, nil
+}
+
+// expandRanges returns the cartesian product of the ranges, ordered by the parameter names
+func expandRanges(ranges map[string]string) ([]map[string]any, errors.EdgeX) {
+	names := make([]string, 0, len(ranges))
+	for name := range ranges {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	parameterSets := []map[string]any{{}}
+	for _, name := range names {
+		values, err := parseRange(ranges[name])
+		if err != nil {
+			return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("invalid range of parameter %s", name), err)
+		}
+		if len(parameterSets)*len(values) > maxTemplateDevices {
+			return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("ranges expand into more than %d Devices", maxTemplateDevices), nil)
+		}
+		product := make([]map[string]any, 0, len(parameterSets)*len(values))
+		for _, parameters := range parameterSets {
+			for _, value := range values {
+				next := make(map[string]any, len(parameters)+1)
+				for k, v := range parameters {
+					next[k] = v
+				}
+				next[name] = value
+				product = append(product, next)
+			}
+		}
+		parameterSets = product
+	}
+	return parameterSets, nil
+}
+
+// parseRange parses the comma separated integers and inclusive ranges, e.g. "1..4,8,10..12", into at most
+// maxTemplateDevices values
+func parseRange(expression string) ([]int64, errors.EdgeX) {
+	var values []int64
+	for _, part := range strings.Split(expression, ",") {
+		part = strings.TrimSpace(part)
+		first, last, isRange := strings.Cut(part, "..")
+		start, err := strconv.ParseInt(strings.TrimSpace(first), 0, 64)
+		if err != nil {
+			return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("invalid range value %s", part), err)
+		}
+		end := start
+		if isRange {
+			if end, err = strconv.ParseInt(strings.TrimSpace(last), 0, 64); err != nil {
+				return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("invalid range value %s", part), err)
+			}
+			if end < start {
+				return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("range %s ends before it starts", part), nil)
+			}
+		}
+		// the difference is computed unsigned as it may overflow int64
+		if uint64(end)-uint64(start) >= uint64(maxTemplateDevices-len(values)) {
+			return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("range %s has more than %d values", expression, maxTemplateDevices), nil)
+		}
+		for v := start; ; v++ {
+			values = append(values, v)
+			if v == end {
+				break
+			}
+		}
+	}
+	return values, nil
+}
+
+// expandTemplates loads the CSVFile of the templates relative to the Devices file and expands the templates
+func expandTemplates(templates []DeviceTemplate, fullPath string, secretProvider interfaces.SecretProvider, lc logger.LoggingClient) ([]dtos.Device, errors.EdgeX) {
+	var devices []dtos.Device
+	for _, t := range templates {
+		if t.CSVFile != "" {
+			if t.CSV != "" {
+				return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, "device template requires exactly one of parameters, csv, csvFile or ranges", nil)
+			}
+			csvPath, err := resolveRelativePath(fullPath, t.CSVFile)
+			if err != nil {
+				return nil, errors.NewCommonEdgeXWrapper(err)
+			}
+			content, err := file.Load(csvPath, secretProvider, lc)
+			if err != nil {
+				return nil, errors.NewCommonEdgeX(errors.KindIOError, fmt.Sprintf("failed to read device template CSV parameters from %s", t.CSVFile), err)
+			}
+			t.CSV = string(content)
+		}
+		expanded, err := t.Expand()
+		if err != nil {
+			return nil, errors.NewCommonEdgeXWrapper(err)
+		}
+		devices = append(devices, expanded...)
+	}
+	return devices, nil
+}
+
+// resolveRelativePath resolves the path relative to the directory of the base file path or URI
+func resolveRelativePath(base string, path string) (string, error) {
+	parsedBase, err := url.Parse(base)
+	if err == nil && (parsedBase.Scheme == "http" || parsedBase.Scheme == "https") {
+		ref, err := url.Parse(path)
+		if err != nil {
+			return "", err
+		}
+		return parsedBase.ResolveReference(ref).String(), nil
+	}
+	if filepath.IsAbs(path) {
+		return path, nil
+	}
+	return filepath.Join(filepath.Dir(base), path), nil
+}
@@ -0,0 +1,117 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provision
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeviceTemplate_Expand(t *testing.T) {
+	skeleton := map[string]any{
+		"name":        "Unit-${unitId}-${bus}",
+		"profileName": "testProfile",
+		"labels":      []any{"${bus}"},
+		"protocols": map[string]any{
+			"modbus-rtu": map[string]any{"UnitID": "${unitId}", "Address": "/dev/${bus}"},
+		},
+	}
+	tests := []struct {
+		name          string
+		template      DeviceTemplate
+		expectedNames []string
+		expectedErr   bool
+	}{
+		{"parameters", DeviceTemplate{Device: skeleton, Parameters: []map[string]any{{"unitId": 1, "bus": "ttyS0"}, {"unitId": 2, "bus": "ttyS0"}}},
+			[]string{"Unit-1-ttyS0", "Unit-2-ttyS0"}, false},
+		{"csv", DeviceTemplate{Device: skeleton, CSV: "unitId, bus\n7, ttyS1\n8, ttyS1\n"},
+			[]string{"Unit-7-ttyS1", "Unit-8-ttyS1"}, false},
+		{"ranges product", DeviceTemplate{Device: skeleton, Ranges: map[string]string{"unitId": "1..2,5", "bus": "0..1"}},
+			[]string{"Unit-1-0", "Unit-2-0", "Unit-5-0", "Unit-1-1", "Unit-2-1", "Unit-5-1"}, false},
+		{"no parameter source", DeviceTemplate{Device: skeleton}, nil, true},
+		{"more than one parameter source", DeviceTemplate{Device: skeleton, CSV: "unitId,bus\n1,a", Ranges: map[string]string{"unitId": "1"}}, nil, true},
+		{"undefined parameter", DeviceTemplate{Device: skeleton, Ranges: map[string]string{"unitId": "1..2"}}, nil, true},
+		{"duplicate names", DeviceTemplate{Device: skeleton, Parameters: []map[string]any{{"unitId": 1, "bus": "a"}, {"unitId": "1", "bus": "a"}}}, nil, true},
+		{"invalid range", DeviceTemplate{Device: skeleton, Ranges: map[string]string{"unitId": "5..1", "bus": "0"}}, nil, true},
+		{"csv without rows", DeviceTemplate{Device: skeleton, CSV: "unitId,bus\n"}, nil, true},
+		{"range too large", DeviceTemplate{Device: skeleton, Ranges: map[string]string{"unitId": "1..10001", "bus": "0"}}, nil, true},
+		{"range overflows", DeviceTemplate{Device: skeleton, Ranges: map[string]string{"unitId": "-9223372036854775808..9223372036854775807", "bus": "0"}}, nil, true},
+		{"ranges product too large", DeviceTemplate{Device: skeleton, Ranges: map[string]string{"unitId": "1..200", "bus": "1..51"}}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			devices, err := tt.template.Expand()
+			if tt.expectedErr {
+				require.Error(t, err)
+				assert.Equal(t, errors.KindContractInvalid, errors.Kind(err))
+				return
+			}
+			require.NoError(t, err)
+			names := make([]string, len(devices))
+			for i, d := range devices {
+				names[i] = d.Name
+				assert.Equal(t, "testProfile", d.ProfileName)
+			}
+			assert.ElementsMatch(t, tt.expectedNames, names)
+		})
+	}
+}
+
+func TestDeviceTemplate_Expand_keepsParameterType(t *testing.T) {
+	template := DeviceTemplate{
+		Device: map[string]any{
+			"name":      "Unit-${unitId}",
+			"protocols": map[string]any{"modbus-rtu": map[string]any{"UnitID": "${unitId}", "Label": "unit ${unitId}"}},
+		},
+		Ranges: map[string]string{"unitId": "3"},
+	}
+
+	devices, err := template.Expand()
+	require.NoError(t, err)
+	require.Len(t, devices, 1)
+	assert.Equal(t, float64(3), devices[0].Protocols["modbus-rtu"]["UnitID"])
+	assert.Equal(t, "unit 3", devices[0].Protocols["modbus-rtu"]["Label"])
+}
+
+func Test_readDevices_templates(t *testing.T) {
+	path := writeFiles(t, map[string]string{
+		"units.csv": "unitId\n1\n2\n",
+		"devices.yaml": `deviceList:
+  - name: plain
+deviceTemplates:
+  - device:
+      name: Unit-${unitId}
+    csvFile: units.csv
+`,
+		"devices.json": `{"deviceTemplates": [{"device": {"name": "Json-${n}"}, "ranges": {"n": "1..3"}}]}`,
+		"duplicate.yaml": `deviceList:
+  - name: Unit-1
+deviceTemplates:
+  - device:
+      name: Unit-${n}
+    ranges:
+      n: "1..2"
+`,
+	})
+	lc := logger.NewMockClient()
+
+	devices, err := readDevices(filepath.Join(path, "devices.yaml"), "devices.yaml", nil, lc)
+	require.NoError(t, err)
+	assert.Len(t, devices, 3)
+
+	devices, err = readDevices(filepath.Join(path, "devices.json"), "devices.json", nil, lc)
+	require.NoError(t, err)
+	assert.Len(t, devices, 3)
+
+	_, err = readDevices(filepath.Join(path, "duplicate.yaml"), "duplicate.yaml", nil, lc)
+	require.Error(t, err)
+}
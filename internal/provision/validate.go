@@ -0,0 +1,235 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provision
+
+import (
+	"fmt"
+
+	"github.com/edgexfoundry/device-sdk-go/v4/internal/config"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/clients/logger"
+	contractsCommon "github.com/edgexfoundry/go-mod-core-contracts/v4/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
+)
+
+// the kinds of the validation issues
+const (
+	ValidationKindDeviceProfile    = "DeviceProfile"
+	ValidationKindDevice           = "Device"
+	ValidationKindProvisionWatcher = "ProvisionWatcher"
+)
+
+// defaultMaxCmdOps is the MaxCmdOps of the EdgeX common configuration of the device services, which is used when the
+// local configuration doesn't set it
+const defaultMaxCmdOps = 200
+
+// ValidationIssue is a problem of a provisioning file found by ValidateProvisioning
+type ValidationIssue struct {
+	Kind    string `json:"kind"`
+	Name    string `json:"name,omitempty"`
+	File    string `json:"file,omitempty"`
+	Message string `json:"message"`
+}
+
+// ValidationReport is the machine-readable result of ValidateProvisioning, the counts are of the valid entries
+type ValidationReport struct {
+	Valid             bool              `json:"valid"`
+	DeviceProfiles    int               `json:"deviceProfiles"`
+	Devices           int               `json:"devices"`
+	ProvisionWatchers int               `json:"provisionWatchers"`
+	Issues            []ValidationIssue `json:"issues"`
+}
+
+func (r *ValidationReport) addIssue(kind, name, file string, err error) {
+	r.Issues = append(r.Issues, ValidationIssue{Kind: kind, Name: name, File: file, Message: err.Error()})
+}
+
+// ValidateProvisioning validates the Device Profiles, Devices and Provision Watchers in the provisioning directories
+// of the device configuration without core-metadata. The entries are checked against the schema, the Devices and
+// Provision Watchers must reference the Device Profiles in the ProfilesDir, the AutoEvents must reference the Device
+// Resources or Device Commands of the profile and the Device Commands must not exceed the MaxCmdOps, which defaults
+// to the one of the common configuration if it is not set. The URIs are not supported as they are usually not reachable before the deployment.
+func ValidateProvisioning(device config.DeviceInfo, serviceName string, lc logger.LoggingClient) ValidationReport {
+	report := ValidationReport{Issues: []ValidationIssue{}}
+
+	maxCmdOps := device.MaxCmdOps
+	if maxCmdOps <= 0 {
+		maxCmdOps = defaultMaxCmdOps
+	}
+	profiles := validateProfiles(device.ProfilesDir, maxCmdOps, &report, lc)
+	validateDevices(device.DevicesDir, serviceName, profiles, &report, lc)
+	validateProvisionWatchers(device.ProvisionWatchersDir, profiles, &report, lc)
+
+	report.Valid = len(report.Issues) == 0
+	return report
+}
+
+func validateProfiles(path string, maxCmdOps int, report *ValidationReport, lc logger.LoggingClient) map[string]dtos.DeviceProfile {
+	var documents []profileDocument
+	err := validationDirectory(path, "Device Profiles", lc, func(fullPath string) {
+		document, err := readProfile(fullPath, fullPath, nil, lc)
+		if err != nil {
+			report.addIssue(ValidationKindDeviceProfile, "", fullPath, err)
+			return
+		}
+		if document != nil {
+			documents = append(documents, *document)
+		}
+	})
+	if err != nil {
+		report.addIssue(ValidationKindDeviceProfile, "", path, err)
+	}
+
+	resolved, failures := resolveProfiles(documents, nil)
+	for _, failure := range failures {
+		report.addIssue(ValidationKindDeviceProfile, failure.document.profile.Name, failure.document.displayPath, failure.err)
+	}
+	displayPaths := make(map[string]string, len(documents))
+	for _, document := range documents {
+		if _, ok := displayPaths[document.profile.Name]; !ok {
+			displayPaths[document.profile.Name] = document.displayPath
+		}
+	}
+
+	profiles := make(map[string]dtos.DeviceProfile, len(resolved))
+	for _, profile := range resolved {
+		displayPath := displayPaths[profile.Name]
+		if err := contractsCommon.Validate(profile); err != nil {
+			report.addIssue(ValidationKindDeviceProfile, profile.Name, displayPath, err)
+			continue
+		}
+		if err := dtos.ValidateDeviceProfileDTO(profile); err != nil {
+			report.addIssue(ValidationKindDeviceProfile, profile.Name, displayPath, err)
+			continue
+		}
+		valid := true
+		for _, command := range profile.DeviceCommands {
+			if len(command.ResourceOperations) > maxCmdOps {
+				err := fmt.Errorf("Device Command %s has %d resource operations which exceed MaxCmdOps (%d)", command.Name, len(command.ResourceOperations), maxCmdOps)
+				report.addIssue(ValidationKindDeviceProfile, profile.Name, displayPath, err)
+				valid = false
+			}
+		}
+		if valid {
+			profiles[profile.Name] = profile
+		}
+	}
+	report.DeviceProfiles = len(profiles)
+	return profiles
+}
+
+func validateDevices(path, serviceName string, profiles map[string]dtos.DeviceProfile, report *ValidationReport, lc logger.LoggingClient) {
+	definedIn := make(map[string]string)
+	err := validationDirectory(path, "Devices", lc, func(fullPath string) {
+		// the devices of the valid rows of a csv file are still validated along with the errors of the invalid rows
+		devices, err := readDevices(fullPath, fullPath, nil, lc)
+		if err != nil {
+			report.addIssue(ValidationKindDevice, "", fullPath, err)
+		}
+		for _, device := range devices {
+			if file, ok := definedIn[device.Name]; ok {
+				report.addIssue(ValidationKindDevice, device.Name, fullPath, fmt.Errorf("Device %s is already defined in %s", device.Name, file))
+				continue
+			}
+			definedIn[device.Name] = fullPath
+
+			// the same as the Devices added by LoadDevices
+			device.ServiceName = serviceName
+			device.AdminState = models.Unlocked
+			device.OperatingState = models.Up
+			if err := contractsCommon.Validate(device); err != nil {
+				report.addIssue(ValidationKindDevice, device.Name, fullPath, err)
+				continue
+			}
+			if err := validateProfileReference(device.ProfileName, device.AutoEvents, profiles); err != nil {
+				report.addIssue(ValidationKindDevice, device.Name, fullPath, err)
+				continue
+			}
+			report.Devices++
+		}
+	})
+	if err != nil {
+		report.addIssue(ValidationKindDevice, "", path, err)
+	}
+}
+
+func validateProvisionWatchers(path string, profiles map[string]dtos.DeviceProfile, report *ValidationReport, lc logger.LoggingClient) {
+	definedIn := make(map[string]string)
+	err := validationDirectory(path, "Provision Watchers", lc, func(fullPath string) {
+		// the watchers are validated against the schema by readProvisionWatchers
+		watchers, err := readProvisionWatchers(fullPath, fullPath, nil, lc)
+		if err != nil {
+			report.addIssue(ValidationKindProvisionWatcher, "", fullPath, err)
+		}
+		for _, watcher := range watchers {
+			if file, ok := definedIn[watcher.Name]; ok {
+				report.addIssue(ValidationKindProvisionWatcher, watcher.Name, fullPath, fmt.Errorf("ProvisionWatcher %s is already defined in %s", watcher.Name, file))
+				continue
+			}
+			definedIn[watcher.Name] = fullPath
+
+			if err := validateProfileReference(watcher.DiscoveredDevice.ProfileName, watcher.DiscoveredDevice.AutoEvents, profiles); err != nil {
+				report.addIssue(ValidationKindProvisionWatcher, watcher.Name, fullPath, err)
+				continue
+			}
+			report.ProvisionWatchers++
+		}
+	})
+	if err != nil {
+		report.addIssue(ValidationKindProvisionWatcher, "", path, err)
+	}
+}
+
+// validateProfileReference checks the profile is in the ProfilesDir and has the sources of the AutoEvents, the empty
+// profile name is allowed as the Devices without profile are supported
+func validateProfileReference(profileName string, autoEvents []dtos.AutoEvent, profiles map[string]dtos.DeviceProfile) error {
+	if profileName == "" {
+		return nil
+	}
+	profile, ok := profiles[profileName]
+	if !ok {
+		return fmt.Errorf("Device Profile %s is not found or invalid in the ProfilesDir", profileName)
+	}
+	for _, autoEvent := range autoEvents {
+		if !deviceResourcesContain(profile, autoEvent.SourceName) && !deviceCommandsContain(profile, autoEvent.SourceName) {
+			return fmt.Errorf("AutoEvent source %s is not a Device Resource or Device Command of Device Profile %s", autoEvent.SourceName, profileName)
+		}
+	}
+	return nil
+}
+
+func deviceResourcesContain(profile dtos.DeviceProfile, name string) bool {
+	for _, r := range profile.DeviceResources {
+		if r.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func deviceCommandsContain(profile dtos.DeviceProfile, name string) bool {
+	for _, c := range profile.DeviceCommands {
+		if c.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// validationDirectory calls the validate function for each file in the local provisioning directory, the empty path
+// is skipped as the provisioning is disabled
+func validationDirectory(path string, description string, lc logger.LoggingClient, validate func(fullPath string)) errors.EdgeX {
+	if path == "" {
+		return nil
+	}
+	_, err := readDirectory(path, description, lc, func(fullPath string) errors.EdgeX {
+		validate(fullPath)
+		return nil
+	})
+	return err
+}
@@ -0,0 +1,156 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provision
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/clients/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/device-sdk-go/v4/internal/config"
+)
+
+const meterDevices = `
+deviceList:
+  - name: Meter-01
+    profileName: Meter-A
+    protocols:
+      other:
+        Address: meter01
+    autoEvents:
+      - interval: 10s
+        sourceName: Readings
+`
+
+const meterProvisionWatcher = `
+name: Meter-Watcher
+serviceName: device-simple
+identifiers:
+  Address: meter[0-9]+
+adminState: UNLOCKED
+discoveredDevice:
+  profileName: Meter-A
+  adminState: UNLOCKED
+`
+
+func TestValidateProvisioning(t *testing.T) {
+	tests := []struct {
+		name              string
+		profiles          map[string]string
+		devices           map[string]string
+		provisionWatchers map[string]string
+		maxCmdOps         int
+		expectedCounts    [3]int
+		expectedIssues    []ValidationIssue
+	}{
+		{"valid",
+			map[string]string{"base.yaml": baseMeterProfile, "meter.yaml": "name: Meter-A\nextends: Meter-Base\n"},
+			map[string]string{"devices.yaml": meterDevices},
+			map[string]string{"watcher.yaml": meterProvisionWatcher},
+			512, [3]int{1, 1, 1}, nil},
+		{"invalid profile schema",
+			map[string]string{"meter.yaml": "name: Meter-A\ndeviceResources:\n  - name: Voltage\n    properties: {valueType: Decimal, readWrite: R}\n"},
+			nil, nil,
+			512, [3]int{0, 0, 0}, []ValidationIssue{{Kind: ValidationKindDeviceProfile}}},
+		{"command references missing resource",
+			map[string]string{"meter.yaml": "name: Meter-A\ndeviceCommands:\n  - name: Readings\n    readWrite: R\n    resourceOperations:\n      - deviceResource: Voltage\n"},
+			nil, nil,
+			512, [3]int{0, 0, 0}, []ValidationIssue{{Kind: ValidationKindDeviceProfile}}},
+		{"command references missing inherited resource",
+			map[string]string{"base.yaml": baseMeterProfile, "meter.yaml": "name: Meter-A\nextends: Meter-Base\nremove:\n  deviceResources: [Voltage]\n"},
+			nil, nil,
+			512, [3]int{0, 0, 0}, []ValidationIssue{{Kind: ValidationKindDeviceProfile, Name: "Meter-A"}}},
+		{"MaxCmdOps exceeded",
+			map[string]string{"base.yaml": baseMeterProfile, "meter.yaml": "name: Meter-A\nextends: Meter-Base\n"},
+			map[string]string{"devices.yaml": meterDevices},
+			map[string]string{"watcher.yaml": meterProvisionWatcher},
+			1, [3]int{0, 0, 0}, []ValidationIssue{
+				{Kind: ValidationKindDeviceProfile, Name: "Meter-A"},
+				{Kind: ValidationKindDevice, Name: "Meter-01"},
+				{Kind: ValidationKindProvisionWatcher, Name: "Meter-Watcher"},
+			}},
+		{"default MaxCmdOps exceeded",
+			map[string]string{"meter.yaml": "name: Meter-A\ndeviceResources:\n  - name: Voltage\n    properties: {valueType: Float32, readWrite: R}\n" +
+				"deviceCommands:\n  - name: Readings\n    readWrite: R\n    resourceOperations:\n" +
+				strings.Repeat("      - deviceResource: Voltage\n", defaultMaxCmdOps+1)},
+			nil, nil,
+			0, [3]int{0, 0, 0}, []ValidationIssue{{Kind: ValidationKindDeviceProfile, Name: "Meter-A"}}},
+		{"device references missing profile and source",
+			map[string]string{"base.yaml": baseMeterProfile, "meter.yaml": "name: Meter-A\nextends: Meter-Base\n"},
+			map[string]string{"devices.yaml": meterDevices + `
+  - name: Meter-02
+    profileName: Meter-X
+    protocols:
+      other:
+        Address: meter02
+  - name: Meter-03
+    profileName: Meter-A
+    protocols:
+      other:
+        Address: meter03
+    autoEvents:
+      - interval: 10s
+        sourceName: Unknown
+`},
+			nil,
+			512, [3]int{1, 1, 0}, []ValidationIssue{
+				{Kind: ValidationKindDevice, Name: "Meter-02"},
+				{Kind: ValidationKindDevice, Name: "Meter-03"},
+			}},
+		{"invalid device schema and duplicate device",
+			map[string]string{"base.yaml": baseMeterProfile, "meter.yaml": "name: Meter-A\nextends: Meter-Base\n"},
+			map[string]string{"a.yaml": meterDevices, "b.yaml": meterDevices, "c.yaml": "deviceList:\n  - name: Meter-04\n    profileName: Meter-A\n"},
+			nil,
+			512, [3]int{1, 1, 0}, []ValidationIssue{
+				{Kind: ValidationKindDevice, Name: "Meter-01"},
+				{Kind: ValidationKindDevice, Name: "Meter-04"},
+			}},
+		{"unreadable files",
+			map[string]string{"base.yaml": baseMeterProfile, "meter.yaml": "name: [Meter-A\n"},
+			map[string]string{"devices.json": "{"},
+			map[string]string{"watcher.yaml": "name: Meter-Watcher\n"},
+			512, [3]int{0, 0, 0}, []ValidationIssue{
+				{Kind: ValidationKindDeviceProfile},
+				{Kind: ValidationKindDevice},
+				{Kind: ValidationKindProvisionWatcher},
+			}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			device := config.DeviceInfo{
+				MaxCmdOps:            tt.maxCmdOps,
+				ProfilesDir:          writeFiles(t, tt.profiles),
+				DevicesDir:           writeFiles(t, tt.devices),
+				ProvisionWatchersDir: writeFiles(t, tt.provisionWatchers),
+			}
+
+			report := ValidateProvisioning(device, TestDeviceService, logger.NewMockClient())
+			assert.Equal(t, len(tt.expectedIssues) == 0, report.Valid)
+			assert.Equal(t, tt.expectedCounts, [3]int{report.DeviceProfiles, report.Devices, report.ProvisionWatchers})
+			require.Len(t, report.Issues, len(tt.expectedIssues), "%+v", report.Issues)
+			for i, issue := range report.Issues {
+				assert.Equal(t, tt.expectedIssues[i].Kind, issue.Kind)
+				assert.Equal(t, tt.expectedIssues[i].Name, issue.Name, issue.Message)
+				assert.NotEmpty(t, issue.File)
+				assert.NotEmpty(t, issue.Message)
+			}
+		})
+	}
+}
+
+func TestValidateProvisioning_missingDirectory(t *testing.T) {
+	device := config.DeviceInfo{ProfilesDir: filepath.Join(t.TempDir(), "missing")}
+
+	report := ValidateProvisioning(device, TestDeviceService, logger.NewMockClient())
+	assert.False(t, report.Valid)
+	require.Len(t, report.Issues, 1)
+	assert.Equal(t, ValidationKindDeviceProfile, report.Issues[0].Kind)
+}
@@ -0,0 +1,145 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provision
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v4/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v4/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
+	"github.com/fsnotify/fsnotify"
+)
+
+// WatchedDirectory is a provisioning directory whose files are loaded again when they are added, changed or removed
+type WatchedDirectory struct {
+	Path string
+	// Load loads the changed files of the directory to core-metadata, e.g. LoadDeviceFiles with the overwrite flag of
+	// the device service. The files are the absolute paths of the added, changed and removed files.
+	Load func(files []string) errors.EdgeX
+}
+
+// StartWatcher watches the provisioning directories and loads the changed files after they are unchanged for the
+// debounce duration. The changed files are loaded in the order the directories are given, so the profiles are loaded
+// before the devices referencing them. Several directories may have the same path, e.g. the DevicesDir and the
+// ProvisionWatchersDir, the changed files are loaded by each of them. The URIs and empty paths are not watched.
+func StartWatcher(ctx context.Context, wg *sync.WaitGroup, debounce time.Duration, dirs []WatchedDirectory, dic *di.Container) errors.EdgeX {
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.KindServerError, "failed to create the provisioning directories watcher", err)
+	}
+
+	// key is the absolute path of the directory, value is the indexes in dirs
+	watched := make(map[string][]int, len(dirs))
+	for i, dir := range dirs {
+		if dir.Path == "" {
+			continue
+		}
+		if parsedUrl, err := url.Parse(dir.Path); err == nil && (parsedUrl.Scheme == "http" || parsedUrl.Scheme == "https") {
+			lc.Infof("Provisioning URI %s is not watched for changes", parsedUrl.Redacted())
+			continue
+		}
+		absPath, err := filepath.Abs(dir.Path)
+		if err != nil {
+			_ = watcher.Close()
+			return errors.NewCommonEdgeX(errors.KindServerError, "failed to create absolute path for "+dir.Path, err)
+		}
+		if _, ok := watched[absPath]; !ok {
+			if err = watcher.Add(absPath); err != nil {
+				_ = watcher.Close()
+				return errors.NewCommonEdgeX(errors.KindServerError, "failed to watch provisioning directory "+absPath, err)
+			}
+			lc.Infof("Watching provisioning directory %s for changes", absPath)
+		}
+		watched[absPath] = append(watched[absPath], i)
+	}
+	if len(watched) == 0 {
+		_ = watcher.Close()
+		return nil
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer func() {
+			_ = watcher.Close()
+		}()
+
+		timer := time.NewTimer(debounce)
+		timer.Stop()
+		// key is the index in dirs, value is the changed files of the directory
+		pending := make(map[int]map[string]struct{})
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				// the removed files are loaded too, so the declarative provisioning deletes their entries
+				if event.Has(fsnotify.Chmod) && !event.Has(fsnotify.Create) && !event.Has(fsnotify.Write) {
+					continue
+				}
+				indexes, ok := watched[filepath.Dir(event.Name)]
+				if !ok || GetFileType(event.Name) == OTHER {
+					continue
+				}
+				lc.Debugf("Provisioning file %s changed: %s", event.Name, event.Op.String())
+				for _, i := range indexes {
+					if pending[i] == nil {
+						pending[i] = make(map[string]struct{})
+					}
+					pending[i][event.Name] = struct{}{}
+				}
+				timer.Reset(debounce)
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				lc.Errorf("Provisioning directories watcher error: %v", err)
+			case <-timer.C:
+				for i, dir := range dirs {
+					changed, ok := pending[i]
+					if !ok {
+						continue
+					}
+					files := make([]string, 0, len(changed))
+					for file := range changed {
+						files = append(files, file)
+					}
+					sort.Strings(files)
+					lc.Infof("Loading changed provisioning files %v of %s", files, dir.Path)
+					if err := dir.Load(files); err != nil {
+						lc.Errorf("Failed to load changed provisioning files of %s: %v", dir.Path, err)
+					}
+				}
+				pending = make(map[int]map[string]struct{})
+			}
+		}
+	}()
+	return nil
+}
+
+// existingFiles returns the files which exist, so the removed files are skipped
+func existingFiles(files []string) []string {
+	var result []string
+	for _, file := range files {
+		if _, err := os.Stat(file); err == nil {
+			result = append(result, file)
+		}
+	}
+	return result
+}
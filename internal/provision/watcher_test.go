@@ -0,0 +1,100 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provision
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v4/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v4/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStartWatcher(t *testing.T) {
+	dic := di.NewContainer(di.ServiceConstructorMap{
+		bootstrapContainer.LoggingClientInterfaceName: func(get di.Get) any {
+			return logger.NewMockClient()
+		},
+	})
+	profilesDir := t.TempDir()
+	devicesDir := t.TempDir()
+
+	var mutex sync.Mutex
+	var loaded []string
+	loadedFiles := make(map[string][]string)
+	load := func(name string) func([]string) errors.EdgeX {
+		return func(files []string) errors.EdgeX {
+			mutex.Lock()
+			defer mutex.Unlock()
+			loaded = append(loaded, name)
+			loadedFiles[name] = files
+			return nil
+		}
+	}
+	dirs := []WatchedDirectory{
+		{Path: profilesDir, Load: load("profiles")},
+		{Path: devicesDir, Load: load("devices")},
+		{Path: devicesDir, Load: load("watchers")},
+		{Path: "", Load: load("empty")},
+		{Path: "https://example.com/index.json", Load: load("uri")},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	wg := &sync.WaitGroup{}
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+	require.NoError(t, StartWatcher(ctx, wg, 200*time.Millisecond, dirs, dic))
+
+	// the devices are changed before the profiles, the profiles must be loaded first
+	require.NoError(t, os.WriteFile(filepath.Join(devicesDir, "device.yaml"), []byte("deviceList: []"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(devicesDir, "notes.txt"), []byte("ignored"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(profilesDir, "profile.json"), []byte("{}"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(profilesDir, "profile.json"), []byte("{ }"), 0600))
+
+	require.Eventually(t, func() bool {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return len(loaded) == 3
+	}, 5*time.Second, 50*time.Millisecond)
+	mutex.Lock()
+	assert.Equal(t, []string{"profiles", "devices", "watchers"}, loaded)
+	// only the changed files are loaded, by every loader of the directory
+	assert.Equal(t, []string{filepath.Join(profilesDir, "profile.json")}, loadedFiles["profiles"])
+	assert.Equal(t, []string{filepath.Join(devicesDir, "device.yaml")}, loadedFiles["devices"])
+	assert.Equal(t, []string{filepath.Join(devicesDir, "device.yaml")}, loadedFiles["watchers"])
+	mutex.Unlock()
+
+	// the file with an unsupported extension does not trigger the loading
+	require.NoError(t, os.WriteFile(filepath.Join(devicesDir, "notes.txt"), []byte("changed"), 0600))
+	time.Sleep(500 * time.Millisecond)
+	mutex.Lock()
+	assert.Len(t, loaded, 3)
+	mutex.Unlock()
+}
+
+func TestStartWatcher_invalidDirectory(t *testing.T) {
+	dic := di.NewContainer(di.ServiceConstructorMap{
+		bootstrapContainer.LoggingClientInterfaceName: func(get di.Get) any {
+			return logger.NewMockClient()
+		},
+	})
+	dirs := []WatchedDirectory{{Path: filepath.Join(t.TempDir(), "nonexistent")}}
+
+	err := StartWatcher(context.Background(), &sync.WaitGroup{}, time.Second, dirs, dic)
+	require.Error(t, err)
+	assert.Equal(t, errors.KindServerError, errors.Kind(err))
+}
@@ -0,0 +1,363 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package transformer
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v4/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v4/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
+
+	sdkCommon "github.com/edgexfoundry/device-sdk-go/v4/internal/common"
+	"github.com/edgexfoundry/device-sdk-go/v4/internal/utils"
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v4/pkg/models"
+)
+
+const (
+	// AssertionConsequenceKey is the key of the assertion consequence defined in the ResourceProperties.Optional
+	AssertionConsequenceKey = "assertionConsequence"
+
+	// AssertionConsequenceDown marks the device DOWN and fails the request, this is the default consequence
+	AssertionConsequenceDown = "down"
+	// AssertionConsequenceDrop drops the reading from the event
+	AssertionConsequenceDrop = "drop"
+	// AssertionConsequenceQuality keeps the reading and downgrades its quality to bad
+	AssertionConsequenceQuality = "quality"
+	// AssertionConsequenceAlarm keeps the reading and publishes an assertion System Event
+	AssertionConsequenceAlarm = "alarm"
+
+	// AssertionFailed is the quality reason of the reading which failed its assertion
+	AssertionFailed = "assertionFailed"
+
+	regexAssertionPrefix = "regex:"
+	bitAssertionPrefix   = "bit:"
+	maskAssertionPrefix  = "mask:"
+)
+
+// assertionFunc reports whether the CommandValue satisfies the assertion
+type assertionFunc func(cv *sdkModels.CommandValue) bool
+
+// parseAssertion parses the assertion expression of the DeviceResource, the supported expressions are:
+//   - "value" or "==value": the value equals to the given string
+//   - "!=value": the value does not equal to the given string
+//   - ">n", ">=n", "<n", "<=n": numeric comparison
+//   - "[min,max]", "(min,max)" or mixed brackets: numeric range, either bound may be omitted, e.g. "[0,]"
+//   - "regex:pattern": the value matches the regular expression
+//   - "bit:n=0" or "bit:n=1": the bit n (0 is the least significant) of the integer value is cleared or set
+//   - "mask:m=v": the integer value bitwise AND m equals to v, e.g. "mask:0x0F=0x05"
+func parseAssertion(expression string) (assertionFunc, errors.EdgeX) {
+	switch {
+	case strings.HasPrefix(expression, regexAssertionPrefix):
+		regex, err := regexp.Compile(strings.TrimPrefix(expression, regexAssertionPrefix))
+		if err != nil {
+			return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("invalid regex assertion %s", expression), err)
+		}
+		return func(cv *sdkModels.CommandValue) bool {
+			return regex.MatchString(cv.ValueToString())
+		}, nil
+	case strings.HasPrefix(expression, bitAssertionPrefix):
+		return parseBitAssertion(expression)
+	case strings.HasPrefix(expression, maskAssertionPrefix):
+		return parseMaskAssertion(expression)
+	case strings.HasPrefix(expression, "=="):
+		expected := strings.TrimPrefix(expression, "==")
+		return func(cv *sdkModels.CommandValue) bool {
+			return cv.ValueToString() == expected
+		}, nil
+	case strings.HasPrefix(expression, "!="):
+		unexpected := strings.TrimPrefix(expression, "!=")
+		return func(cv *sdkModels.CommandValue) bool {
+			return cv.ValueToString() != unexpected
+		}, nil
+	case strings.HasPrefix(expression, ">="), strings.HasPrefix(expression, "<="),
+		strings.HasPrefix(expression, ">"), strings.HasPrefix(expression, "<"):
+		return parseComparisonAssertion(expression)
+	case isRangeAssertion(expression):
+		return parseRangeAssertion(expression)
+	}
+
+	// an expression without operator is the equality assertion
+	return func(cv *sdkModels.CommandValue) bool {
+		return cv.ValueToString() == expression
+	}, nil
+}
+
+func parseComparisonAssertion(expression string) (assertionFunc, errors.EdgeX) {
+	operator := expression[:1]
+	if strings.HasPrefix(expression[1:], "=") {
+		operator = expression[:2]
+	}
+	bound, err := strconv.ParseFloat(strings.TrimSpace(strings.TrimPrefix(expression, operator)), 64)
+	if err != nil {
+		return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("invalid comparison assertion %s", expression), err)
+	}
+	return func(cv *sdkModels.CommandValue) bool {
+		value, ok := assertionFloatValue(cv)
+		if !ok {
+			return false
+		}
+		switch operator {
+		case ">":
+			return value > bound
+		case ">=":
+			return value >= bound
+		case "<":
+			return value < bound
+		default:
+			return value <= bound
+		}
+	}, nil
+}
+
+func isRangeAssertion(expression string) bool {
+	return len(expression) >= 3 &&
+		strings.ContainsAny(expression[:1], "[(") &&
+		strings.ContainsAny(expression[len(expression)-1:], "])") &&
+		strings.Count(expression, ",") == 1
+}
+
+func parseRangeAssertion(expression string) (assertionFunc, errors.EdgeX) {
+	minInclusive := expression[0] == '['
+	maxInclusive := expression[len(expression)-1] == ']'
+	bounds := strings.Split(expression[1:len(expression)-1], ",")
+	minimum, maximum := math.Inf(-1), math.Inf(1)
+	var err error
+	if s := strings.TrimSpace(bounds[0]); s != "" {
+		if minimum, err = strconv.ParseFloat(s, 64); err != nil {
+			return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("invalid range assertion %s", expression), err)
+		}
+	}
+	if s := strings.TrimSpace(bounds[1]); s != "" {
+		if maximum, err = strconv.ParseFloat(s, 64); err != nil {
+			return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("invalid range assertion %s", expression), err)
+		}
+	}
+	if minimum > maximum {
+		return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("invalid range assertion %s, minimum is greater than maximum", expression), nil)
+	}
+	return func(cv *sdkModels.CommandValue) bool {
+		value, ok := assertionFloatValue(cv)
+		if !ok {
+			return false
+		}
+		if value < minimum || (value == minimum && !minInclusive) {
+			return false
+		}
+		if value > maximum || (value == maximum && !maxInclusive) {
+			return false
+		}
+		return true
+	}, nil
+}
+
+func parseBitAssertion(expression string) (assertionFunc, errors.EdgeX) {
+	bit, expected, ok := strings.Cut(strings.TrimPrefix(expression, bitAssertionPrefix), "=")
+	position, err := strconv.ParseUint(strings.TrimSpace(bit), 10, 6)
+	expected = strings.TrimSpace(expected)
+	if !ok || err != nil || (expected != "0" && expected != "1") {
+		return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("invalid bit assertion %s, expected bit:<0-63>=<0|1>", expression), err)
+	}
+	set := expected == "1"
+	return func(cv *sdkModels.CommandValue) bool {
+		value, ok := assertionIntegerValue(cv)
+		if !ok {
+			return false
+		}
+		return (value&(1<<position) != 0) == set
+	}, nil
+}
+
+func parseMaskAssertion(expression string) (assertionFunc, errors.EdgeX) {
+	maskString, expectedString, ok := strings.Cut(strings.TrimPrefix(expression, maskAssertionPrefix), "=")
+	if !ok {
+		return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("invalid mask assertion %s, expected mask:<mask>=<value>", expression), nil)
+	}
+	mask, err := strconv.ParseUint(strings.TrimSpace(maskString), 0, 64)
+	if err != nil {
+		return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("invalid mask assertion %s", expression), err)
+	}
+	expected, err := strconv.ParseUint(strings.TrimSpace(expectedString), 0, 64)
+	if err != nil {
+		return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("invalid mask assertion %s", expression), err)
+	}
+	return func(cv *sdkModels.CommandValue) bool {
+		value, ok := assertionIntegerValue(cv)
+		if !ok {
+			return false
+		}
+		return value&mask == expected
+	}, nil
+}
+
+// assertionFloatValue returns the numeric value of the CommandValue, the value of other types is parsed from its
+// string format
+func assertionFloatValue(cv *sdkModels.CommandValue) (float64, bool) {
+	if isNumericValueType(cv) {
+		value, err := commandValueForTransform(cv)
+		if err != nil {
+			return 0, false
+		}
+		return toFloat64(value), true
+	}
+	value, err := strconv.ParseFloat(cv.ValueToString(), 64)
+	if err != nil {
+		return 0, false
+	}
+	return value, true
+}
+
+// assertionIntegerValue returns the bits of the integer CommandValue, negative values are in two's complement
+func assertionIntegerValue(cv *sdkModels.CommandValue) (uint64, bool) {
+	if !isNumericValueType(cv) {
+		return 0, false
+	}
+	value, err := commandValueForTransform(cv)
+	if err != nil || !isIntegerValue(value) {
+		return 0, false
+	}
+	switch v := value.(type) {
+	case uint8:
+		return uint64(v), true
+	case uint16:
+		return uint64(v), true
+	case uint32:
+		return uint64(v), true
+	case uint64:
+		return v, true
+	case int8:
+		return uint64(v), true // nolint: gosec
+	case int16:
+		return uint64(v), true // nolint: gosec
+	case int32:
+		return uint64(v), true // nolint: gosec
+	case int64:
+		return uint64(v), true // nolint: gosec
+	}
+	return 0, false
+}
+
+// assertionConsequence returns the consequence of the failed assertion defined in the ResourceProperties.Optional
+func assertionConsequence(dr models.DeviceResource) (string, errors.EdgeX) {
+	consequence, ok := dr.Properties.Optional[AssertionConsequenceKey]
+	if !ok {
+		return AssertionConsequenceDown, nil
+	}
+	switch consequence {
+	case AssertionConsequenceDown, AssertionConsequenceDrop, AssertionConsequenceQuality, AssertionConsequenceAlarm:
+		return consequence.(string), nil
+	}
+	errMsg := fmt.Sprintf("invalid assertion consequence %v of DeviceResource %s, must be one of %s, %s, %s or %s", consequence, dr.Name,
+		AssertionConsequenceDown, AssertionConsequenceDrop, AssertionConsequenceQuality, AssertionConsequenceAlarm)
+	return "", errors.NewCommonEdgeX(errors.KindContractInvalid, errMsg, nil)
+}
+
+// parsedAssertion is the assertion of a DeviceResource with its consequence
+type parsedAssertion struct {
+	check       assertionFunc
+	consequence string
+}
+
+// assertionFor returns the parsed assertion of the DeviceResource, the assertion is only parsed once per DeviceResource
+// and the invalid assertion or consequence is reported by the error
+func assertionFor(profileName string, dr models.DeviceResource) (parsedAssertion, errors.EdgeX) {
+	return assertionCache.get(parseKey{profile: profileName, resource: dr.Name}, dr, func(dr models.DeviceResource) (parsedAssertion, errors.EdgeX) {
+		check, err := parseAssertion(dr.Properties.Assertion)
+		if err != nil {
+			return parsedAssertion{}, errors.NewCommonEdgeX(errors.Kind(err), fmt.Sprintf("invalid assertion of DeviceResource %s", dr.Name), err)
+		}
+		consequence, err := assertionConsequence(dr)
+		if err != nil {
+			return parsedAssertion{}, errors.NewCommonEdgeXWrapper(err)
+		}
+		return parsedAssertion{check: check, consequence: consequence}, nil
+	})
+}
+
+// alarmedAssertions records the DeviceResources of the devices whose assertion is failing with the alarm consequence,
+// so the alarm is only published when the assertion starts failing rather than for every failed reading
+var alarmedAssertions = assertionAlarms{failing: make(map[string]map[string]struct{})}
+
+type assertionAlarms struct {
+	failing map[string]map[string]struct{}
+	mutex   sync.Mutex
+}
+
+// update records whether the assertion of the DeviceResource of the device is failing, and reports whether the
+// assertion started failing
+func (a *assertionAlarms) update(deviceName, resourceName string, failed bool) bool {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	resources := a.failing[deviceName]
+	if _, ok := resources[resourceName]; ok == failed {
+		return false
+	}
+	if !failed {
+		delete(resources, resourceName)
+		if len(resources) == 0 {
+			delete(a.failing, deviceName)
+		}
+		return false
+	}
+	if resources == nil {
+		resources = make(map[string]struct{})
+		a.failing[deviceName] = resources
+	}
+	resources[resourceName] = struct{}{}
+	return true
+}
+
+// checkAssertion checks the CommandValue against the assertion of the DeviceResource. It returns the consequence to
+// be applied on the reading if the assertion fails, or an empty string if the assertion passes. The error is returned
+// if the assertion fails with the down consequence, or the assertion is invalid. The alarm is published when the
+// assertion of the DeviceResource of the device starts failing, and again once it has passed.
+func checkAssertion(cv *sdkModels.CommandValue, dr models.DeviceResource, profileName, deviceName string, dic *di.Container) (string, errors.EdgeX) {
+	if dr.Properties.Assertion == "" {
+		return "", nil
+	}
+	assertion, err := assertionFor(profileName, dr)
+	if err != nil {
+		return "", errors.NewCommonEdgeXWrapper(err)
+	}
+	passed := assertion.check(cv)
+	var alarm bool
+	if assertion.consequence == AssertionConsequenceAlarm {
+		alarm = alarmedAssertions.update(deviceName, cv.DeviceResourceName, !passed)
+	}
+	if passed {
+		return "", nil
+	}
+
+	sdkCommon.IncAssertionsFailed()
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	errMsg := fmt.Sprintf("Assertion %s failed for DeviceResource %s, with value %s", dr.Properties.Assertion, cv.DeviceResourceName, cv.ValueToString())
+	switch assertion.consequence {
+	case AssertionConsequenceDrop:
+		lc.Warnf("%s, the reading is dropped", errMsg)
+	case AssertionConsequenceQuality:
+		lc.Warnf("%s, the reading is flagged with %s quality", errMsg, sdkModels.QualityBad)
+	case AssertionConsequenceAlarm:
+		if !alarm {
+			lc.Debugf("%s, the assertion alarm is already published", errMsg)
+			break
+		}
+		lc.Warnf("%s, publishing the assertion alarm", errMsg)
+		go utils.PublishAssertionAlarmSystemEvent(deviceName, cv.DeviceResourceName, dr.Properties.Assertion, cv.ValueToString(), context.Background(), dic)
+	default:
+		dc := bootstrapContainer.DeviceClientFrom(dic.Get)
+		go sdkCommon.UpdateOperatingState(deviceName, models.Down, lc, dc)
+		return "", errors.NewCommonEdgeX(errors.KindServerError, errMsg, nil)
+	}
+	return assertion.consequence, nil
+}
@@ -0,0 +1,177 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package transformer
+
+import (
+	"reflect"
+	"testing"
+
+	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v4/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v4/di"
+	clientMocks "github.com/edgexfoundry/go-mod-core-contracts/v4/clients/interfaces/mocks"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v4/pkg/models"
+)
+
+func Test_parseAssertion(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		valueType  string
+		value      any
+		expected   bool
+	}{
+		{"equal - plain string", "OK", common.ValueTypeString, "OK", true},
+		{"equal - plain string fails", "OK", common.ValueTypeString, "FAULT", false},
+		{"equal - operator", "==1", common.ValueTypeInt16, int16(1), true},
+		{"not equal", "!=0", common.ValueTypeUint8, uint8(0), false},
+		{"not equal - bool", "!=false", common.ValueTypeBool, true, true},
+		{"greater than", ">10", common.ValueTypeFloat32, float32(10.5), true},
+		{"greater than or equal", ">=10", common.ValueTypeInt32, int32(10), true},
+		{"less than", "<-1", common.ValueTypeInt8, int8(-1), false},
+		{"less than or equal", "<= 2.5", common.ValueTypeFloat64, float64(2.5), true},
+		{"comparison - non-numeric value", ">1", common.ValueTypeString, "high", false},
+		{"comparison - numeric string", ">1", common.ValueTypeString, "3", true},
+		{"range - inclusive", "[0,100]", common.ValueTypeUint16, uint16(100), true},
+		{"range - exclusive", "(0,100)", common.ValueTypeUint16, uint16(100), false},
+		{"range - half open", "[0,100)", common.ValueTypeInt64, int64(0), true},
+		{"range - lower bound only", "[-40,]", common.ValueTypeFloat64, float64(1e9), true},
+		{"range - out of range", "[-40, 85]", common.ValueTypeFloat64, float64(-40.1), false},
+		{"regex", "regex:^(RUN|IDLE)$", common.ValueTypeString, "IDLE", true},
+		{"regex - not matched", "regex:^(RUN|IDLE)$", common.ValueTypeString, "FAULT", false},
+		{"bit set", "bit:3=1", common.ValueTypeUint8, uint8(0x08), true},
+		{"bit cleared", "bit:0=0", common.ValueTypeUint8, uint8(0x01), false},
+		{"bit - negative value", "bit:15=1", common.ValueTypeInt16, int16(-1), true},
+		{"bit - float value", "bit:0=1", common.ValueTypeFloat32, float32(1), false},
+		{"mask", "mask:0x0F=0x05", common.ValueTypeUint16, uint16(0xF5), true},
+		{"mask - not matched", "mask:0x0F=0x05", common.ValueTypeUint16, uint16(0xF6), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check, err := parseAssertion(tt.expression)
+			require.NoError(t, err)
+			cv, e := sdkModels.NewCommandValue(TestDeviceResource, tt.valueType, tt.value)
+			require.NoError(t, e)
+
+			assert.Equal(t, tt.expected, check(cv))
+		})
+	}
+}
+
+func Test_parseAssertion_invalid(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+	}{
+		{"invalid comparison", ">abc"},
+		{"invalid range bound", "[a,10]"},
+		{"invalid range minimum greater than maximum", "[10,0]"},
+		{"invalid regex", "regex:(abc"},
+		{"invalid bit position", "bit:64=1"},
+		{"invalid bit value", "bit:1=2"},
+		{"invalid mask", "mask:0x0F"},
+		{"invalid mask value", "mask:0x0F=zz"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseAssertion(tt.expression)
+			require.Error(t, err)
+			assert.Equal(t, errors.KindContractInvalid, errors.Kind(err))
+		})
+	}
+}
+
+func Test_checkAssertion(t *testing.T) {
+	dcMock := &clientMocks.DeviceClient{}
+	dcMock.On("UpdateWithQueryParams", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+	dic := di.NewContainer(di.ServiceConstructorMap{
+		bootstrapContainer.LoggingClientInterfaceName: func(get di.Get) any {
+			return logger.NewMockClient()
+		},
+		bootstrapContainer.DeviceClientName: func(get di.Get) any {
+			return dcMock
+		},
+	})
+	cv, e := sdkModels.NewCommandValue(TestDeviceResource, common.ValueTypeInt32, int32(120))
+	require.NoError(t, e)
+
+	tests := []struct {
+		name                string
+		assertion           string
+		consequence         any
+		expectedConsequence string
+		expectedErrKind     errors.ErrKind
+	}{
+		{"no assertion", "", nil, "", ""},
+		{"assertion passed", "[0,200]", AssertionConsequenceDrop, "", ""},
+		{"assertion failed - default down", "[0,100]", nil, "", errors.KindServerError},
+		{"assertion failed - down", "[0,100]", AssertionConsequenceDown, "", errors.KindServerError},
+		{"assertion failed - drop", "[0,100]", AssertionConsequenceDrop, AssertionConsequenceDrop, ""},
+		{"assertion failed - quality", "<100", AssertionConsequenceQuality, AssertionConsequenceQuality, ""},
+		{"invalid consequence", "[0,100]", "ignore", "", errors.KindContractInvalid},
+		{"invalid assertion", "[0,x]", AssertionConsequenceDrop, "", errors.KindContractInvalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dr := models.DeviceResource{Name: TestDeviceResource, Properties: models.ResourceProperties{Assertion: tt.assertion}}
+			if tt.consequence != nil {
+				dr.Properties.Optional = map[string]any{AssertionConsequenceKey: tt.consequence}
+			}
+
+			consequence, err := checkAssertion(cv, dr, TestProfile, TestDevice, dic)
+			if tt.expectedErrKind != "" {
+				require.Error(t, err)
+				assert.Equal(t, tt.expectedErrKind, errors.Kind(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedConsequence, consequence)
+		})
+	}
+}
+
+func Test_assertionAlarms(t *testing.T) {
+	alarms := assertionAlarms{failing: make(map[string]map[string]struct{})}
+
+	assert.False(t, alarms.update(TestDevice, TestDeviceResource, false), "passing assertion")
+	assert.True(t, alarms.update(TestDevice, TestDeviceResource, true), "assertion starts failing")
+	assert.False(t, alarms.update(TestDevice, TestDeviceResource, true), "assertion still failing")
+	assert.True(t, alarms.update(TestDevice, "otherResource", true), "other resource starts failing")
+	assert.True(t, alarms.update("otherDevice", TestDeviceResource, true), "other device starts failing")
+
+	assert.False(t, alarms.update(TestDevice, TestDeviceResource, false), "assertion passes again")
+	assert.True(t, alarms.update(TestDevice, TestDeviceResource, true), "assertion fails again")
+}
+
+func Test_assertionFor(t *testing.T) {
+	dr := models.DeviceResource{Name: TestDeviceResource, Properties: models.ResourceProperties{Assertion: "[0,100]"}}
+	parsed, err := assertionFor(TestProfile, dr)
+	require.NoError(t, err)
+	cached, err := assertionFor(TestProfile, dr)
+	require.NoError(t, err)
+	assert.Equal(t, reflect.ValueOf(parsed.check).Pointer(), reflect.ValueOf(cached.check).Pointer(), "assertion is parsed again")
+
+	// the changed assertion is parsed again
+	dr.Properties.Assertion = "[0,x]"
+	_, err = assertionFor(TestProfile, dr)
+	require.Error(t, err)
+	assert.Equal(t, errors.KindContractInvalid, errors.Kind(err))
+
+	// the entries of the updated profile are cleared
+	clearParsedProfile(sdkModels.DeviceProfileChange{Before: &models.DeviceProfile{Name: TestProfile}})
+	assertionCache.mutex.RLock()
+	_, ok := assertionCache.entries[parseKey{profile: TestProfile, resource: TestDeviceResource}]
+	assertionCache.mutex.RUnlock()
+	assert.False(t, ok)
+}
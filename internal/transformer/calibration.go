@@ -0,0 +1,248 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package transformer
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
+)
+
+const (
+	// CalibrationKey is the key of the calibration table, which can be defined in the ResourceProperties.Optional
+	// of a DeviceResource, or in the Device.Properties keyed by DeviceResource name to override the profile one.
+	CalibrationKey = "calibration"
+
+	// OutOfRangeClamp limits the value to the first or last point of the calibration table
+	OutOfRangeClamp = "clamp"
+	// OutOfRangeExtrapolate extends the first or last segment of the calibration table
+	OutOfRangeExtrapolate = "extrapolate"
+	// OutOfRangeError rejects the value which is outside the calibration table
+	OutOfRangeError = "error"
+)
+
+// CalibrationPoint maps a raw device value to its engineering value
+type CalibrationPoint struct {
+	Raw         float64 `json:"raw"`
+	Engineering float64 `json:"engineering"`
+}
+
+// Calibration is a lookup table used to convert raw values to engineering values by piecewise-linear interpolation
+type Calibration struct {
+	Points     []CalibrationPoint `json:"points"`
+	OutOfRange string             `json:"outOfRange"`
+}
+
+// CalibrationFor returns the calibration table of the DeviceResource for the given Device. The table defined in the
+// Device.Properties takes precedence over the one defined in the device profile. Returns nil if no table is defined.
+// The table is only parsed once per DeviceResource, or per Device for the table defined in the Device.Properties.
+func CalibrationFor(device models.Device, dr models.DeviceResource) (*Calibration, errors.EdgeX) {
+	key := parseKey{profile: device.ProfileName, resource: dr.Name}
+	table, ok := dr.Properties.Optional[CalibrationKey]
+	if deviceTables, isMap := device.Properties[CalibrationKey].(map[string]any); isMap {
+		if deviceTable, defined := deviceTables[dr.Name]; defined {
+			key.device = device.Name
+			table, ok = deviceTable, true
+		}
+	}
+	if !ok {
+		return nil, nil
+	}
+	return calibrationCache.get(key, table, parseCalibration)
+}
+
+func parseCalibration(table any) (*Calibration, errors.EdgeX) {
+	bytes, err := json.Marshal(table)
+	if err != nil {
+		return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, "failed to encode calibration table", err)
+	}
+	var calibration Calibration
+	if err = json.Unmarshal(bytes, &calibration); err != nil {
+		return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, "failed to decode calibration table", err)
+	}
+	if edgexErr := calibration.validate(); edgexErr != nil {
+		return nil, errors.NewCommonEdgeXWrapper(edgexErr)
+	}
+	return &calibration, nil
+}
+
+func (c *Calibration) validate() errors.EdgeX {
+	switch c.OutOfRange {
+	case "":
+		c.OutOfRange = OutOfRangeClamp
+	case OutOfRangeClamp, OutOfRangeExtrapolate, OutOfRangeError:
+	default:
+		errMsg := fmt.Sprintf("invalid calibration outOfRange %s, must be one of %s, %s or %s", c.OutOfRange, OutOfRangeClamp, OutOfRangeExtrapolate, OutOfRangeError)
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, errMsg, nil)
+	}
+	if len(c.Points) < 2 {
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, "calibration table requires at least 2 points", nil)
+	}
+	sort.SliceStable(c.Points, func(i, j int) bool {
+		return c.Points[i].Raw < c.Points[j].Raw
+	})
+	for i := 1; i < len(c.Points); i++ {
+		if c.Points[i].Raw == c.Points[i-1].Raw {
+			errMsg := fmt.Sprintf("duplicate raw value %v in calibration table", c.Points[i].Raw)
+			return errors.NewCommonEdgeX(errors.KindContractInvalid, errMsg, nil)
+		}
+	}
+	return nil
+}
+
+// toEngineering interpolates the engineering value of the given raw value
+func (c *Calibration) toEngineering(raw float64) (float64, errors.EdgeX) {
+	xs := make([]float64, len(c.Points))
+	ys := make([]float64, len(c.Points))
+	for i, p := range c.Points {
+		xs[i], ys[i] = p.Raw, p.Engineering
+	}
+	return interpolate(raw, xs, ys, c.OutOfRange)
+}
+
+// toRaw reverse interpolates the raw value of the given engineering value, the engineering values of the
+// calibration table must be strictly monotonic to be reversible.
+func (c *Calibration) toRaw(engineering float64) (float64, errors.EdgeX) {
+	n := len(c.Points)
+	xs := make([]float64, n)
+	ys := make([]float64, n)
+	descending := c.Points[n-1].Engineering < c.Points[0].Engineering
+	for i, p := range c.Points {
+		// reverse the points of a descending curve, e.g. NTC thermistor, so the lookup always runs on ascending values
+		j := i
+		if descending {
+			j = n - 1 - i
+		}
+		xs[j], ys[j] = p.Engineering, p.Raw
+	}
+	for i := 1; i < n; i++ {
+		if xs[i] <= xs[i-1] {
+			return 0, errors.NewCommonEdgeX(errors.KindContractInvalid, "calibration table is not reversible, engineering values must be strictly monotonic", nil)
+		}
+	}
+	return interpolate(engineering, xs, ys, c.OutOfRange)
+}
+
+// interpolate performs the piecewise-linear interpolation on the ascending xs
+func interpolate(x float64, xs, ys []float64, outOfRange string) (float64, errors.EdgeX) {
+	last := len(xs) - 1
+	if x < xs[0] || x > xs[last] {
+		switch outOfRange {
+		case OutOfRangeError:
+			// not an overflow, so the value is rejected rather than handled by the overflow policy
+			errMsg := fmt.Sprintf("value %v out of calibration range [%v, %v]", x, xs[0], xs[last])
+			return 0, errors.NewCommonEdgeX(errors.KindContractInvalid, errMsg, nil)
+		case OutOfRangeExtrapolate:
+			if x < xs[0] {
+				return linear(x, xs[0], ys[0], xs[1], ys[1]), nil
+			}
+			return linear(x, xs[last-1], ys[last-1], xs[last], ys[last]), nil
+		default:
+			if x < xs[0] {
+				return ys[0], nil
+			}
+			return ys[last], nil
+		}
+	}
+
+	i := sort.SearchFloat64s(xs, x)
+	if xs[i] == x {
+		return ys[i], nil
+	}
+	return linear(x, xs[i-1], ys[i-1], xs[i], ys[i]), nil
+}
+
+func linear(x, x0, y0, x1, y1 float64) float64 {
+	return y0 + (x-x0)*(y1-y0)/(x1-x0)
+}
+
+// transformCalibration converts the value with the calibration table, the interpolated result of integer types
+// is rounded to the nearest integer.
+func transformCalibration(value any, calibration *Calibration, read bool) (any, errors.EdgeX) {
+	var err errors.EdgeX
+	valueFloat64 := toFloat64(value)
+	if read {
+		valueFloat64, err = calibration.toEngineering(valueFloat64)
+	} else {
+		valueFloat64, err = calibration.toRaw(valueFloat64)
+	}
+	if err != nil {
+		return 0, errors.NewCommonEdgeXWrapper(err)
+	}
+	return toOriginType(value, valueFloat64)
+}
+
+// toOriginType converts the float64 result back to the type of origin, the result of integer types is rounded to
+// the nearest integer and must be within the range of the type.
+func toOriginType(origin any, value float64) (any, errors.EdgeX) {
+	switch origin.(type) {
+	case float32, float64:
+	default:
+		value = math.Round(value)
+	}
+	inRange := checkTransformedValueInRange(origin, value)
+	if !inRange {
+		errMsg := fmt.Sprintf("transformed value out of its original type (%T) range", origin)
+		return 0, errors.NewCommonEdgeX(errors.KindOverflowError, errMsg, nil)
+	}
+	return fromFloat64(origin, value), nil
+}
+
+func toFloat64(value any) float64 {
+	switch v := value.(type) {
+	case uint8:
+		return float64(v)
+	case uint16:
+		return float64(v)
+	case uint32:
+		return float64(v)
+	case uint64:
+		return float64(v)
+	case int8:
+		return float64(v)
+	case int16:
+		return float64(v)
+	case int32:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case float32:
+		return float64(v)
+	case float64:
+		return v
+	}
+	return 0
+}
+
+// fromFloat64 converts the float64 value back to the type of origin
+func fromFloat64(origin any, value float64) any {
+	switch origin.(type) {
+	case uint8:
+		return uint8(value)
+	case uint16:
+		return uint16(value)
+	case uint32:
+		return uint32(value)
+	case uint64:
+		return uint64(value)
+	case int8:
+		return int8(value)
+	case int16:
+		return int16(value)
+	case int32:
+		return int32(value)
+	case int64:
+		return int64(value)
+	case float32:
+		return float32(value)
+	}
+	return value
+}
@@ -0,0 +1,196 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package transformer
+
+import (
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v4/pkg/models"
+)
+
+func testCalibrationTable(outOfRange string) map[string]any {
+	return map[string]any{
+		"points": []any{
+			map[string]any{"raw": 100, "engineering": 0},
+			map[string]any{"raw": 0, "engineering": 100},
+			map[string]any{"raw": 50, "engineering": 40},
+		},
+		"outOfRange": outOfRange,
+	}
+}
+
+func TestCalibrationFor(t *testing.T) {
+	deviceTable := map[string]any{
+		"points": []any{
+			map[string]any{"raw": 0, "engineering": 0},
+			map[string]any{"raw": 10, "engineering": 20},
+		},
+	}
+	resource := models.DeviceResource{
+		Name:       TestDeviceResource,
+		Properties: models.ResourceProperties{Optional: map[string]any{CalibrationKey: testCalibrationTable("")}},
+	}
+
+	tests := []struct {
+		name        string
+		device      models.Device
+		resource    models.DeviceResource
+		expected    *Calibration
+		expectedErr bool
+	}{
+		{"valid - no calibration", models.Device{}, models.DeviceResource{Name: TestDeviceResource}, nil, false},
+		{"valid - resource calibration", models.Device{}, resource, &Calibration{
+			Points:     []CalibrationPoint{{0, 100}, {50, 40}, {100, 0}},
+			OutOfRange: OutOfRangeClamp,
+		}, false},
+		{"valid - device calibration overrides resource", models.Device{Properties: map[string]any{CalibrationKey: map[string]any{TestDeviceResource: deviceTable}}}, resource, &Calibration{
+			Points:     []CalibrationPoint{{0, 0}, {10, 20}},
+			OutOfRange: OutOfRangeClamp,
+		}, false},
+		{"invalid - single point", models.Device{}, models.DeviceResource{
+			Properties: models.ResourceProperties{Optional: map[string]any{CalibrationKey: map[string]any{"points": []any{map[string]any{"raw": 1, "engineering": 1}}}}},
+		}, nil, true},
+		{"invalid - duplicate raw value", models.Device{}, models.DeviceResource{
+			Properties: models.ResourceProperties{Optional: map[string]any{CalibrationKey: map[string]any{"points": []any{
+				map[string]any{"raw": 1, "engineering": 1}, map[string]any{"raw": 1, "engineering": 2},
+			}}}},
+		}, nil, true},
+		{"invalid - unknown outOfRange", models.Device{}, models.DeviceResource{
+			Properties: models.ResourceProperties{Optional: map[string]any{CalibrationKey: testCalibrationTable("wrap")}},
+		}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := CalibrationFor(tt.device, tt.resource)
+			if tt.expectedErr {
+				require.Error(t, err)
+				assert.Equal(t, errors.KindContractInvalid, errors.Kind(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, res)
+		})
+	}
+}
+
+func TestCalibrationFor_cache(t *testing.T) {
+	resource := models.DeviceResource{
+		Name:       TestDeviceResource,
+		Properties: models.ResourceProperties{Optional: map[string]any{CalibrationKey: testCalibrationTable("")}},
+	}
+	device := models.Device{Name: TestDevice, ProfileName: TestProfile}
+
+	first, err := CalibrationFor(device, resource)
+	require.NoError(t, err)
+	cached, err := CalibrationFor(device, resource)
+	require.NoError(t, err)
+	assert.Same(t, first, cached, "the table is parsed once")
+
+	// the table of the device is parsed and cached separately from the profile one
+	device.Properties = map[string]any{CalibrationKey: map[string]any{TestDeviceResource: testCalibrationTable(OutOfRangeError)}}
+	override, err := CalibrationFor(device, resource)
+	require.NoError(t, err)
+	assert.Equal(t, OutOfRangeError, override.OutOfRange)
+	cached, err = CalibrationFor(device, resource)
+	require.NoError(t, err)
+	assert.Same(t, override, cached)
+
+	// the changed table is parsed again
+	device.Properties = map[string]any{CalibrationKey: map[string]any{TestDeviceResource: testCalibrationTable(OutOfRangeExtrapolate)}}
+	changed, err := CalibrationFor(device, resource)
+	require.NoError(t, err)
+	assert.Equal(t, OutOfRangeExtrapolate, changed.OutOfRange)
+
+	// the tables of the device are cleared when the device changes, the profile one is kept
+	clearParsedDevice(sdkModels.DeviceChange{Before: &device})
+	cached, err = CalibrationFor(device, resource)
+	require.NoError(t, err)
+	assert.NotSame(t, changed, cached)
+	cached, err = CalibrationFor(models.Device{Name: TestDevice, ProfileName: TestProfile}, resource)
+	require.NoError(t, err)
+	assert.Same(t, first, cached)
+}
+
+func Test_transformCalibration(t *testing.T) {
+	clamp, err := parseCalibration(testCalibrationTable(OutOfRangeClamp))
+	require.NoError(t, err)
+	extrapolate, err := parseCalibration(testCalibrationTable(OutOfRangeExtrapolate))
+	require.NoError(t, err)
+	strict, err := parseCalibration(testCalibrationTable(OutOfRangeError))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		value       any
+		calibration *Calibration
+		read        bool
+		expected    any
+		expectedErr errors.ErrKind
+	}{
+		{"valid - read exact point", float64(50), clamp, true, float64(40), ""},
+		{"valid - read interpolation", float64(25), clamp, true, float64(70), ""},
+		{"valid - read integer rounded", uint8(33), clamp, true, uint8(60), ""},
+		{"valid - read clamp", float32(120), clamp, true, float32(0), ""},
+		{"valid - read extrapolate", float64(-10), extrapolate, true, float64(112), ""},
+		{"invalid - read error", float64(101), strict, true, nil, errors.KindContractInvalid},
+		{"invalid - read extrapolate overflow", uint8(120), extrapolate, true, nil, errors.KindOverflowError},
+		{"valid - write reverse interpolation", float64(70), clamp, false, float64(25), ""},
+		{"valid - write clamp", float64(-5), clamp, false, float64(100), ""},
+		{"valid - write extrapolate", int16(112), extrapolate, false, int16(-10), ""},
+		{"invalid - write error", float64(101), strict, false, nil, errors.KindContractInvalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := transformCalibration(tt.value, tt.calibration, tt.read)
+			if tt.expectedErr != "" {
+				require.Error(t, err)
+				assert.Equal(t, tt.expectedErr, errors.Kind(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.InDelta(t, tt.expected, res, 1e-9)
+			assert.IsType(t, tt.expected, res)
+		})
+	}
+}
+
+func Test_transformCalibration_notReversible(t *testing.T) {
+	calibration, err := parseCalibration(map[string]any{
+		"points": []any{
+			map[string]any{"raw": 0, "engineering": 0},
+			map[string]any{"raw": 10, "engineering": 10},
+			map[string]any{"raw": 20, "engineering": 0},
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = transformCalibration(float64(5), calibration, false)
+	require.Error(t, err)
+	assert.Equal(t, errors.KindContractInvalid, errors.Kind(err))
+}
+
+func TestTransformReadResult_calibration(t *testing.T) {
+	calibration, err := parseCalibration(testCalibrationTable(OutOfRangeClamp))
+	require.NoError(t, err)
+	scale := float64(10)
+	cv, e := sdkModels.NewCommandValue(TestDeviceResource, common.ValueTypeFloat64, float64(5))
+	require.NoError(t, e)
+
+	err = TransformReadResult(cv, models.ResourceProperties{Scale: &scale}, calibration)
+	require.NoError(t, err)
+	assert.Equal(t, float64(40), cv.Value)
+
+	err = TransformWriteParameter(cv, models.ResourceProperties{Scale: &scale}, calibration)
+	require.NoError(t, err)
+	assert.Equal(t, float64(5), cv.Value)
+}
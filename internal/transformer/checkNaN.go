@@ -36,3 +36,13 @@ func isNaN(cv *models.CommandValue) (bool, errors.EdgeX) {
 	}
 	return false, nil
 }
+
+func isNaNValue(value any) bool {
+	switch v := value.(type) {
+	case float32:
+		return math.IsNaN(float64(v))
+	case float64:
+		return math.IsNaN(v)
+	}
+	return false
+}
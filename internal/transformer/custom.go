@@ -0,0 +1,112 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package transformer
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v4/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
+
+	"github.com/edgexfoundry/device-sdk-go/v4/internal/container"
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v4/pkg/models"
+)
+
+const (
+	// PreTransformersAttribute is the DeviceResource attribute listing the custom transformers which run on the value
+	// read from the device before the built-in transformations, e.g. to decode the raw value. On the set command,
+	// they run after the built-in transformations in the reverse order.
+	PreTransformersAttribute = "preTransformers"
+	// PostTransformersAttribute is the DeviceResource attribute listing the custom transformers which run after the
+	// built-in transformations of the reading. On the set command, they run before the built-in transformations in the
+	// reverse order.
+	PostTransformersAttribute = "postTransformers"
+)
+
+// customTransformers returns the names of the custom transformers listed in the DeviceResource attribute, the
+// attribute is either a comma separated string or a list of strings
+func customTransformers(dr models.DeviceResource, attribute string) ([]string, errors.EdgeX) {
+	value, ok := dr.Attributes[attribute]
+	if !ok {
+		return nil, nil
+	}
+
+	var names []string
+	switch v := value.(type) {
+	case string:
+		names = strings.Split(v, ",")
+	case []string:
+		names = v
+	case []any:
+		for _, name := range v {
+			s, ok := name.(string)
+			if !ok {
+				errMsg := fmt.Sprintf("invalid %s attribute of DeviceResource %s, the transformer name %v is not a string", attribute, dr.Name, name)
+				return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, errMsg, nil)
+			}
+			names = append(names, s)
+		}
+	default:
+		errMsg := fmt.Sprintf("invalid %s attribute of DeviceResource %s, must be a string or a list of strings", attribute, dr.Name)
+		return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, errMsg, nil)
+	}
+
+	result := make([]string, 0, len(names))
+	for _, name := range names {
+		if name = strings.TrimSpace(name); name != "" {
+			result = append(result, name)
+		}
+	}
+	return result, nil
+}
+
+// transformCustom runs the Read or Write function of the custom transformers listed in the DeviceResource attribute
+func transformCustom(cv *sdkModels.CommandValue, dr models.DeviceResource, attribute string, write bool, dic *di.Container) errors.EdgeX {
+	names, err := customTransformers(dr, attribute)
+	if err != nil || len(names) == 0 {
+		return err
+	}
+
+	registry := container.TransformerRegistryFrom(dic.Get)
+	if write {
+		names = slices.Clone(names)
+		slices.Reverse(names)
+	}
+	for _, name := range names {
+		var transformer sdkModels.Transformer
+		ok := false
+		if registry != nil {
+			transformer, ok = registry.Get(name)
+		}
+		if !ok {
+			errMsg := fmt.Sprintf("custom transformer %s of DeviceResource %s is not registered", name, dr.Name)
+			return errors.NewCommonEdgeX(errors.KindContractInvalid, errMsg, nil)
+		}
+
+		transform := transformer.Read
+		if write {
+			transform = transformer.Write
+		}
+		if transform == nil || cv.Value == nil {
+			continue
+		}
+		if e := transform(cv, dr.Attributes); e != nil {
+			errMsg := fmt.Sprintf("custom transformer %s failed on DeviceResource %s", name, dr.Name)
+			return errors.NewCommonEdgeX(errors.Kind(e), errMsg, e)
+		}
+	}
+	return nil
+}
+
+// TransformWriteCustom runs the Write function of the custom transformers listed in the DeviceResource attribute on
+// the set parameter, in the reverse order of the list.
+func TransformWriteCustom(cv *sdkModels.CommandValue, dr models.DeviceResource, attribute string, dic *di.Container) errors.EdgeX {
+	return transformCustom(cv, dr, attribute, true, dic)
+}
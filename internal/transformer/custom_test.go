@@ -0,0 +1,110 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package transformer
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v4/di"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/device-sdk-go/v4/internal/container"
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v4/pkg/models"
+)
+
+func bcdTransformer() sdkModels.Transformer {
+	return sdkModels.Transformer{
+		Read: func(cv *sdkModels.CommandValue, _ map[string]any) error {
+			raw := cv.Value.(uint16)
+			var value uint16
+			for shift := 12; shift >= 0; shift -= 4 {
+				digit := (raw >> shift) & 0x0F
+				if digit > 9 {
+					return errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("invalid BCD value %#x", raw), nil)
+				}
+				value = value*10 + digit
+			}
+			cv.Value = value
+			return nil
+		},
+		Write: func(cv *sdkModels.CommandValue, _ map[string]any) error {
+			value := cv.Value.(uint16)
+			var raw uint16
+			for shift := 0; shift < 16; shift += 4 {
+				raw |= (value % 10) << shift
+				value /= 10
+			}
+			cv.Value = raw
+			return nil
+		},
+	}
+}
+
+func offsetTransformer(offset uint16) sdkModels.Transformer {
+	return sdkModels.Transformer{
+		Read: func(cv *sdkModels.CommandValue, _ map[string]any) error {
+			cv.Value = cv.Value.(uint16) + offset
+			return nil
+		},
+		Write: func(cv *sdkModels.CommandValue, _ map[string]any) error {
+			cv.Value = cv.Value.(uint16) - offset
+			return nil
+		},
+	}
+}
+
+func Test_transformCustom(t *testing.T) {
+	registry := container.NewTransformerRegistry()
+	require.NoError(t, registry.Register("bcd", bcdTransformer()))
+	require.NoError(t, registry.Register("plus10", offsetTransformer(10)))
+	require.NoError(t, registry.Register("readOnly", sdkModels.Transformer{Read: offsetTransformer(1).Read}))
+	dic := di.NewContainer(di.ServiceConstructorMap{
+		container.TransformerRegistryName: func(get di.Get) any {
+			return registry
+		},
+	})
+
+	tests := []struct {
+		name            string
+		attributes      map[string]any
+		value           uint16
+		write           bool
+		expected        uint16
+		expectedErrKind errors.ErrKind
+	}{
+		{"no transformer", nil, 0x1234, false, 0x1234, ""},
+		{"read", map[string]any{PreTransformersAttribute: "bcd"}, 0x1234, false, 1234, ""},
+		{"read - list in order", map[string]any{PreTransformersAttribute: []any{"bcd", "plus10"}}, 0x1234, false, 1244, ""},
+		{"read - comma separated", map[string]any{PreTransformersAttribute: "bcd, plus10"}, 0x1234, false, 1244, ""},
+		{"write - reverse order", map[string]any{PreTransformersAttribute: []any{"bcd", "plus10"}}, 1244, true, 0x1234, ""},
+		{"write - no write function", map[string]any{PreTransformersAttribute: "readOnly"}, 5, true, 5, ""},
+		{"transformer failed", map[string]any{PreTransformersAttribute: "bcd"}, 0x12A4, false, 0, errors.KindContractInvalid},
+		{"transformer not registered", map[string]any{PreTransformersAttribute: "float32Swap"}, 1, false, 0, errors.KindContractInvalid},
+		{"invalid attribute", map[string]any{PreTransformersAttribute: 1}, 1, false, 0, errors.KindContractInvalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dr := models.DeviceResource{Name: TestDeviceResource, Attributes: tt.attributes}
+			cv, e := sdkModels.NewCommandValue(TestDeviceResource, common.ValueTypeUint16, tt.value)
+			require.NoError(t, e)
+
+			err := transformCustom(cv, dr, PreTransformersAttribute, tt.write, dic)
+			if tt.expectedErrKind != "" {
+				require.Error(t, err)
+				assert.Equal(t, tt.expectedErrKind, errors.Kind(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, cv.Value)
+		})
+	}
+}
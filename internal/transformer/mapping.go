@@ -0,0 +1,206 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package transformer
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
+
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v4/pkg/models"
+)
+
+// MappingDefaultKey is the key of the ResourceOperation mapping entry which applies when no other entry matches
+const MappingDefaultKey = "*"
+
+// the kinds of the mapping entries, in the order they are evaluated
+const (
+	mappingExact = iota
+	mappingBits
+	mappingRange
+	mappingRegex
+	mappingDefault
+)
+
+// valueMapping is a ResourceOperation mapping entry, the key is either an exact raw value or an assertion expression,
+// e.g. "[0,10)", ">=100", "mask:0x0F=0x05", "bit:3=1" or "regex:^E[0-9]+$"
+type valueMapping struct {
+	kind  int
+	key   string
+	label string
+	match assertionFunc
+}
+
+func mappingKind(key string) int {
+	switch {
+	case key == MappingDefaultKey:
+		return mappingDefault
+	case strings.HasPrefix(key, bitAssertionPrefix), strings.HasPrefix(key, maskAssertionPrefix):
+		return mappingBits
+	case strings.HasPrefix(key, regexAssertionPrefix):
+		return mappingRegex
+	case isRangeAssertion(key), strings.HasPrefix(key, ">"), strings.HasPrefix(key, "<"), strings.HasPrefix(key, "!="):
+		return mappingRange
+	}
+	return mappingExact
+}
+
+// parseMappings parses the ResourceOperation mappings into entries sorted by their evaluation order. The exact entries
+// are evaluated first, then the bit, range and regex entries, and the default entry at last. The entries of the same
+// kind are evaluated by the order of their keys, so the first overlapping range entry in the key order wins. The key
+// which is not a valid expression is treated as an exact raw value, so the existing mappings keep working.
+func parseMappings(mappings map[string]string) []valueMapping {
+	entries := make([]valueMapping, 0, len(mappings))
+	for key, label := range mappings {
+		entry := valueMapping{kind: mappingKind(key), key: key, label: label}
+		switch entry.kind {
+		case mappingExact:
+		case mappingDefault:
+			entry.match = func(*sdkModels.CommandValue) bool {
+				return true
+			}
+		default:
+			match, err := parseAssertion(key)
+			if err != nil {
+				entry.kind = mappingExact
+			}
+			entry.match = match
+		}
+		if entry.kind == mappingExact {
+			expected := strings.TrimPrefix(key, "==")
+			entry.match = func(cv *sdkModels.CommandValue) bool {
+				return cv.ValueToString() == expected
+			}
+		}
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].kind != entries[j].kind {
+			return entries[i].kind < entries[j].kind
+		}
+		return lessRawValue(entries[i].key, entries[j].key)
+	})
+	return entries
+}
+
+// mappingsFor returns the parsed mappings of the ResourceOperation, the mappings are only parsed once per
+// ResourceOperation. The ResourceOperation of a reading is looked up by its DeviceResource, so its command is empty.
+func mappingsFor(profileName, commandName string, ro models.ResourceOperation) []valueMapping {
+	key := parseKey{profile: profileName, command: commandName, resource: ro.DeviceResource}
+	entries, _ := mappingCache.get(key, ro.Mappings, func(mappings map[string]string) ([]valueMapping, errors.EdgeX) {
+		return parseMappings(mappings), nil
+	})
+	return entries
+}
+
+// lessRawValue compares the keys numerically if both are numbers, otherwise lexically
+func lessRawValue(a, b string) bool {
+	x, errX := strconv.ParseFloat(a, 64)
+	y, errY := strconv.ParseFloat(b, 64)
+	if errX == nil && errY == nil && x != y {
+		return x < y
+	}
+	return a < b
+}
+
+// mapCommandValue maps the CommandValue to a String CommandValue with the label of the first matched mapping entry.
+// Returns false if no entry matches.
+func mapCommandValue(value *sdkModels.CommandValue, profileName string, ro models.ResourceOperation) (*sdkModels.CommandValue, bool, errors.EdgeX) {
+	if value.Value == nil {
+		return nil, false, nil
+	}
+
+	var label string
+	var ok bool
+	// the exact match is looked up directly as it is the most common mapping
+	if label, ok = ro.Mappings[value.ValueToString()]; !ok {
+		for _, entry := range mappingsFor(profileName, "", ro) {
+			if entry.match(value) {
+				label, ok = entry.label, true
+				break
+			}
+		}
+	}
+	if !ok {
+		return nil, false, nil
+	}
+
+	result, err := sdkModels.NewCommandValue(value.DeviceResourceName, common.ValueTypeString, label)
+	if err != nil {
+		return nil, false, errors.NewCommonEdgeXWrapper(err)
+	}
+	result.Quality = value.Quality
+	return result, true, nil
+}
+
+// ReverseMapping returns the raw value of the label for the set command. When several entries map to the label, the
+// raw value is resolved by the evaluation order of the entries: the exact raw value, the expected value of a bit or
+// mask entry, then the inclusive bound of a range entry. The label mapped only by the regex or default entries cannot
+// be reversed. Returns the label as is if no entry maps to it.
+func ReverseMapping(label string, profileName, commandName string, ro models.ResourceOperation) (string, errors.EdgeX) {
+	found := false
+	for _, entry := range mappingsFor(profileName, commandName, ro) {
+		if entry.label != label {
+			continue
+		}
+		found = true
+		if raw, ok := reverseMappingEntry(entry); ok {
+			return raw, nil
+		}
+	}
+	if found {
+		errMsg := fmt.Sprintf("mapping value %s cannot be reversed to a raw value, it's only mapped by the regex, default or exclusive range entries", label)
+		return "", errors.NewCommonEdgeX(errors.KindContractInvalid, errMsg, nil)
+	}
+	return label, nil
+}
+
+func reverseMappingEntry(entry valueMapping) (string, bool) {
+	key := entry.key
+	switch entry.kind {
+	case mappingExact:
+		return strings.TrimPrefix(key, "=="), true
+	case mappingBits:
+		if strings.HasPrefix(key, maskAssertionPrefix) {
+			_, expected, _ := strings.Cut(key, "=")
+			value, err := strconv.ParseUint(strings.TrimSpace(expected), 0, 64)
+			return strconv.FormatUint(value, 10), err == nil
+		}
+		position, set, _ := strings.Cut(strings.TrimPrefix(key, bitAssertionPrefix), "=")
+		if strings.TrimSpace(set) == "0" {
+			return "0", true
+		}
+		n, err := strconv.ParseUint(strings.TrimSpace(position), 10, 6)
+		return strconv.FormatUint(1<<n, 10), err == nil
+	case mappingRange:
+		var bound string
+		switch {
+		case strings.HasPrefix(key, ">="):
+			bound = key[2:]
+		case strings.HasPrefix(key, "<="):
+			bound = key[2:]
+		case isRangeAssertion(key):
+			minimum, maximum, _ := strings.Cut(key[1:len(key)-1], ",")
+			if key[0] == '[' && strings.TrimSpace(minimum) != "" {
+				bound = minimum
+			} else if key[len(key)-1] == ']' && strings.TrimSpace(maximum) != "" {
+				bound = maximum
+			}
+		}
+		value, err := strconv.ParseFloat(strings.TrimSpace(bound), 64)
+		if err != nil {
+			return "", false
+		}
+		return strconv.FormatFloat(value, 'f', -1, 64), true
+	}
+	return "", false
+}
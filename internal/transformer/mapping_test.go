@@ -0,0 +1,117 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package transformer
+
+import (
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v4/pkg/models"
+)
+
+func Test_mapCommandValue_patterns(t *testing.T) {
+	mappings := map[string]string{
+		"0":               "off",
+		"[0,10)":          "low",
+		"[10,100]":        "high",
+		">100":            "overload",
+		"mask:0xF0=0x80":  "fault",
+		"regex:^E[0-9]+$": "error",
+		MappingDefaultKey: "unknown",
+	}
+
+	tests := []struct {
+		name      string
+		valueType string
+		value     any
+		expected  string
+	}{
+		{"exact match takes precedence", common.ValueTypeUint8, uint8(0), "off"},
+		{"range", common.ValueTypeUint8, uint8(5), "low"},
+		{"range - inclusive bound", common.ValueTypeFloat32, float32(100), "high"},
+		{"comparison", common.ValueTypeUint16, uint16(101), "overload"},
+		{"mask takes precedence over range", common.ValueTypeUint8, uint8(0x85), "fault"},
+		{"regex", common.ValueTypeString, "E42", "error"},
+		{"default", common.ValueTypeString, "standby", "unknown"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cv, e := sdkModels.NewCommandValue(TestDeviceResource, tt.valueType, tt.value)
+			require.NoError(t, e)
+			cv.Quality = sdkModels.QualityUncertain
+
+			res, ok, err := mapCommandValue(cv, TestProfile, models.ResourceOperation{DeviceResource: TestDeviceResource, Mappings: mappings})
+			require.NoError(t, err)
+			require.True(t, ok)
+			assert.Equal(t, common.ValueTypeString, res.Type)
+			assert.Equal(t, tt.expected, res.Value)
+			assert.Equal(t, sdkModels.QualityUncertain, res.Quality)
+		})
+	}
+}
+
+func Test_mapCommandValue_nullValue(t *testing.T) {
+	cv := &sdkModels.CommandValue{DeviceResourceName: TestDeviceResource, Type: common.ValueTypeInt32}
+
+	_, ok, err := mapCommandValue(cv, TestProfile, models.ResourceOperation{DeviceResource: TestDeviceResource, Mappings: map[string]string{MappingDefaultKey: "unknown"}})
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestReverseMapping(t *testing.T) {
+	tests := []struct {
+		name        string
+		mappings    map[string]string
+		label       string
+		expected    string
+		expectedErr bool
+	}{
+		{"exact", map[string]string{"1": "on", "0": "off"}, "on", "1", false},
+		{"several raw values - lowest numeric value", map[string]string{"10": "on", "2": "on", "9": "on"}, "on", "2", false},
+		{"several raw values - lowest string value", map[string]string{"b": "on", "a": "on"}, "on", "a", false},
+		{"exact takes precedence over range", map[string]string{"[1,10]": "low", "5": "low"}, "low", "5", false},
+		{"range - inclusive lower bound", map[string]string{"[0,10)": "low", "[10,100]": "high"}, "high", "10", false},
+		{"range - inclusive upper bound", map[string]string{"(0,10]": "low"}, "low", "10", false},
+		{"comparison", map[string]string{">=100": "overload"}, "overload", "100", false},
+		{"mask", map[string]string{"mask:0xF0=0x80": "fault"}, "fault", "128", false},
+		{"bit set", map[string]string{"bit:3=1": "alarm"}, "alarm", "8", false},
+		{"bit cleared", map[string]string{"bit:3=0": "normal"}, "normal", "0", false},
+		{"not mapped label is kept", map[string]string{"1": "on"}, "42", "42", false},
+		{"exclusive range", map[string]string{"(0,10)": "low"}, "low", "", true},
+		{"regex", map[string]string{"regex:^E[0-9]+$": "error"}, "error", "", true},
+		{"default", map[string]string{"1": "on", MappingDefaultKey: "unknown"}, "unknown", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := ReverseMapping(tt.label, TestProfile, TestDeviceCommand, models.ResourceOperation{DeviceResource: TestDeviceResource, Mappings: tt.mappings})
+			if tt.expectedErr {
+				require.Error(t, err)
+				assert.Equal(t, errors.KindContractInvalid, errors.Kind(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, res)
+		})
+	}
+}
+
+func Test_mappingsFor(t *testing.T) {
+	ro := models.ResourceOperation{DeviceResource: TestDeviceResource, Mappings: map[string]string{"[0,10)": "low", "1": "on"}}
+	parsed := mappingsFor(TestProfile, TestDeviceCommand, ro)
+	require.Len(t, parsed, 2)
+	cached := mappingsFor(TestProfile, TestDeviceCommand, ro)
+	assert.Same(t, &parsed[0], &cached[0], "mappings are parsed again")
+
+	// the changed mappings are parsed again
+	ro.Mappings = map[string]string{"1": "on"}
+	assert.Len(t, mappingsFor(TestProfile, TestDeviceCommand, ro), 1)
+}
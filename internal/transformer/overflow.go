@@ -0,0 +1,137 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package transformer
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
+
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v4/pkg/models"
+)
+
+const (
+	// OverflowPolicyKey is the key of the overflow policy defined in the ResourceProperties.Optional, which overrides
+	// the Writable.Reading.OverflowPolicy of the service
+	OverflowPolicyKey = "overflowPolicy"
+	// OverflowPolicyTag is the reading tag recording the overflow policy applied on the reading
+	OverflowPolicyTag = "overflowPolicy"
+
+	// OverflowPolicyRaw delivers the untransformed value under the transformed type, it must be opted in explicitly
+	// as the consumers which ignore the quality tag would take the raw value as a transformed one
+	OverflowPolicyRaw = "raw"
+	// OverflowPolicyClamp keeps the value type and clamps the transformed value to the bounds of the type
+	OverflowPolicyClamp = "clamp"
+	// OverflowPolicyNull delivers a null reading, this is the default policy
+	OverflowPolicyNull = "null"
+	// OverflowPolicyDrop drops the reading from the event
+	OverflowPolicyDrop = "drop"
+	// OverflowPolicyFail fails the command
+	OverflowPolicyFail = "fail"
+)
+
+// overflowPolicyFor returns the policy to handle the overflow or NaN value of the DeviceResource. The policy defined
+// in the ResourceProperties.Optional takes precedence over the service one.
+func overflowPolicyFor(dr models.DeviceResource, servicePolicy string) (string, errors.EdgeX) {
+	policy := servicePolicy
+	if resourcePolicy, ok := dr.Properties.Optional[OverflowPolicyKey]; ok {
+		policy = fmt.Sprintf("%v", resourcePolicy)
+	}
+	switch policy {
+	case "":
+		return OverflowPolicyNull, nil
+	case OverflowPolicyRaw, OverflowPolicyClamp, OverflowPolicyNull, OverflowPolicyDrop, OverflowPolicyFail:
+		return policy, nil
+	}
+	errMsg := fmt.Sprintf("invalid overflow policy %s of DeviceResource %s, must be one of %s, %s, %s, %s or %s", policy, dr.Name,
+		OverflowPolicyRaw, OverflowPolicyClamp, OverflowPolicyNull, OverflowPolicyDrop, OverflowPolicyFail)
+	return "", errors.NewCommonEdgeX(errors.KindContractInvalid, errMsg, nil)
+}
+
+// clampCommandValue sets the CommandValue to the clamped transformation result of the raw value. The NaN values,
+// which cannot be clamped, are kept.
+func clampCommandValue(cv *sdkModels.CommandValue, rawValue any, pv models.ResourceProperties, calibration *Calibration, fromUnit, toUnit string) errors.EdgeX {
+	rawCV := &sdkModels.CommandValue{DeviceResourceName: cv.DeviceResourceName, Type: cv.Type, Value: rawValue}
+	if isNumericArrayValueType(rawCV) {
+		result, _, err := transformArrayValue(rawCV, func(value any) (any, errors.EdgeX) {
+			return clampReadValue(value, pv, calibration, fromUnit, toUnit)
+		})
+		if err != nil {
+			return errors.NewCommonEdgeXWrapper(err)
+		}
+		cv.Value = result
+		return nil
+	}
+
+	value, err := commandValueForTransform(rawCV)
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+	newValue, err := clampReadValue(value, pv, calibration, fromUnit, toUnit)
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+	cv.Value = newValue
+	return nil
+}
+
+// clampReadValue performs the outgoing data transformations and unit conversion on the float64 form of the raw value,
+// so the result is not limited by the raw type, then clamps the result to the bounds of the raw type.
+func clampReadValue(value any, pv models.ResourceProperties, calibration *Calibration, fromUnit, toUnit string) (any, errors.EdgeX) {
+	if isNaNValue(value) {
+		return nil, errors.NewCommonEdgeX(errors.KindNaNError, "NaN value cannot be clamped", nil)
+	}
+	transformed, err := transformReadValue(toFloat64(transformReadBits(value, pv)), pv, calibration)
+	if err != nil {
+		return nil, errors.NewCommonEdgeXWrapper(err)
+	}
+	result := transformed.(float64)
+	if toUnit != "" && toUnit != fromUnit {
+		result, err = convertUnit(result, fromUnit, toUnit)
+		if err != nil {
+			return nil, errors.NewCommonEdgeXWrapper(err)
+		}
+	}
+	return clampToType(value, result), nil
+}
+
+// clampToType converts the float64 value to the type of origin, the value of integer types is rounded to the nearest
+// integer, and the value out of the range of the type is limited to its minimum or maximum.
+func clampToType(origin any, value float64) any {
+	var minimum, maximum float64
+	switch origin.(type) {
+	case uint8:
+		minimum, maximum = 0, math.MaxUint8
+	case uint16:
+		minimum, maximum = 0, math.MaxUint16
+	case uint32:
+		minimum, maximum = 0, math.MaxUint32
+	case uint64:
+		if value >= float64(math.MaxUint64) {
+			return uint64(math.MaxUint64)
+		}
+		minimum, maximum = 0, math.MaxUint64
+	case int8:
+		minimum, maximum = math.MinInt8, math.MaxInt8
+	case int16:
+		minimum, maximum = math.MinInt16, math.MaxInt16
+	case int32:
+		minimum, maximum = math.MinInt32, math.MaxInt32
+	case int64:
+		if value >= float64(math.MaxInt64) {
+			return int64(math.MaxInt64)
+		}
+		minimum, maximum = math.MinInt64, math.MaxInt64
+	case float32:
+		return float32(math.Max(-math.MaxFloat32, math.Min(math.MaxFloat32, value)))
+	case float64:
+		return math.Max(-math.MaxFloat64, math.Min(math.MaxFloat64, value))
+	}
+	return fromFloat64(origin, math.Max(minimum, math.Min(maximum, math.Round(value))))
+}
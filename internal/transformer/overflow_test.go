@@ -0,0 +1,123 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package transformer
+
+import (
+	"math"
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v4/pkg/models"
+)
+
+func Test_overflowPolicyFor(t *testing.T) {
+	tests := []struct {
+		name           string
+		resourcePolicy any
+		servicePolicy  string
+		expected       string
+		expectedErr    bool
+	}{
+		{"default policy", nil, "", OverflowPolicyNull, false},
+		{"service policy", nil, OverflowPolicyNull, OverflowPolicyNull, false},
+		{"resource policy overrides service", OverflowPolicyClamp, OverflowPolicyFail, OverflowPolicyClamp, false},
+		{"invalid service policy", nil, "ignore", "", true},
+		{"invalid resource policy", "wrap", OverflowPolicyDrop, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dr := models.DeviceResource{Name: TestDeviceResource}
+			if tt.resourcePolicy != nil {
+				dr.Properties.Optional = map[string]any{OverflowPolicyKey: tt.resourcePolicy}
+			}
+
+			res, err := overflowPolicyFor(dr, tt.servicePolicy)
+			if tt.expectedErr {
+				require.Error(t, err)
+				assert.Equal(t, errors.KindContractInvalid, errors.Kind(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, res)
+		})
+	}
+}
+
+func Test_clampToType(t *testing.T) {
+	tests := []struct {
+		name     string
+		origin   any
+		value    float64
+		expected any
+	}{
+		{"uint8 maximum", uint8(0), 400, uint8(math.MaxUint8)},
+		{"uint8 minimum", uint8(0), -3, uint8(0)},
+		{"uint8 rounded", uint8(0), 1.5, uint8(2)},
+		{"int16 minimum", int16(0), -1e6, int16(math.MinInt16)},
+		{"uint64 maximum", uint64(0), 1e30, uint64(math.MaxUint64)},
+		{"int64 maximum", int64(0), 1e30, int64(math.MaxInt64)},
+		{"float32 maximum", float32(0), 1e39, float32(math.MaxFloat32)},
+		{"float64 infinity", float64(0), math.Inf(-1), -math.MaxFloat64},
+		{"float64 in range", float64(0), 1.25, 1.25},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, clampToType(tt.origin, tt.value))
+		})
+	}
+}
+
+func Test_clampCommandValue(t *testing.T) {
+	scale := float64(2)
+	offset := float64(-100)
+	tests := []struct {
+		name      string
+		valueType string
+		rawValue  any
+		pv        models.ResourceProperties
+		fromUnit  string
+		toUnit    string
+		expected  any
+	}{
+		{"uint8 scale overflow", common.ValueTypeUint8, uint8(200), models.ResourceProperties{Scale: &scale}, "", "", uint8(math.MaxUint8)},
+		{"int8 offset overflow", common.ValueTypeInt8, int8(-100), models.ResourceProperties{Offset: &offset}, "", "", int8(math.MinInt8)},
+		{"scale and offset overflow", common.ValueTypeUint8, uint8(200), models.ResourceProperties{Scale: &scale, Offset: &offset}, "", "", uint8(math.MaxUint8)},
+		{"unit conversion overflow", common.ValueTypeUint8, uint8(150), models.ResourceProperties{}, "degC", "degF", uint8(math.MaxUint8)},
+		{"uint8 array", common.ValueTypeUint8Array, []uint8{1, 200}, models.ResourceProperties{Scale: &scale}, "", "", []uint8{2, math.MaxUint8}},
+		{"float64 array NaN kept", common.ValueTypeFloat64Array, []float64{1, math.NaN()}, models.ResourceProperties{Scale: &scale}, "", "", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cv, e := sdkModels.NewCommandValue(TestDeviceResource, tt.valueType, tt.rawValue)
+			require.NoError(t, e)
+
+			err := clampCommandValue(cv, tt.rawValue, tt.pv, nil, tt.fromUnit, tt.toUnit)
+			require.NoError(t, err)
+			if tt.expected == nil {
+				values := cv.Value.([]float64)
+				assert.Equal(t, float64(2), values[0])
+				assert.True(t, math.IsNaN(values[1]))
+				return
+			}
+			assert.Equal(t, tt.expected, cv.Value)
+		})
+	}
+}
+
+func Test_clampCommandValue_NaN(t *testing.T) {
+	cv, e := sdkModels.NewCommandValue(TestDeviceResource, common.ValueTypeFloat32, float32(math.NaN()))
+	require.NoError(t, e)
+
+	err := clampCommandValue(cv, cv.Value, models.ResourceProperties{}, nil, "", "")
+	require.Error(t, err)
+	assert.Equal(t, errors.KindNaNError, errors.Kind(err))
+}
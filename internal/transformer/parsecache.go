@@ -0,0 +1,122 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package transformer
+
+import (
+	"maps"
+	"reflect"
+	"sync"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
+
+	"github.com/edgexfoundry/device-sdk-go/v4/internal/cache"
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v4/pkg/models"
+)
+
+// parseKey identifies the DeviceResource, or the ResourceOperation of the DeviceCommand, whose expressions are parsed.
+// The device is set for the expressions defined in the Device.Properties to override the profile ones.
+type parseKey struct {
+	profile  string
+	command  string
+	resource string
+	device   string
+}
+
+type parsed[S, T any] struct {
+	source S
+	value  T
+	err    errors.EdgeX
+}
+
+// parseCache keeps the parsed expressions of the profiles, so they are not parsed again on every reading. The entries
+// of a profile are cleared when the profile is updated or removed, the entries of a device when the device is updated
+// or removed, and an entry is parsed again if its source differs from the cached one.
+type parseCache[S, T any] struct {
+	mutex   sync.RWMutex
+	entries map[parseKey]parsed[S, T]
+	equal   func(S, S) bool
+}
+
+var (
+	assertionCache = newParseCache[models.DeviceResource, parsedAssertion](func(a, b models.DeviceResource) bool {
+		return a.Properties.Assertion == b.Properties.Assertion &&
+			reflect.DeepEqual(a.Properties.Optional[AssertionConsequenceKey], b.Properties.Optional[AssertionConsequenceKey])
+	})
+	mappingCache     = newParseCache[map[string]string, []valueMapping](maps.Equal[map[string]string])
+	calibrationCache = newParseCache[any, *Calibration](func(a, b any) bool {
+		return reflect.DeepEqual(a, b)
+	})
+
+	watchCachesOnce sync.Once
+)
+
+func newParseCache[S, T any](equal func(S, S) bool) *parseCache[S, T] {
+	return &parseCache[S, T]{entries: make(map[parseKey]parsed[S, T]), equal: equal}
+}
+
+// get returns the parsed value of the source, the source is parsed if it's not cached. The parse error is cached as
+// well, so an invalid expression is reported without being parsed again.
+func (c *parseCache[S, T]) get(key parseKey, source S, parse func(S) (T, errors.EdgeX)) (T, errors.EdgeX) {
+	watchCachesOnce.Do(func() {
+		cache.WatchDeviceProfiles(clearParsedProfile)
+		cache.WatchDevices(clearParsedDevice)
+	})
+
+	c.mutex.RLock()
+	entry, ok := c.entries[key]
+	c.mutex.RUnlock()
+	if ok && c.equal(entry.source, source) {
+		return entry.value, entry.err
+	}
+
+	value, err := parse(source)
+	c.mutex.Lock()
+	c.entries[key] = parsed[S, T]{source: source, value: value, err: err}
+	c.mutex.Unlock()
+	return value, err
+}
+
+func (c *parseCache[S, T]) clear(match func(parseKey) bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for key := range c.entries {
+		if match(key) {
+			delete(c.entries, key)
+		}
+	}
+}
+
+func (c *parseCache[S, T]) clearProfile(profile string) {
+	c.clear(func(key parseKey) bool {
+		return key.profile == profile
+	})
+}
+
+func (c *parseCache[S, T]) clearDevice(device string) {
+	c.clear(func(key parseKey) bool {
+		return key.device == device
+	})
+}
+
+func clearParsedProfile(change sdkModels.DeviceProfileChange) {
+	for _, profile := range []*models.DeviceProfile{change.Before, change.After} {
+		if profile != nil {
+			assertionCache.clearProfile(profile.Name)
+			mappingCache.clearProfile(profile.Name)
+			calibrationCache.clearProfile(profile.Name)
+		}
+	}
+}
+
+func clearParsedDevice(change sdkModels.DeviceChange) {
+	for _, device := range []*models.Device{change.Before, change.After} {
+		if device != nil {
+			calibrationCache.clearDevice(device.Name)
+		}
+	}
+}
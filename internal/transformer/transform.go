@@ -1,12 +1,14 @@
 //
-// Copyright (C) 2021-2023 IOTech Ltd
+// Copyright (C) 2021-2026 IOTech Ltd
 //
 // SPDX-License-Identifier: Apache-2.0
 
 package transformer
 
 import (
+	goErrors "errors"
 	"fmt"
+	"reflect"
 	"sync"
 	"time"
 
@@ -20,6 +22,7 @@ import (
 	"github.com/edgexfoundry/go-mod-core-contracts/v4/common"
 	"github.com/edgexfoundry/go-mod-core-contracts/v4/dtos"
 	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
+	edgexModels "github.com/edgexfoundry/go-mod-core-contracts/v4/models"
 )
 
 var (
@@ -27,7 +30,11 @@ var (
 	originMutex    sync.Mutex
 )
 
-func CommandValuesToEventDTO(cvs []*models.CommandValue, deviceName string, sourceName string, dataTransform bool, dic *di.Container) (*dtos.Event, errors.EdgeX) {
+// CommandValuesToEventDTO converts the CommandValues into an Event. When dataTransform is enabled, the numeric values are
+// transformed, with the custom transformers of the DeviceResource running before and after, then converted to the
+// targetUnits requested, or to the units defined in the Device.Properties. The assertions and the mappings are
+// evaluated on the value in the units of the profile.
+func CommandValuesToEventDTO(cvs []*models.CommandValue, deviceName string, sourceName string, dataTransform bool, targetUnits []string, dic *di.Container) (*dtos.Event, errors.EdgeX) {
 	// in some case device service driver implementation would generate no readings
 	// in this case no event would be created. Based on the implementation there would be 2 scenarios:
 	// 1. uninitialized *CommandValue slices, i.e. nil
@@ -59,35 +66,114 @@ func CommandValuesToEventDTO(cvs []*models.CommandValue, deviceName string, sour
 			return nil, errors.NewCommonEdgeX(errors.KindEntityDoesNotExist, msg, nil)
 		}
 
-		// perform data transformation
+		var qualityReason, overflowPolicy string
+		if !models.IsValidQuality(cv.Quality) {
+			lc.Warnf("invalid Quality %s of CommandValue (%s), must be one of %s, %s or %s, the reading is flagged with %s quality",
+				cv.Quality, cv.String(), models.QualityGood, models.QualityUncertain, models.QualityBad, models.QualityBad)
+			cv.Quality = models.QualityBad
+			qualityReason = InvalidQuality
+		}
+
+		// perform data transformation and unit conversion
+		var failedElements ArrayElementsError
+		// the value before the unit conversion, in the units of the profile
+		var unconverted any
+		units := dr.Properties.Units
 		if dataTransform && cv.Value != nil {
-			edgexErr := TransformReadResult(cv, dr.Properties)
+			var targetUnit string
+			var calibration *Calibration
+			edgexErr := transformCustom(cv, dr, PreTransformersAttribute, false, dic)
+			rawValue := cv.Value
+			if edgexErr == nil {
+				calibration, edgexErr = CalibrationFor(device, dr)
+			}
+			if edgexErr == nil {
+				targetUnit, edgexErr = targetUnitFor(device, dr, targetUnits)
+			}
+			if edgexErr == nil {
+				edgexErr = TransformReadResult(cv, dr.Properties, calibration)
+			}
+			if edgexErr == nil {
+				edgexErr = transformCustom(cv, dr, PostTransformersAttribute, false, dic)
+			}
+			if edgexErr == nil && targetUnit != "" {
+				unconverted = cv.Value
+				edgexErr = convertReadUnit(cv, dr.Properties.Units, targetUnit)
+				// the elements of an array which did not fail are delivered in the target unit
+				var elementsErr ArrayElementsError
+				if edgexErr == nil || goErrors.As(edgexErr, &elementsErr) {
+					units = targetUnit
+				}
+			}
 			if edgexErr != nil {
 				lc.Errorf("failed to transform CommandValue (%s): %v", cv.String(), edgexErr)
+				// the array elements which did not fail are transformed, the failed ones are listed in the reading
+				goErrors.As(edgexErr, &failedElements)
 
-				var err error
-				if errors.Kind(edgexErr) == errors.KindOverflowError {
-					cv, err = models.NewCommandValue(cv.DeviceResourceName, common.ValueTypeString, Overflow)
-					if err != nil {
-						return nil, errors.NewCommonEdgeXWrapper(err)
+				kind := errors.Kind(edgexErr)
+				if kind == errors.KindOverflowError || kind == errors.KindNaNError {
+					qualityReason = Overflow
+					if kind == errors.KindNaNError {
+						qualityReason = NaN
 					}
-				} else if errors.Kind(edgexErr) == errors.KindNaNError {
-					cv, err = models.NewCommandValue(cv.DeviceResourceName, common.ValueTypeString, NaN)
+					config := container.ConfigurationFrom(dic.Get)
+					var err errors.EdgeX
+					overflowPolicy, err = overflowPolicyFor(dr, config.Writable.Reading.OverflowPolicy)
 					if err != nil {
 						return nil, errors.NewCommonEdgeXWrapper(err)
 					}
+
+					switch overflowPolicy {
+					case OverflowPolicyFail:
+						errMsg := fmt.Sprintf("failed to transform value for DeviceResource %s of %s", dr.Name, deviceName)
+						return nil, errors.NewCommonEdgeX(kind, errMsg, edgexErr)
+					case OverflowPolicyDrop:
+						lc.Debugf("dropping the %s reading of DeviceResource %s by the %s policy", qualityReason, dr.Name, overflowPolicy)
+						continue
+					case OverflowPolicyNull:
+						cv.Value = nil
+						cv.DowngradeQuality(models.QualityBad)
+					case OverflowPolicyClamp:
+						// the clamped value is approximate, the NaN values cannot be clamped and are still bad
+						cv.DowngradeQuality(models.QualityUncertain)
+						if kind == errors.KindNaNError {
+							cv.DowngradeQuality(models.QualityBad)
+						}
+						if err = clampCommandValue(cv, rawValue, dr.Properties, calibration, dr.Properties.Units, targetUnit); err != nil {
+							lc.Errorf("failed to clamp CommandValue (%s): %v", cv.String(), err)
+							cv.DowngradeQuality(models.QualityBad)
+						} else if targetUnit != "" {
+							units = targetUnit
+						}
+					default:
+						// the value which overflows or is NaN is delivered untransformed
+						cv.DowngradeQuality(models.QualityBad)
+					}
 				} else {
 					transformsOK = false
 				}
 			}
 		}
 
+		if outOfRange(profileUnitValue(cv, unconverted), dr.Properties) {
+			cv.DowngradeQuality(models.QualityBad)
+			if qualityReason == "" {
+				qualityReason = OutOfRange
+			}
+		}
+
 		// assertion
-		dc := bootstrapContainer.DeviceClientFrom(dic.Get)
-		err := checkAssertion(cv, dr.Properties.Assertion, device.Name, lc, dc)
+		consequence, err := checkAssertion(profileUnitValue(cv, unconverted), dr, device.ProfileName, device.Name, dic)
 		if err != nil {
 			return nil, errors.NewCommonEdgeXWrapper(err)
 		}
+		switch consequence {
+		case AssertionConsequenceDrop:
+			continue
+		case AssertionConsequenceQuality:
+			cv.DowngradeQuality(models.QualityBad)
+			qualityReason = AssertionFailed
+		}
 
 		for key, value := range cv.Tags {
 			tags[key] = value
@@ -99,8 +185,10 @@ func CommandValuesToEventDTO(cvs []*models.CommandValue, deviceName string, sour
 			// this allows SDK to directly read deviceResource without deviceCommands defined.
 			lc.Debugf("failed to read ResourceOperation: %v", err)
 		} else if len(ro.Mappings) > 0 {
-			newCV, ok := mapCommandValue(cv, ro.Mappings)
-			if ok {
+			newCV, ok, err := mapCommandValue(profileUnitValue(cv, unconverted), device.ProfileName, ro)
+			if err != nil {
+				lc.Errorf("failed to map CommandValue (%s): %v", cv.String(), err)
+			} else if ok {
 				cv = newCV
 			}
 		}
@@ -112,9 +200,13 @@ func CommandValuesToEventDTO(cvs []*models.CommandValue, deviceName string, sour
 		// ReadingUnits=true to include units in the reading
 		config := container.ConfigurationFrom(dic.Get)
 		if config.Writable.Reading.ReadingUnits {
-			reading.Units = dr.Properties.Units
+			reading.Units = units
 		}
 		sdkCommon.AddReadingTags(&reading)
+		addQualityTags(&reading, cv.Quality, qualityReason, overflowPolicy)
+		if cv.Value != nil {
+			addFailedElementsTag(&reading, failedElements)
+		}
 		readings = append(readings, reading)
 
 		if cv.Type == common.ValueTypeBinary {
@@ -141,6 +233,79 @@ func CommandValuesToEventDTO(cvs []*models.CommandValue, deviceName string, sour
 	}
 }
 
+// outOfRange reports whether the numeric value, or any element of the numeric array, is outside the Minimum and
+// Maximum of the DeviceResource
+func outOfRange(cv *models.CommandValue, pv edgexModels.ResourceProperties) bool {
+	if cv.Value == nil || (pv.Minimum == nil && pv.Maximum == nil) {
+		return false
+	}
+	var values []any
+	if isNumericValueType(cv) {
+		value, err := commandValueForTransform(cv)
+		if err != nil {
+			return false
+		}
+		values = []any{value}
+	} else if isNumericArrayValueType(cv) {
+		array := reflect.ValueOf(cv.Value)
+		if array.Kind() != reflect.Slice {
+			return false
+		}
+		for i := 0; i < array.Len(); i++ {
+			values = append(values, array.Index(i).Interface())
+		}
+	}
+	for _, value := range values {
+		v := toFloat64(value)
+		if (pv.Minimum != nil && v < *pv.Minimum) || (pv.Maximum != nil && v > *pv.Maximum) {
+			return true
+		}
+	}
+	return false
+}
+
+// profileUnitValue returns the CommandValue with the value before the unit conversion, as the assertions and the
+// mappings are written in the units of the profile. The CommandValue is returned as is if it was not converted, or if
+// its value was nulled by the overflow policy.
+func profileUnitValue(cv *models.CommandValue, unconverted any) *models.CommandValue {
+	if unconverted == nil || cv.Value == nil {
+		return cv
+	}
+	profileCV := *cv
+	profileCV.Value = unconverted
+	return &profileCV
+}
+
+// addQualityTags carries the Quality of the CommandValue into the reading, together with the reason and the overflow
+// policy applied if the Quality is downgraded by the SDK
+func addQualityTags(reading *dtos.BaseReading, quality, reason, overflowPolicy string) {
+	if quality == "" {
+		return
+	}
+	if reading.Tags == nil {
+		reading.Tags = make(map[string]any)
+	}
+	reading.Tags[models.QualityTag] = quality
+	if reason != "" {
+		reading.Tags[models.QualityReasonTag] = reason
+	}
+	if overflowPolicy != "" {
+		reading.Tags[OverflowPolicyTag] = overflowPolicy
+	}
+}
+
+// addFailedElementsTag lists the array elements which failed to be transformed in the reading, so they can be told
+// apart from the transformed ones
+func addFailedElementsTag(reading *dtos.BaseReading, failed ArrayElementsError) {
+	if len(failed.Elements) == 0 {
+		return
+	}
+	if reading.Tags == nil {
+		reading.Tags = make(map[string]any)
+	}
+	reading.Tags[FailedElementsTag] = failed.failures()
+}
+
 func commandValueToReading(cv *models.CommandValue, deviceName, profileName, mediaType string, eventOrigin int64) (dtos.BaseReading, errors.EdgeX) {
 	var err error
 	var reading dtos.BaseReading
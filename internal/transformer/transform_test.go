@@ -1,5 +1,5 @@
 //
-// Copyright (C) 2021-2023 IOTech Ltd
+// Copyright (C) 2021-2026 IOTech Ltd
 //
 // SPDX-License-Identifier: Apache-2.0
 
@@ -20,6 +20,7 @@ import (
 	"github.com/edgexfoundry/go-mod-core-contracts/v4/dtos"
 	dtoCommon "github.com/edgexfoundry/go-mod-core-contracts/v4/dtos/common"
 	"github.com/edgexfoundry/go-mod-core-contracts/v4/dtos/responses"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
 	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -177,7 +178,7 @@ func TestCommandValuesToEventDTO_ReadingUnits(t *testing.T) {
 					return configuration
 				},
 			})
-			event, err := CommandValuesToEventDTO(testCase.CommandValues, TestDevice, TestDeviceCommand, configuration.Device.DataTransform, dic)
+			event, err := CommandValuesToEventDTO(testCase.CommandValues, TestDevice, TestDeviceCommand, configuration.Device.DataTransform, nil, dic)
 			require.NoError(t, err)
 
 			assert.Equal(t, TestDevice, event.DeviceName)
@@ -227,7 +228,7 @@ func TestCommandValuesToEventDTO_ReadingNilValue(t *testing.T) {
 		testCommandNilValue(t, common.ValueTypeObject),
 		testCommandNilValue(t, common.ValueTypeObjectArray),
 	}
-	event, err := CommandValuesToEventDTO(cvs, TestDevice, TestDeviceCommand, true, dic)
+	event, err := CommandValuesToEventDTO(cvs, TestDevice, TestDeviceCommand, true, nil, dic)
 	require.NoError(t, err)
 
 	for _, r := range event.Readings {
@@ -245,3 +246,167 @@ func testCommandNilValue(t *testing.T, valueType string) *sdkModels.CommandValue
 	require.NoError(t, e)
 	return cv
 }
+
+func Test_addQualityTags(t *testing.T) {
+	tests := []struct {
+		name     string
+		quality  string
+		reason   string
+		policy   string
+		expected dtos.Tags
+	}{
+		{"quality not specified", "", "", "", nil},
+		{"quality set by driver", sdkModels.QualityUncertain, "", "", dtos.Tags{sdkModels.QualityTag: sdkModels.QualityUncertain}},
+		{"quality downgraded by transformation", sdkModels.QualityBad, Overflow, OverflowPolicyRaw, dtos.Tags{sdkModels.QualityTag: sdkModels.QualityBad, sdkModels.QualityReasonTag: Overflow, OverflowPolicyTag: OverflowPolicyRaw}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reading := dtos.BaseReading{}
+			addQualityTags(&reading, tt.quality, tt.reason, tt.policy)
+			assert.Equal(t, tt.expected, reading.Tags)
+		})
+	}
+}
+
+func Test_addFailedElementsTag(t *testing.T) {
+	tests := []struct {
+		name     string
+		failed   ArrayElementsError
+		expected dtos.Tags
+	}{
+		{"no failed elements", ArrayElementsError{}, nil},
+		{"failed elements", ArrayElementsError{Elements: map[int]errors.ErrKind{3: errors.KindNaNError, 1: errors.KindOverflowError}},
+			dtos.Tags{FailedElementsTag: []map[string]any{{"index": 1, "reason": Overflow}, {"index": 3, "reason": NaN}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reading := dtos.BaseReading{}
+			addFailedElementsTag(&reading, tt.failed)
+			assert.Equal(t, tt.expected, reading.Tags)
+		})
+	}
+}
+
+func TestCommandValuesToEventDTO_TargetUnitsAfterAssertionAndMapping(t *testing.T) {
+	dic := NewMockDIC()
+	configuration := container.ConfigurationFrom(dic.Get)
+	configuration.Writable.Reading.ReadingUnits = true
+	profile := dtos.DeviceProfile{
+		DeviceProfileBasicInfo: dtos.DeviceProfileBasicInfo{Name: TestProfile},
+		DeviceResources: []dtos.DeviceResource{{
+			Name: TestDeviceResource,
+			Properties: dtos.ResourceProperties{
+				ValueType: common.ValueTypeFloat64,
+				ReadWrite: common.ReadWrite_R,
+				Units:     "degC",
+				Assertion: "[0,100]",
+			},
+		}},
+		DeviceCommands: []dtos.DeviceCommand{{
+			Name:      TestDeviceCommand,
+			ReadWrite: common.ReadWrite_R,
+			ResourceOperations: []dtos.ResourceOperation{{
+				DeviceResource: TestDeviceResource,
+				Mappings:       map[string]string{"[90,100]": "hot"},
+			}},
+		}},
+	}
+	cache.InitCacheFromSnapshot(cache.Snapshot{
+		Devices:  []dtos.Device{{Name: TestDevice, ProfileName: TestProfile, AdminState: models.Unlocked, OperatingState: models.Up}},
+		Profiles: []dtos.DeviceProfile{profile},
+	}, dic)
+
+	hot, err := sdkModels.NewCommandValue(TestDeviceResource, common.ValueTypeFloat64, float64(95))
+	require.NoError(t, err)
+	warm, err := sdkModels.NewCommandValue(TestDeviceResource, common.ValueTypeFloat64, float64(20))
+	require.NoError(t, err)
+
+	// the assertion and the mapping are in the profile units, 95 degC is in range and mapped although 203 degF is not
+	event, err := CommandValuesToEventDTO([]*sdkModels.CommandValue{hot, warm}, TestDevice, TestDeviceCommand, true, []string{"degF"}, dic)
+	require.NoError(t, err)
+	require.Len(t, event.Readings, 2)
+	assert.Equal(t, "hot", event.Readings[0].Value)
+	assert.Equal(t, common.ValueTypeString, event.Readings[0].ValueType)
+	assert.Equal(t, "6.8e+01", event.Readings[1].Value)
+	assert.Equal(t, "degF", event.Readings[1].Units)
+}
+
+func Test_outOfRange(t *testing.T) {
+	minimum, maximum := float64(0), float64(100)
+	pv := models.ResourceProperties{Minimum: &minimum, Maximum: &maximum}
+
+	tests := []struct {
+		name      string
+		valueType string
+		value     any
+		pv        models.ResourceProperties
+		expected  bool
+	}{
+		{"in range", common.ValueTypeInt16, int16(50), pv, false},
+		{"at maximum", common.ValueTypeFloat64, float64(100), pv, false},
+		{"below minimum", common.ValueTypeInt16, int16(-1), pv, true},
+		{"above maximum", common.ValueTypeFloat32, float32(100.5), pv, true},
+		{"only maximum defined", common.ValueTypeUint8, uint8(200), models.ResourceProperties{Maximum: &maximum}, true},
+		{"no range defined", common.ValueTypeUint8, uint8(200), models.ResourceProperties{}, false},
+		{"array in range", common.ValueTypeUint16Array, []uint16{0, 50, 100}, pv, false},
+		{"array element above maximum", common.ValueTypeUint16Array, []uint16{0, 150, 100}, pv, true},
+		{"not numeric", common.ValueTypeString, "1000", pv, false},
+		{"nil value", common.ValueTypeInt16, nil, pv, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cv, err := sdkModels.NewCommandValue(TestDeviceResource, tt.valueType, tt.value)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, outOfRange(cv, tt.pv))
+		})
+	}
+}
+
+func TestCommandValuesToEventDTO_Quality(t *testing.T) {
+	dic := NewMockDIC()
+	maximum := float64(100)
+	profile := dtos.DeviceProfile{
+		DeviceProfileBasicInfo: dtos.DeviceProfileBasicInfo{Name: TestProfile},
+		DeviceResources: []dtos.DeviceResource{{
+			Name:       TestDeviceResource,
+			Properties: dtos.ResourceProperties{ValueType: common.ValueTypeInt32, ReadWrite: common.ReadWrite_R, Maximum: &maximum},
+		}},
+	}
+	cache.InitCacheFromSnapshot(cache.Snapshot{
+		Devices:  []dtos.Device{{Name: TestDevice, ProfileName: TestProfile, AdminState: models.Unlocked, OperatingState: models.Up}},
+		Profiles: []dtos.DeviceProfile{profile},
+	}, dic)
+
+	tests := []struct {
+		name           string
+		value          int32
+		quality        string
+		expectedTags   dtos.Tags
+		expectedReason bool
+	}{
+		{"in range", 50, "", nil, false},
+		{"driver quality kept", 50, sdkModels.QualityUncertain, dtos.Tags{sdkModels.QualityTag: sdkModels.QualityUncertain}, false},
+		{"out of range", 150, sdkModels.QualityGood, dtos.Tags{sdkModels.QualityTag: sdkModels.QualityBad, sdkModels.QualityReasonTag: OutOfRange}, true},
+		{"invalid quality", 50, "excellent", dtos.Tags{sdkModels.QualityTag: sdkModels.QualityBad, sdkModels.QualityReasonTag: InvalidQuality}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cv, err := sdkModels.NewCommandValue(TestDeviceResource, common.ValueTypeInt32, tt.value)
+			require.NoError(t, err)
+			cv.Quality = tt.quality
+
+			event, err := CommandValuesToEventDTO([]*sdkModels.CommandValue{cv}, TestDevice, TestDeviceResource, true, nil, dic)
+			require.NoError(t, err)
+			require.Len(t, event.Readings, 1)
+			for key, value := range tt.expectedTags {
+				assert.Equal(t, value, event.Readings[0].Tags[key])
+			}
+			if tt.expectedTags == nil {
+				assert.NotContains(t, event.Readings[0].Tags, sdkModels.QualityTag)
+			}
+			if !tt.expectedReason {
+				assert.NotContains(t, event.Readings[0].Tags, sdkModels.QualityReasonTag)
+			}
+		})
+	}
+}
@@ -0,0 +1,202 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package transformer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
+
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v4/pkg/models"
+)
+
+// FailedElementsTag is the reading tag listing the index and the reason, Overflow or NaN, of the array elements which
+// failed to be transformed and keep their original value
+const FailedElementsTag = "failedElements"
+
+type numeric interface {
+	uint8 | uint16 | uint32 | uint64 | int8 | int16 | int32 | int64 | float32 | float64
+}
+
+// ArrayElementsError reports the elements of a numeric array which failed to be transformed
+type ArrayElementsError struct {
+	// Elements maps the index of the failed element to the error kind, i.e. KindOverflowError or KindNaNError
+	Elements map[int]errors.ErrKind
+}
+
+func (e ArrayElementsError) Error() string {
+	indexes := make([]int, 0, len(e.Elements))
+	for i := range e.Elements {
+		indexes = append(indexes, i)
+	}
+	sort.Ints(indexes)
+	failures := make([]string, len(indexes))
+	for i, index := range indexes {
+		failures[i] = fmt.Sprintf("%d: %s", index, e.Elements[index])
+	}
+	return fmt.Sprintf("failed to transform array elements [%s]", strings.Join(failures, ", "))
+}
+
+// failures returns the failed elements sorted by their index, with the reason of the failure
+func (e ArrayElementsError) failures() []map[string]any {
+	indexes := make([]int, 0, len(e.Elements))
+	for i := range e.Elements {
+		indexes = append(indexes, i)
+	}
+	sort.Ints(indexes)
+	failures := make([]map[string]any, len(indexes))
+	for i, index := range indexes {
+		reason := Overflow
+		if e.Elements[index] == errors.KindNaNError {
+			reason = NaN
+		}
+		failures[i] = map[string]any{"index": index, "reason": reason}
+	}
+	return failures
+}
+
+// kind returns KindOverflowError if any element overflows, otherwise KindNaNError
+func (e ArrayElementsError) kind() errors.ErrKind {
+	for _, kind := range e.Elements {
+		if kind == errors.KindOverflowError {
+			return errors.KindOverflowError
+		}
+	}
+	return errors.KindNaNError
+}
+
+// transformReadArray performs the outgoing data transformations on each element of the numeric array. The elements
+// which overflow or are NaN keep their original value and are reported by the ArrayElementsError.
+func transformReadArray(cv *sdkModels.CommandValue, pv models.ResourceProperties, calibration *Calibration) errors.EdgeX {
+	result, failed, err := transformArrayValue(cv, func(value any) (any, errors.EdgeX) {
+		if isNaNValue(value) {
+			return nil, errors.NewCommonEdgeX(errors.KindNaNError, "NaN error", nil)
+		}
+		return transformReadValue(value, pv, calibration)
+	})
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+
+	cv.Value = result
+	if len(failed.Elements) > 0 {
+		errMsg := fmt.Sprintf("transformation error for DeviceResource %s", cv.DeviceResourceName)
+		return errors.NewCommonEdgeX(failed.kind(), errMsg, failed)
+	}
+	return nil
+}
+
+// transformWriteArray validates and performs the incoming data transformations on each element of the numeric array.
+// The parameter is rejected if any element fails.
+func transformWriteArray(cv *sdkModels.CommandValue, pv models.ResourceProperties, calibration *Calibration) errors.EdgeX {
+	result, failed, err := transformArrayValue(cv, func(value any) (any, errors.EdgeX) {
+		return transformWriteValue(value, pv, calibration)
+	})
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+	if len(failed.Elements) > 0 {
+		return errors.NewCommonEdgeX(failed.kind(), "failed to transform set parameter", failed)
+	}
+
+	cv.Value = result
+	return nil
+}
+
+func transformArrayValue(cv *sdkModels.CommandValue, transform func(any) (any, errors.EdgeX)) (any, ArrayElementsError, errors.EdgeX) {
+	var err error
+	var result any
+	var failed ArrayElementsError
+	var edgexErr errors.EdgeX
+	switch cv.Type {
+	case common.ValueTypeUint8Array:
+		var values []uint8
+		if values, err = cv.Uint8ArrayValue(); err == nil {
+			result, failed, edgexErr = transformElements(values, transform)
+		}
+	case common.ValueTypeUint16Array:
+		var values []uint16
+		if values, err = cv.Uint16ArrayValue(); err == nil {
+			result, failed, edgexErr = transformElements(values, transform)
+		}
+	case common.ValueTypeUint32Array:
+		var values []uint32
+		if values, err = cv.Uint32ArrayValue(); err == nil {
+			result, failed, edgexErr = transformElements(values, transform)
+		}
+	case common.ValueTypeUint64Array:
+		var values []uint64
+		if values, err = cv.Uint64ArrayValue(); err == nil {
+			result, failed, edgexErr = transformElements(values, transform)
+		}
+	case common.ValueTypeInt8Array:
+		var values []int8
+		if values, err = cv.Int8ArrayValue(); err == nil {
+			result, failed, edgexErr = transformElements(values, transform)
+		}
+	case common.ValueTypeInt16Array:
+		var values []int16
+		if values, err = cv.Int16ArrayValue(); err == nil {
+			result, failed, edgexErr = transformElements(values, transform)
+		}
+	case common.ValueTypeInt32Array:
+		var values []int32
+		if values, err = cv.Int32ArrayValue(); err == nil {
+			result, failed, edgexErr = transformElements(values, transform)
+		}
+	case common.ValueTypeInt64Array:
+		var values []int64
+		if values, err = cv.Int64ArrayValue(); err == nil {
+			result, failed, edgexErr = transformElements(values, transform)
+		}
+	case common.ValueTypeFloat32Array:
+		var values []float32
+		if values, err = cv.Float32ArrayValue(); err == nil {
+			result, failed, edgexErr = transformElements(values, transform)
+		}
+	case common.ValueTypeFloat64Array:
+		var values []float64
+		if values, err = cv.Float64ArrayValue(); err == nil {
+			result, failed, edgexErr = transformElements(values, transform)
+		}
+	default:
+		return nil, failed, errors.NewCommonEdgeX(errors.KindServerError, "unsupported ValueType for array transformation", nil)
+	}
+	if err != nil {
+		return nil, failed, errors.NewCommonEdgeXWrapper(err)
+	}
+	if edgexErr != nil {
+		return nil, failed, errors.NewCommonEdgeXWrapper(edgexErr)
+	}
+	return result, failed, nil
+}
+
+// transformElements applies the transform function on each element and returns a new slice. The elements which
+// overflow or are NaN keep their original value and are collected into the ArrayElementsError, any other error
+// aborts the transformation.
+func transformElements[T numeric](values []T, transform func(any) (any, errors.EdgeX)) ([]T, ArrayElementsError, errors.EdgeX) {
+	failed := ArrayElementsError{Elements: make(map[int]errors.ErrKind)}
+	result := make([]T, len(values))
+	for i, v := range values {
+		newValue, err := transform(v)
+		if err != nil {
+			kind := errors.Kind(err)
+			if kind == errors.KindOverflowError || kind == errors.KindNaNError {
+				failed.Elements[i] = kind
+				result[i] = v
+				continue
+			}
+			return nil, failed, errors.NewCommonEdgeX(kind, fmt.Sprintf("failed to transform array element %d", i), err)
+		}
+		result[i] = newValue.(T)
+	}
+	return result, failed, nil
+}
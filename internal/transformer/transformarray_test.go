@@ -0,0 +1,94 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package transformer
+
+import (
+	goErrors "errors"
+	"math"
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v4/pkg/models"
+)
+
+func TestTransformReadResult_array(t *testing.T) {
+	scale := float64(2)
+	offset := float64(1)
+	mask := uint64(0x0F)
+	tests := []struct {
+		name           string
+		valueType      string
+		value          any
+		pv             models.ResourceProperties
+		expected       any
+		expectedFailed map[int]errors.ErrKind
+	}{
+		{"valid - uint8 array scale and offset", common.ValueTypeUint8Array, []uint8{1, 2, 3}, models.ResourceProperties{Scale: &scale, Offset: &offset}, []uint8{3, 5, 7}, nil},
+		{"valid - int16 array mask", common.ValueTypeInt16Array, []int16{0x1F, 0x2E}, models.ResourceProperties{Mask: &mask}, []int16{0x0F, 0x0E}, nil},
+		{"valid - float32 array scale", common.ValueTypeFloat32Array, []float32{1.5, -2.5}, models.ResourceProperties{Scale: &scale}, []float32{3, -5}, nil},
+		{"valid - float64 array offset", common.ValueTypeFloat64Array, []float64{1.5, -2.5}, models.ResourceProperties{Offset: &offset}, []float64{2.5, -1.5}, nil},
+		{"invalid - uint8 array element overflow", common.ValueTypeUint8Array, []uint8{1, 200, 3}, models.ResourceProperties{Scale: &scale}, []uint8{2, 200, 6}, map[int]errors.ErrKind{1: errors.KindOverflowError}},
+		{"invalid - float64 array element NaN", common.ValueTypeFloat64Array, []float64{1, math.NaN()}, models.ResourceProperties{Scale: &scale}, nil, map[int]errors.ErrKind{1: errors.KindNaNError}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cv, e := sdkModels.NewCommandValue(TestDeviceResource, tt.valueType, tt.value)
+			require.NoError(t, e)
+
+			err := TransformReadResult(cv, tt.pv, nil)
+			if tt.expectedFailed == nil {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expected, cv.Value)
+				return
+			}
+			require.Error(t, err)
+			var elementsErr ArrayElementsError
+			require.True(t, goErrors.As(err, &elementsErr))
+			assert.Equal(t, tt.expectedFailed, elementsErr.Elements)
+			if tt.expected != nil {
+				assert.Equal(t, tt.expected, cv.Value)
+			}
+		})
+	}
+}
+
+func TestTransformWriteParameter_array(t *testing.T) {
+	scale := float64(2)
+	maximum := float64(10)
+	tests := []struct {
+		name        string
+		valueType   string
+		value       any
+		pv          models.ResourceProperties
+		expected    any
+		expectedErr bool
+	}{
+		{"valid - int32 array scale", common.ValueTypeInt32Array, []int32{2, -4}, models.ResourceProperties{Scale: &scale}, []int32{1, -2}, false},
+		{"valid - float32 array scale", common.ValueTypeFloat32Array, []float32{3, 5}, models.ResourceProperties{Scale: &scale}, []float32{1.5, 2.5}, false},
+		{"invalid - uint16 array exceeds maximum", common.ValueTypeUint16Array, []uint16{2, 12}, models.ResourceProperties{Maximum: &maximum}, []uint16{2, 12}, true},
+		{"invalid - uint8 array element not divisible", common.ValueTypeUint8Array, []uint8{2, 3}, models.ResourceProperties{Scale: &scale}, []uint8{2, 3}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cv, e := sdkModels.NewCommandValue(TestDeviceResource, tt.valueType, tt.value)
+			require.NoError(t, e)
+
+			err := TransformWriteParameter(cv, tt.pv, nil)
+			if tt.expectedErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.expected, cv.Value)
+		})
+	}
+}
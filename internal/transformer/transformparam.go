@@ -9,7 +9,6 @@ package transformer
 import (
 	"fmt"
 
-	"github.com/edgexfoundry/go-mod-core-contracts/v4/common"
 	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
 	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
 
@@ -18,10 +17,14 @@ import (
 
 // TransformWriteParameter performs the data transformation on incoming data
 // the incoming data transformations order can refer to https://docs.edgexfoundry.org/4.0/design/adr/device-service/0011-DeviceService-Rest-API/#data-transformations
-func TransformWriteParameter(cv *dsModels.CommandValue, pv models.ResourceProperties) errors.EdgeX {
+// the calibration table, if any, is reversed before the offset transformation
+func TransformWriteParameter(cv *dsModels.CommandValue, pv models.ResourceProperties, calibration *Calibration) errors.EdgeX {
 	if cv.Value == nil {
 		return nil
 	}
+	if isNumericArrayValueType(cv) {
+		return transformWriteArray(cv, pv, calibration)
+	}
 	if !isNumericValueType(cv) {
 		return nil
 	}
@@ -30,54 +33,66 @@ func TransformWriteParameter(cv *dsModels.CommandValue, pv models.ResourceProper
 	if err != nil {
 		return errors.NewCommonEdgeXWrapper(err)
 	}
+	newValue, err := transformWriteValue(value, pv, calibration)
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+
+	if value != newValue {
+		cv.Value = newValue
+	}
+	return nil
+}
+
+// transformWriteValue validates and performs the incoming data transformations on a single numeric value
+func transformWriteValue(value any, pv models.ResourceProperties, calibration *Calibration) (any, errors.EdgeX) {
+	var err errors.EdgeX
 	newValue := value
 
 	if pv.Maximum != nil {
 		err = validateWriteMaximum(value, *pv.Maximum)
 		if err != nil {
-			return errors.NewCommonEdgeXWrapper(err)
+			return nil, errors.NewCommonEdgeXWrapper(err)
 		}
 	}
 	if pv.Minimum != nil {
 		err = validateWriteMinimum(value, *pv.Minimum)
 		if err != nil {
-			return errors.NewCommonEdgeXWrapper(err)
+			return nil, errors.NewCommonEdgeXWrapper(err)
+		}
+	}
+	if calibration != nil {
+		newValue, err = transformCalibration(newValue, calibration, false)
+		if err != nil {
+			return nil, errors.NewCommonEdgeXWrapper(err)
 		}
 	}
 	if pv.Offset != nil && *pv.Offset != defaultOffset {
 		newValue, err = transformOffset(newValue, *pv.Offset, false)
 		if err != nil {
-			return errors.NewCommonEdgeXWrapper(err)
+			return nil, errors.NewCommonEdgeXWrapper(err)
 		}
 	}
 	if pv.Scale != nil && *pv.Scale != defaultScale {
 		newValue, err = transformScale(newValue, *pv.Scale, false)
 		if err != nil {
-			return errors.NewCommonEdgeXWrapper(err)
+			return nil, errors.NewCommonEdgeXWrapper(err)
 		}
 	}
 	if pv.Base != nil && *pv.Base != defaultBase {
 		newValue, err = transformBase(newValue, *pv.Base, false)
 		if err != nil {
-			return errors.NewCommonEdgeXWrapper(err)
+			return nil, errors.NewCommonEdgeXWrapper(err)
 		}
 	}
-	if pv.Shift != nil && *pv.Shift != defaultShift &&
-		(cv.Type == common.ValueTypeUint8 || cv.Type == common.ValueTypeUint16 || cv.Type == common.ValueTypeUint32 || cv.Type == common.ValueTypeUint64 ||
-			cv.Type == common.ValueTypeInt8 || cv.Type == common.ValueTypeInt16 || cv.Type == common.ValueTypeInt32 || cv.Type == common.ValueTypeInt64) {
+	if pv.Shift != nil && *pv.Shift != defaultShift && isIntegerValue(value) {
 		// use negative value to reuse the shift function to perform reversed operation
 		newValue = transformShift(newValue, -*pv.Shift)
 	}
-	if pv.Mask != nil && *pv.Mask != defaultMask &&
-		(cv.Type == common.ValueTypeUint8 || cv.Type == common.ValueTypeUint16 || cv.Type == common.ValueTypeUint32 || cv.Type == common.ValueTypeUint64 ||
-			cv.Type == common.ValueTypeInt8 || cv.Type == common.ValueTypeInt16 || cv.Type == common.ValueTypeInt32 || cv.Type == common.ValueTypeInt64) {
+	if pv.Mask != nil && *pv.Mask != defaultMask && isIntegerValue(value) {
 		newValue = transformMask(newValue, *pv.Mask)
 	}
-
-	if value != newValue {
-		cv.Value = newValue
-	}
-	return nil
+	return newValue, nil
 }
 
 func validateWriteMaximum(value any, maximum float64) errors.EdgeX {
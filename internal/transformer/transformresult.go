@@ -10,13 +10,10 @@ import (
 	"fmt"
 	"math"
 
-	"github.com/edgexfoundry/go-mod-core-contracts/v4/clients/interfaces"
-	"github.com/edgexfoundry/go-mod-core-contracts/v4/clients/logger"
 	"github.com/edgexfoundry/go-mod-core-contracts/v4/common"
 	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
 	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
 
-	sdkCommon "github.com/edgexfoundry/device-sdk-go/v4/internal/common"
 	sdkModels "github.com/edgexfoundry/device-sdk-go/v4/pkg/models"
 )
 
@@ -27,13 +24,23 @@ const (
 	defaultMask   uint64  = 0
 	defaultShift  int64   = 0
 
+	// Overflow is the quality reason of the value which overflows its type after transformation
 	Overflow = "overflow"
-	NaN      = "NaN"
+	// NaN is the quality reason of the value which is not a number
+	NaN = "NaN"
+	// OutOfRange is the quality reason of the value outside the Minimum and Maximum of the DeviceResource
+	OutOfRange = "outOfRange"
+	// InvalidQuality is the quality reason of the value whose Quality set by the ProtocolDriver is unknown
+	InvalidQuality = "invalidQuality"
 )
 
 // TransformReadResult performs the data transformation on outgoing data
 // the outgoing data transformations order can refer to https://docs.edgexfoundry.org/4.0/design/adr/device-service/0011-DeviceService-Rest-API/#data-transformations
-func TransformReadResult(cv *sdkModels.CommandValue, pv models.ResourceProperties) errors.EdgeX {
+// the calibration table, if any, is applied after the offset transformation
+func TransformReadResult(cv *sdkModels.CommandValue, pv models.ResourceProperties, calibration *Calibration) errors.EdgeX {
+	if isNumericArrayValueType(cv) {
+		return transformReadArray(cv, pv, calibration)
+	}
 	if !isNumericValueType(cv) {
 		return nil
 	}
@@ -49,41 +56,61 @@ func TransformReadResult(cv *sdkModels.CommandValue, pv models.ResourcePropertie
 	if err != nil {
 		return errors.NewCommonEdgeXWrapper(err)
 	}
-	newValue := value
-
-	if pv.Mask != nil && *pv.Mask != defaultMask &&
-		(cv.Type == common.ValueTypeUint8 || cv.Type == common.ValueTypeUint16 || cv.Type == common.ValueTypeUint32 || cv.Type == common.ValueTypeUint64 ||
-			cv.Type == common.ValueTypeInt8 || cv.Type == common.ValueTypeInt16 || cv.Type == common.ValueTypeInt32 || cv.Type == common.ValueTypeInt64) {
-		newValue = transformMask(newValue, *pv.Mask)
+	newValue, err := transformReadValue(value, pv, calibration)
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
 	}
-	if pv.Shift != nil && *pv.Shift != defaultShift &&
-		(cv.Type == common.ValueTypeUint8 || cv.Type == common.ValueTypeUint16 || cv.Type == common.ValueTypeUint32 || cv.Type == common.ValueTypeUint64 ||
-			cv.Type == common.ValueTypeInt8 || cv.Type == common.ValueTypeInt16 || cv.Type == common.ValueTypeInt32 || cv.Type == common.ValueTypeInt64) {
-		newValue = transformShift(newValue, *pv.Shift)
+
+	if value != newValue {
+		cv.Value = newValue
 	}
+	return nil
+}
+
+// transformReadValue performs the outgoing data transformations on a single numeric value
+func transformReadValue(value any, pv models.ResourceProperties, calibration *Calibration) (any, errors.EdgeX) {
+	var err errors.EdgeX
+	newValue := transformReadBits(value, pv)
+
 	if pv.Base != nil && *pv.Base != defaultBase {
 		newValue, err = transformBase(newValue, *pv.Base, true)
 		if err != nil {
-			return errors.NewCommonEdgeXWrapper(err)
+			return nil, errors.NewCommonEdgeXWrapper(err)
 		}
 	}
 	if pv.Scale != nil && *pv.Scale != defaultScale {
 		newValue, err = transformScale(newValue, *pv.Scale, true)
 		if err != nil {
-			return errors.NewCommonEdgeXWrapper(err)
+			return nil, errors.NewCommonEdgeXWrapper(err)
 		}
 	}
 	if pv.Offset != nil && *pv.Offset != defaultOffset {
 		newValue, err = transformOffset(newValue, *pv.Offset, true)
 		if err != nil {
-			return errors.NewCommonEdgeXWrapper(err)
+			return nil, errors.NewCommonEdgeXWrapper(err)
 		}
 	}
+	if calibration != nil {
+		newValue, err = transformCalibration(newValue, calibration, true)
+		if err != nil {
+			return nil, errors.NewCommonEdgeXWrapper(err)
+		}
+	}
+	return newValue, nil
+}
 
-	if value != newValue {
-		cv.Value = newValue
+// transformReadBits performs the mask and shift transformations, which only apply on integer values
+func transformReadBits(value any, pv models.ResourceProperties) any {
+	if !isIntegerValue(value) {
+		return value
 	}
-	return nil
+	if pv.Mask != nil && *pv.Mask != defaultMask {
+		value = transformMask(value, *pv.Mask)
+	}
+	if pv.Shift != nil && *pv.Shift != defaultShift {
+		value = transformShift(value, *pv.Shift)
+	}
+	return value
 }
 
 func transformBase(value any, base float64, read bool) (any, errors.EdgeX) {
@@ -491,34 +518,6 @@ func commandValueForTransform(cv *sdkModels.CommandValue) (interface{}, errors.E
 	return v, nil
 }
 
-func checkAssertion(
-	cv *sdkModels.CommandValue,
-	assertion string,
-	deviceName string,
-	lc logger.LoggingClient,
-	dc interfaces.DeviceClient) errors.EdgeX {
-	if assertion != "" && cv.ValueToString() != assertion {
-		go sdkCommon.UpdateOperatingState(deviceName, models.Down, lc, dc)
-		errMsg := fmt.Sprintf("Assertion failed for DeviceResource %s, with value %s", cv.DeviceResourceName, cv.ValueToString())
-		return errors.NewCommonEdgeX(errors.KindServerError, errMsg, nil)
-	}
-	return nil
-}
-
-func mapCommandValue(value *sdkModels.CommandValue, mappings map[string]string) (*sdkModels.CommandValue, bool) {
-	var err error
-	var result *sdkModels.CommandValue
-
-	newValue, ok := mappings[value.ValueToString()]
-	if ok {
-		result, err = sdkModels.NewCommandValue(value.DeviceResourceName, common.ValueTypeString, newValue)
-		if err != nil {
-			return nil, false
-		}
-	}
-	return result, ok
-}
-
 func isNumericValueType(cv *sdkModels.CommandValue) bool {
 	switch cv.Type {
 	case common.ValueTypeUint8:
@@ -536,3 +535,29 @@ func isNumericValueType(cv *sdkModels.CommandValue) bool {
 	}
 	return true
 }
+
+func isNumericArrayValueType(cv *sdkModels.CommandValue) bool {
+	switch cv.Type {
+	case common.ValueTypeUint8Array:
+	case common.ValueTypeUint16Array:
+	case common.ValueTypeUint32Array:
+	case common.ValueTypeUint64Array:
+	case common.ValueTypeInt8Array:
+	case common.ValueTypeInt16Array:
+	case common.ValueTypeInt32Array:
+	case common.ValueTypeInt64Array:
+	case common.ValueTypeFloat32Array:
+	case common.ValueTypeFloat64Array:
+	default:
+		return false
+	}
+	return true
+}
+
+func isIntegerValue(value any) bool {
+	switch value.(type) {
+	case uint8, uint16, uint32, uint64, int8, int16, int32, int64:
+		return true
+	}
+	return false
+}
@@ -1,6 +1,6 @@
 // -*- Mode: Go; indent-tabs-mode: t -*-
 //
-// Copyright (C) 2019-2023 IOTech Ltd
+// Copyright (C) 2019-2026 IOTech Ltd
 //
 // SPDX-License-Identifier: Apache-2.0
 
@@ -12,6 +12,7 @@ import (
 
 	"github.com/edgexfoundry/go-mod-core-contracts/v4/common"
 	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
+	edgexModels "github.com/edgexfoundry/go-mod-core-contracts/v4/models"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -280,7 +281,8 @@ func Test_mapCommandValue(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			res, ok := mapCommandValue(tt.cv, mappings)
+			res, ok, err := mapCommandValue(tt.cv, TestProfile, edgexModels.ResourceOperation{DeviceResource: "test-resource", Mappings: mappings})
+			require.NoError(t, err)
 			require.Equal(t, ok, tt.success)
 			if ok {
 				assert.Equal(t, res.Value, "value")
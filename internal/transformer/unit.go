@@ -0,0 +1,285 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package transformer
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
+
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v4/pkg/models"
+)
+
+// significantDigits is the precision of the converted values
+const significantDigits = 12
+
+// UnitsKey is the key of the target units defined in the Device.Properties, keyed by DeviceResource name,
+// e.g. units: { Temperature: degF }
+const UnitsKey = "units"
+
+const (
+	dimensionTemperature = "temperature"
+	dimensionPressure    = "pressure"
+	dimensionLength      = "length"
+	dimensionMass        = "mass"
+	dimensionVolume      = "volume"
+	dimensionFlow        = "flow"
+	dimensionSpeed       = "speed"
+	dimensionEnergy      = "energy"
+	dimensionPower       = "power"
+	dimensionVoltage     = "voltage"
+	dimensionCurrent     = "current"
+	dimensionFrequency   = "frequency"
+	dimensionTime        = "time"
+)
+
+// unit converts a value to the SI unit of its dimension by: si = value * factor + offset
+type unit struct {
+	dimension string
+	factor    float64
+	offset    float64
+}
+
+var (
+	kelvin     = unit{dimensionTemperature, 1, 0}
+	celsius    = unit{dimensionTemperature, 1, 273.15}
+	fahrenheit = unit{dimensionTemperature, 5.0 / 9.0, 273.15 - 32*5.0/9.0}
+)
+
+// units is the built-in table of the supported units, keyed by the symbols and aliases used in the device profiles
+var units = map[string]unit{
+	// temperature, SI unit K
+	"K": kelvin, "kelvin": kelvin,
+	"°C": celsius, "C": celsius, "degC": celsius, "celsius": celsius, "degrees Celsius": celsius,
+	"°F": fahrenheit, "F": fahrenheit, "degF": fahrenheit, "fahrenheit": fahrenheit, "degrees Fahrenheit": fahrenheit,
+
+	// pressure, SI unit Pa
+	"Pa":   {dimensionPressure, 1, 0},
+	"hPa":  {dimensionPressure, 1e2, 0},
+	"kPa":  {dimensionPressure, 1e3, 0},
+	"MPa":  {dimensionPressure, 1e6, 0},
+	"mbar": {dimensionPressure, 1e2, 0},
+	"bar":  {dimensionPressure, 1e5, 0},
+	"psi":  {dimensionPressure, 6894.757293168361, 0},
+	"atm":  {dimensionPressure, 101325, 0},
+	"mmHg": {dimensionPressure, 133.322387415, 0},
+	"inHg": {dimensionPressure, 3386.389, 0},
+
+	// length, SI unit m
+	"mm": {dimensionLength, 1e-3, 0},
+	"cm": {dimensionLength, 1e-2, 0},
+	"m":  {dimensionLength, 1, 0},
+	"km": {dimensionLength, 1e3, 0},
+	"in": {dimensionLength, 0.0254, 0},
+	"ft": {dimensionLength, 0.3048, 0},
+	"yd": {dimensionLength, 0.9144, 0},
+	"mi": {dimensionLength, 1609.344, 0},
+
+	// mass, SI unit kg
+	"mg": {dimensionMass, 1e-6, 0},
+	"g":  {dimensionMass, 1e-3, 0},
+	"kg": {dimensionMass, 1, 0},
+	"t":  {dimensionMass, 1e3, 0},
+	"oz": {dimensionMass, 0.028349523125, 0},
+	"lb": {dimensionMass, 0.45359237, 0},
+
+	// volume, SI unit m³
+	"mL":  {dimensionVolume, 1e-6, 0},
+	"L":   {dimensionVolume, 1e-3, 0},
+	"m³":  {dimensionVolume, 1, 0},
+	"m3":  {dimensionVolume, 1, 0},
+	"gal": {dimensionVolume, 0.003785411784, 0},
+	"ft³": {dimensionVolume, 0.028316846592, 0},
+	"ft3": {dimensionVolume, 0.028316846592, 0},
+
+	// volumetric flow, SI unit m³/s
+	"m³/s":  {dimensionFlow, 1, 0},
+	"m3/s":  {dimensionFlow, 1, 0},
+	"m³/h":  {dimensionFlow, 1.0 / 3600, 0},
+	"m3/h":  {dimensionFlow, 1.0 / 3600, 0},
+	"L/s":   {dimensionFlow, 1e-3, 0},
+	"L/min": {dimensionFlow, 1e-3 / 60, 0},
+	"gpm":   {dimensionFlow, 0.003785411784 / 60, 0},
+	"cfm":   {dimensionFlow, 0.028316846592 / 60, 0},
+
+	// speed, SI unit m/s
+	"m/s":  {dimensionSpeed, 1, 0},
+	"km/h": {dimensionSpeed, 1 / 3.6, 0},
+	"ft/s": {dimensionSpeed, 0.3048, 0},
+	"mph":  {dimensionSpeed, 0.44704, 0},
+	"kn":   {dimensionSpeed, 1852.0 / 3600, 0},
+
+	// energy, SI unit J
+	"J":    {dimensionEnergy, 1, 0},
+	"kJ":   {dimensionEnergy, 1e3, 0},
+	"MJ":   {dimensionEnergy, 1e6, 0},
+	"Wh":   {dimensionEnergy, 3600, 0},
+	"kWh":  {dimensionEnergy, 3.6e6, 0},
+	"MWh":  {dimensionEnergy, 3.6e9, 0},
+	"cal":  {dimensionEnergy, 4.184, 0},
+	"kcal": {dimensionEnergy, 4184, 0},
+	"BTU":  {dimensionEnergy, 1055.05585262, 0},
+
+	// power, SI unit W
+	"mW": {dimensionPower, 1e-3, 0},
+	"W":  {dimensionPower, 1, 0},
+	"kW": {dimensionPower, 1e3, 0},
+	"MW": {dimensionPower, 1e6, 0},
+	"hp": {dimensionPower, 745.6998715822702, 0},
+
+	// voltage, SI unit V
+	"mV": {dimensionVoltage, 1e-3, 0},
+	"V":  {dimensionVoltage, 1, 0},
+	"kV": {dimensionVoltage, 1e3, 0},
+
+	// current, SI unit A
+	"µA": {dimensionCurrent, 1e-6, 0},
+	"uA": {dimensionCurrent, 1e-6, 0},
+	"mA": {dimensionCurrent, 1e-3, 0},
+	"A":  {dimensionCurrent, 1, 0},
+
+	// frequency, SI unit Hz
+	"Hz":  {dimensionFrequency, 1, 0},
+	"kHz": {dimensionFrequency, 1e3, 0},
+	"MHz": {dimensionFrequency, 1e6, 0},
+	"GHz": {dimensionFrequency, 1e9, 0},
+	"rpm": {dimensionFrequency, 1.0 / 60, 0},
+
+	// time, SI unit s
+	"ms":  {dimensionTime, 1e-3, 0},
+	"s":   {dimensionTime, 1, 0},
+	"min": {dimensionTime, 60, 0},
+	"h":   {dimensionTime, 3600, 0},
+}
+
+// ParseTargetUnits parses the comma-separated target units requested by the ds-units query parameter. At most one
+// unit per dimension is allowed, so each DeviceResource is converted to the unit matching its own dimension.
+func ParseTargetUnits(value string) ([]string, errors.EdgeX) {
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+	var targetUnits []string
+	dimensions := make(map[string]string)
+	for _, name := range strings.Split(value, ",") {
+		name = strings.TrimSpace(name)
+		u, ok := units[name]
+		if !ok {
+			return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("unsupported unit %s", name), nil)
+		}
+		if existing, ok := dimensions[u.dimension]; ok {
+			errMsg := fmt.Sprintf("units %s and %s are both %s units, only one target unit per dimension is allowed", existing, name, u.dimension)
+			return nil, errors.NewCommonEdgeX(errors.KindContractInvalid, errMsg, nil)
+		}
+		dimensions[u.dimension] = name
+		targetUnits = append(targetUnits, name)
+	}
+	return targetUnits, nil
+}
+
+// targetUnitFor returns the unit the DeviceResource should be delivered in. The units requested by the query
+// parameter take precedence over the ones defined in the Device.Properties. Returns an empty string if no conversion
+// is requested.
+func targetUnitFor(device models.Device, dr models.DeviceResource, requested []string) (string, errors.EdgeX) {
+	if from, ok := units[dr.Properties.Units]; ok {
+		for _, name := range requested {
+			if units[name].dimension == from.dimension {
+				return name, nil
+			}
+		}
+	}
+
+	deviceUnits, ok := device.Properties[UnitsKey].(map[string]any)
+	if !ok {
+		return "", nil
+	}
+	target, ok := deviceUnits[dr.Name]
+	if !ok {
+		return "", nil
+	}
+	name, ok := target.(string)
+	if !ok {
+		errMsg := fmt.Sprintf("target unit of DeviceResource %s in device %s must be a string", dr.Name, device.Name)
+		return "", errors.NewCommonEdgeX(errors.KindContractInvalid, errMsg, nil)
+	}
+	return name, nil
+}
+
+// convertUnit converts the value between two units of the same dimension
+func convertUnit(value float64, from, to string) (float64, errors.EdgeX) {
+	fromUnit, ok := units[from]
+	if !ok {
+		return 0, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("unsupported unit %s", from), nil)
+	}
+	toUnit, ok := units[to]
+	if !ok {
+		return 0, errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("unsupported unit %s", to), nil)
+	}
+	if fromUnit.dimension != toUnit.dimension {
+		errMsg := fmt.Sprintf("cannot convert %s unit %s to %s unit %s", fromUnit.dimension, from, toUnit.dimension, to)
+		return 0, errors.NewCommonEdgeX(errors.KindContractInvalid, errMsg, nil)
+	}
+	si := value*fromUnit.factor + fromUnit.offset
+	return roundSignificant((si-toUnit.offset)/toUnit.factor, significantDigits), nil
+}
+
+// roundSignificant rounds the value to the given significant digits to remove the floating-point noise introduced by
+// converting through the SI unit, e.g. 25 °C is converted to 77 °F rather than 76.99999999999993 °F.
+func roundSignificant(value float64, digits int) float64 {
+	if value == 0 || math.IsNaN(value) || math.IsInf(value, 0) {
+		return value
+	}
+	pow := math.Pow(10, float64(digits)-math.Ceil(math.Log10(math.Abs(value))))
+	return math.Round(value*pow) / pow
+}
+
+// transformUnit converts the numeric value, the converted result of integer types is rounded to the nearest integer.
+func transformUnit(value any, from, to string) (any, errors.EdgeX) {
+	valueFloat64, err := convertUnit(toFloat64(value), from, to)
+	if err != nil {
+		return nil, errors.NewCommonEdgeXWrapper(err)
+	}
+	return toOriginType(value, valueFloat64)
+}
+
+// convertReadUnit converts the numeric CommandValue from the unit declared by the DeviceResource to the target unit
+func convertReadUnit(cv *sdkModels.CommandValue, from, to string) errors.EdgeX {
+	if to == "" || to == from {
+		return nil
+	}
+	if isNumericArrayValueType(cv) {
+		result, failed, err := transformArrayValue(cv, func(value any) (any, errors.EdgeX) {
+			return transformUnit(value, from, to)
+		})
+		if err != nil {
+			return errors.NewCommonEdgeXWrapper(err)
+		}
+		cv.Value = result
+		if len(failed.Elements) > 0 {
+			errMsg := fmt.Sprintf("unit conversion error for DeviceResource %s", cv.DeviceResourceName)
+			return errors.NewCommonEdgeX(failed.kind(), errMsg, failed)
+		}
+		return nil
+	}
+	if !isNumericValueType(cv) {
+		errMsg := fmt.Sprintf("cannot convert the unit of DeviceResource %s with non-numeric ValueType %s", cv.DeviceResourceName, cv.Type)
+		return errors.NewCommonEdgeX(errors.KindContractInvalid, errMsg, nil)
+	}
+
+	value, err := commandValueForTransform(cv)
+	if err != nil {
+		return errors.NewCommonEdgeXWrapper(err)
+	}
+	newValue, err := transformUnit(value, from, to)
+	if err != nil {
+		return errors.NewCommonEdgeX(errors.Kind(err), fmt.Sprintf("unit conversion error for DeviceResource %s", cv.DeviceResourceName), err)
+	}
+	cv.Value = newValue
+	return nil
+}
@@ -0,0 +1,158 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package transformer
+
+import (
+	goErrors "errors"
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v4/pkg/models"
+)
+
+func TestParseTargetUnits(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       string
+		expected    []string
+		expectedErr bool
+	}{
+		{"valid - empty", "", nil, false},
+		{"valid - single unit", "degF", []string{"degF"}, false},
+		{"valid - multiple dimensions", "degF, kPa,L/min", []string{"degF", "kPa", "L/min"}, false},
+		{"invalid - unknown unit", "furlong", nil, true},
+		{"invalid - same dimension", "degF,K", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := ParseTargetUnits(tt.value)
+			if tt.expectedErr {
+				require.Error(t, err)
+				assert.Equal(t, errors.KindContractInvalid, errors.Kind(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, res)
+		})
+	}
+}
+
+func Test_convertUnit(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       float64
+		from        string
+		to          string
+		expected    float64
+		expectedErr bool
+	}{
+		{"valid - celsius to fahrenheit", 100, "°C", "degF", 212, false},
+		{"valid - fahrenheit to celsius", -40, "F", "C", -40, false},
+		{"valid - celsius to kelvin", 0, "degC", "K", 273.15, false},
+		{"valid - psi to kPa", 1, "psi", "kPa", 6.894757293168361, false},
+		{"valid - bar to psi", 1, "bar", "psi", 14.503773773020923, false},
+		{"valid - gpm to L/min", 1, "gpm", "L/min", 3.785411784, false},
+		{"valid - kWh to J", 1, "kWh", "J", 3.6e6, false},
+		{"valid - rpm to Hz", 120, "rpm", "Hz", 2, false},
+		{"invalid - unknown unit", 1, "furlong", "m", 0, true},
+		{"invalid - different dimension", 1, "kPa", "degF", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := convertUnit(tt.value, tt.from, tt.to)
+			if tt.expectedErr {
+				require.Error(t, err)
+				assert.Equal(t, errors.KindContractInvalid, errors.Kind(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.InDelta(t, tt.expected, res, 1e-9)
+		})
+	}
+}
+
+func Test_targetUnitFor(t *testing.T) {
+	dr := models.DeviceResource{Name: TestDeviceResource, Properties: models.ResourceProperties{Units: "degC"}}
+	device := models.Device{Name: TestDevice, Properties: map[string]any{UnitsKey: map[string]any{TestDeviceResource: "K"}}}
+
+	tests := []struct {
+		name        string
+		device      models.Device
+		requested   []string
+		expected    string
+		expectedErr bool
+	}{
+		{"valid - no target unit", models.Device{}, nil, "", false},
+		{"valid - device target unit", device, nil, "K", false},
+		{"valid - requested unit overrides device", device, []string{"kPa", "degF"}, "degF", false},
+		{"valid - requested unit of other dimension ignored", device, []string{"kPa"}, "K", false},
+		{"invalid - device target unit not string", models.Device{Properties: map[string]any{UnitsKey: map[string]any{TestDeviceResource: 1}}}, nil, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := targetUnitFor(tt.device, dr, tt.requested)
+			if tt.expectedErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, res)
+		})
+	}
+}
+
+func Test_convertReadUnit(t *testing.T) {
+	tests := []struct {
+		name         string
+		valueType    string
+		value        any
+		from         string
+		to           string
+		expected     any
+		expectedKind errors.ErrKind
+	}{
+		{"valid - float64", common.ValueTypeFloat64, float64(25), "degC", "degF", float64(77), ""},
+		{"valid - int16 rounded", common.ValueTypeInt16, int16(21), "degC", "degF", int16(70), ""},
+		{"valid - no target unit", common.ValueTypeFloat32, float32(1.5), "kPa", "", float32(1.5), ""},
+		{"valid - float32 array", common.ValueTypeFloat32Array, []float32{0, 250}, "kPa", "bar", []float32{0, 2.5}, ""},
+		{"invalid - uint8 overflow", common.ValueTypeUint8, uint8(200), "degC", "degF", uint8(200), errors.KindOverflowError},
+		{"invalid - non-numeric", common.ValueTypeString, "warm", "degC", "degF", "warm", errors.KindContractInvalid},
+		{"invalid - incompatible units", common.ValueTypeFloat64, float64(1), "degC", "kPa", float64(1), errors.KindContractInvalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cv, e := sdkModels.NewCommandValue(TestDeviceResource, tt.valueType, tt.value)
+			require.NoError(t, e)
+
+			err := convertReadUnit(cv, tt.from, tt.to)
+			if tt.expectedKind != "" {
+				require.Error(t, err)
+				assert.Equal(t, tt.expectedKind, errors.Kind(err))
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.expected, cv.Value)
+		})
+	}
+}
+
+func Test_convertReadUnit_arrayOverflow(t *testing.T) {
+	cv, e := sdkModels.NewCommandValue(TestDeviceResource, common.ValueTypeUint8Array, []uint8{20, 200})
+	require.NoError(t, e)
+
+	err := convertReadUnit(cv, "degC", "degF")
+	require.Error(t, err)
+	var elementsErr ArrayElementsError
+	require.True(t, goErrors.As(err, &elementsErr))
+	assert.Equal(t, map[int]errors.ErrKind{1: errors.KindOverflowError}, elementsErr.Elements)
+	assert.Equal(t, []uint8{68, 200}, cv.Value)
+}
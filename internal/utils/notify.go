@@ -1,5 +1,5 @@
 //
-// Copyright (C) 2024-2025 IOTech Ltd
+// Copyright (C) 2024-2026 IOTech Ltd
 //
 // SPDX-License-Identifier: Apache-2.0
 
@@ -8,6 +8,7 @@ package utils
 import (
 	"context"
 
+	sdkCommon "github.com/edgexfoundry/device-sdk-go/v4/internal/common"
 	"github.com/edgexfoundry/device-sdk-go/v4/internal/container"
 	sdkModels "github.com/edgexfoundry/device-sdk-go/v4/pkg/models"
 	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v4/bootstrap/container"
@@ -31,6 +32,25 @@ func PublishProfileScanProgressSystemEvent(id string, progress int, message stri
 	PublishGenericSystemEvent(common.DeviceSystemEventType, common.SystemEventActionProfileScan, details, ctx, dic)
 }
 
+func PublishAssertionAlarmSystemEvent(deviceName, resourceName, assertion, value string, ctx context.Context, dic *di.Container) {
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	lc.Debugf("Publishing assertion alarm system event. Device: %s, DeviceResource: %s", deviceName, resourceName)
+	details := sdkModels.AssertionAlarm{DeviceName: deviceName, ResourceName: resourceName, Assertion: assertion, Value: value}
+	PublishGenericSystemEvent(common.DeviceSystemEventType, sdkCommon.SystemEventActionAssertion, details, ctx, dic)
+}
+
+func PublishPendingApprovalSystemEvent(details sdkModels.PendingApproval, ctx context.Context, dic *di.Container) {
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	lc.Debugf("Publishing pending approval system event. Device: %s, ProvisionWatcher: %s", details.DeviceName, details.ProvisionWatcher)
+	PublishGenericSystemEvent(common.DeviceSystemEventType, sdkCommon.SystemEventActionPendingApproval, details, ctx, dic)
+}
+
+func PublishStaleDeviceSystemEvent(details sdkModels.StaleDevice, ctx context.Context, dic *di.Container) {
+	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
+	lc.Debugf("Publishing stale device system event. Device: %s, ProvisionWatcher: %s", details.DeviceName, details.ProvisionWatcher)
+	PublishGenericSystemEvent(common.DeviceSystemEventType, sdkCommon.SystemEventActionStale, details, ctx, dic)
+}
+
 func PublishGenericSystemEvent(eventType, action string, details any, ctx context.Context, dic *di.Container) {
 	lc := bootstrapContainer.LoggingClientFrom(dic.Get)
 	config := container.ConfigurationFrom(dic.Get)
@@ -321,6 +321,106 @@ func (_m *DeviceServiceSDK) Devices() []models.Device {
 	return r0
 }
 
+// DevicesByAdminState provides a mock function with given fields: state
+func (_m *DeviceServiceSDK) DevicesByAdminState(state models.AdminState) []models.Device {
+	ret := _m.Called(state)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DevicesByAdminState")
+	}
+
+	var r0 []models.Device
+	if rf, ok := ret.Get(0).(func(models.AdminState) []models.Device); ok {
+		r0 = rf(state)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Device)
+		}
+	}
+
+	return r0
+}
+
+// DevicesByLabel provides a mock function with given fields: label
+func (_m *DeviceServiceSDK) DevicesByLabel(label string) []models.Device {
+	ret := _m.Called(label)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DevicesByLabel")
+	}
+
+	var r0 []models.Device
+	if rf, ok := ret.Get(0).(func(string) []models.Device); ok {
+		r0 = rf(label)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Device)
+		}
+	}
+
+	return r0
+}
+
+// DevicesByOperatingState provides a mock function with given fields: state
+func (_m *DeviceServiceSDK) DevicesByOperatingState(state models.OperatingState) []models.Device {
+	ret := _m.Called(state)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DevicesByOperatingState")
+	}
+
+	var r0 []models.Device
+	if rf, ok := ret.Get(0).(func(models.OperatingState) []models.Device); ok {
+		r0 = rf(state)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Device)
+		}
+	}
+
+	return r0
+}
+
+// DevicesByProfileName provides a mock function with given fields: profileName
+func (_m *DeviceServiceSDK) DevicesByProfileName(profileName string) []models.Device {
+	ret := _m.Called(profileName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DevicesByProfileName")
+	}
+
+	var r0 []models.Device
+	if rf, ok := ret.Get(0).(func(string) []models.Device); ok {
+		r0 = rf(profileName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Device)
+		}
+	}
+
+	return r0
+}
+
+// DevicesByProtocolProperty provides a mock function with given fields: protocol, property, value
+func (_m *DeviceServiceSDK) DevicesByProtocolProperty(protocol string, property string, value string) []models.Device {
+	ret := _m.Called(protocol, property, value)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DevicesByProtocolProperty")
+	}
+
+	var r0 []models.Device
+	if rf, ok := ret.Get(0).(func(string, string, string) []models.Device); ok {
+		r0 = rf(protocol, property, value)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Device)
+		}
+	}
+
+	return r0
+}
+
 // DiscoveredDeviceChannel provides a mock function with given fields:
 func (_m *DeviceServiceSDK) DiscoveredDeviceChannel() chan []pkgmodels.DiscoveredDevice {
 	ret := _m.Called()
@@ -592,6 +692,24 @@ func (_m *DeviceServiceSDK) PublishProfileScanProgressSystemEvent(reqId string,
 	_m.Called(reqId, progress, message)
 }
 
+// RegisterTransformer provides a mock function with given fields: name, transformer
+func (_m *DeviceServiceSDK) RegisterTransformer(name string, transformer pkgmodels.Transformer) error {
+	ret := _m.Called(name, transformer)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RegisterTransformer")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, pkgmodels.Transformer) error); ok {
+		r0 = rf(name, transformer)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // RemoveDeviceAutoEvent provides a mock function with given fields: deviceName, event
 func (_m *DeviceServiceSDK) RemoveDeviceAutoEvent(deviceName string, event models.AutoEvent) error {
 	ret := _m.Called(deviceName, event)
@@ -792,6 +910,66 @@ func (_m *DeviceServiceSDK) Version() string {
 	return r0
 }
 
+// WatchDeviceProfiles provides a mock function with given fields: handler
+func (_m *DeviceServiceSDK) WatchDeviceProfiles(handler func(pkgmodels.DeviceProfileChange)) func() {
+	ret := _m.Called(handler)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WatchDeviceProfiles")
+	}
+
+	var r0 func()
+	if rf, ok := ret.Get(0).(func(func(pkgmodels.DeviceProfileChange)) func()); ok {
+		r0 = rf(handler)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(func())
+		}
+	}
+
+	return r0
+}
+
+// WatchDevices provides a mock function with given fields: handler
+func (_m *DeviceServiceSDK) WatchDevices(handler func(pkgmodels.DeviceChange)) func() {
+	ret := _m.Called(handler)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WatchDevices")
+	}
+
+	var r0 func()
+	if rf, ok := ret.Get(0).(func(func(pkgmodels.DeviceChange)) func()); ok {
+		r0 = rf(handler)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(func())
+		}
+	}
+
+	return r0
+}
+
+// WatchProvisionWatchers provides a mock function with given fields: handler
+func (_m *DeviceServiceSDK) WatchProvisionWatchers(handler func(pkgmodels.ProvisionWatcherChange)) func() {
+	ret := _m.Called(handler)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WatchProvisionWatchers")
+	}
+
+	var r0 func()
+	if rf, ok := ret.Get(0).(func(func(pkgmodels.ProvisionWatcherChange)) func()); ok {
+		r0 = rf(handler)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(func())
+		}
+	}
+
+	return r0
+}
+
 // NewDeviceServiceSDK creates a new instance of DeviceServiceSDK. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewDeviceServiceSDK(t interface {
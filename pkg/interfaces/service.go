@@ -43,6 +43,17 @@ type DeviceServiceSDK interface {
 	AddDevice(device models.Device) (string, error)
 	// Devices return all managed Devices from cache
 	Devices() []models.Device
+	// DevicesByLabel returns the managed Devices with the given label from cache, sorted by name.
+	DevicesByLabel(label string) []models.Device
+	// DevicesByProfileName returns the managed Devices associated with the given profile from cache, sorted by name.
+	DevicesByProfileName(profileName string) []models.Device
+	// DevicesByAdminState returns the managed Devices in the given admin state from cache, sorted by name.
+	DevicesByAdminState(state models.AdminState) []models.Device
+	// DevicesByOperatingState returns the managed Devices in the given operating state from cache, sorted by name.
+	DevicesByOperatingState(state models.OperatingState) []models.Device
+	// DevicesByProtocolProperty returns the managed Devices whose protocol property has the given value from cache,
+	// sorted by name. The values are compared in their string form, and the empty protocol matches any protocol.
+	DevicesByProtocolProperty(protocol string, property string, value string) []models.Device
 	// GetDeviceByName returns the Device by its name if it exists in the cache, or returns an error.
 	GetDeviceByName(name string) (models.Device, error)
 	// UpdateDevice updates the Device in the cache and ensures that the
@@ -154,6 +165,26 @@ type DeviceServiceSDK interface {
 
 	// PublishGenericSystemEvent publishes a generic system event through the EdgeX message bus
 	PublishGenericSystemEvent(eventType, action string, details any)
+
+	// RegisterTransformer registers a custom data transformer with the given name, the DeviceResource references it in
+	// the preTransformers or postTransformers attribute to run it before or after the built-in data transformations.
+	// Returns error if the name is empty or already registered.
+	RegisterTransformer(name string, transformer sdkModels.Transformer) error
+
+	// WatchDevices subscribes the handler to the changes of the managed Devices in cache, each change carries the
+	// Device before and after the change, so the driver can react to the changes made through any path. The handler is
+	// called asynchronously from its own goroutine, with the changes in the order they are applied to the cache, and it
+	// may call the SDK to change the Devices. Returns the function to cancel the subscription.
+	WatchDevices(handler func(sdkModels.DeviceChange)) func()
+	// WatchDeviceProfiles subscribes the handler to the changes of the DeviceProfiles in cache, e.g. to rebuild the
+	// register maps of the devices when their profile changes. The handler is called asynchronously from its own
+	// goroutine, with the changes in the order they are applied to the cache. Returns the function to cancel the
+	// subscription.
+	WatchDeviceProfiles(handler func(sdkModels.DeviceProfileChange)) func()
+	// WatchProvisionWatchers subscribes the handler to the changes of the ProvisionWatchers in cache. The handler is
+	// called asynchronously from its own goroutine, with the changes in the order they are applied to the cache.
+	// Returns the function to cancel the subscription.
+	WatchProvisionWatchers(handler func(sdkModels.ProvisionWatcherChange)) func()
 }
 
 // DeviceServiceSDKExt extends DeviceServiceSDK with additional methods that bypass device validation.
@@ -0,0 +1,44 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+import (
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
+)
+
+// CacheChangeAction is the kind of change made to the device service caches
+type CacheChangeAction string
+
+const (
+	CacheChangeAdd    CacheChangeAction = "add"
+	CacheChangeUpdate CacheChangeAction = "update"
+	CacheChangeRemove CacheChangeAction = "remove"
+)
+
+// DeviceChange is the notification of a Device added to, updated in or removed from the device cache. Before is nil
+// for the added Device and After is nil for the removed Device.
+type DeviceChange struct {
+	Action CacheChangeAction
+	Before *models.Device
+	After  *models.Device
+}
+
+// DeviceProfileChange is the notification of a DeviceProfile added to, updated in or removed from the profile cache.
+// Before is nil for the added DeviceProfile and After is nil for the removed DeviceProfile.
+type DeviceProfileChange struct {
+	Action CacheChangeAction
+	Before *models.DeviceProfile
+	After  *models.DeviceProfile
+}
+
+// ProvisionWatcherChange is the notification of a ProvisionWatcher added to, updated in or removed from the provision
+// watcher cache. Before is nil for the added ProvisionWatcher and After is nil for the removed ProvisionWatcher.
+type ProvisionWatcherChange struct {
+	Action CacheChangeAction
+	Before *models.ProvisionWatcher
+	After  *models.ProvisionWatcher
+}
@@ -1,7 +1,7 @@
 // -*- Mode: Go; indent-tabs-mode: t -*-
 //
 // Copyright (C) 2018 Canonical Ltd
-// Copyright (C) 2018-2025 IOTech Ltd
+// Copyright (C) 2018-2026 IOTech Ltd
 //
 // SPDX-License-Identifier: Apache-2.0
 
@@ -21,6 +21,26 @@ const (
 	MaxBinaryBytes = 16777216
 )
 
+const (
+	// QualityGood indicates the value is reliable
+	QualityGood = "good"
+	// QualityUncertain indicates the value may be unreliable, e.g. a stale value
+	QualityUncertain = "uncertain"
+	// QualityBad indicates the value is not usable, e.g. the sensor is out of range or the transformation overflowed
+	QualityBad = "bad"
+
+	// QualityTag is the standard reading tag carrying the Quality of the CommandValue
+	QualityTag = "quality"
+	// QualityReasonTag is the standard reading tag carrying the reason why the SDK downgraded the Quality
+	QualityReasonTag = "qualityReason"
+)
+
+var qualityLevels = map[string]int{
+	QualityGood:      0,
+	QualityUncertain: 1,
+	QualityBad:       2,
+}
+
 // CommandValue is the struct to represent the reading value of a Get command coming
 // from ProtocolDrivers or the parameter of a Put command sending to ProtocolDrivers.
 type CommandValue struct {
@@ -39,6 +59,11 @@ type CommandValue struct {
 	// Tags allows device service to add custom information to the Event in order to
 	// help identify its origin or otherwise label it before it is send to north side.
 	Tags map[string]string
+	// Quality flags the reliability of the value, i.e. QualityGood, QualityUncertain or QualityBad. It can be set by
+	// the ProtocolDriver and downgraded by the SDK, e.g. to QualityBad when the value is outside the Minimum and
+	// Maximum of the DeviceResource, the value is carried into the reading by the QualityTag. Empty means the quality
+	// is not specified, an unknown quality is replaced by QualityBad.
+	Quality string
 }
 
 // NewCommandValue create a CommandValue according to the valueType supplied.
@@ -66,6 +91,27 @@ func NewCommandValueWithOrigin(deviceResourceName string, valueType string, valu
 	return cv, nil
 }
 
+// IsValidQuality reports whether the quality is one of QualityGood, QualityUncertain or QualityBad, or is unspecified
+func IsValidQuality(quality string) bool {
+	if quality == "" {
+		return true
+	}
+	_, ok := qualityLevels[quality]
+	return ok
+}
+
+// DowngradeQuality sets the Quality of the CommandValue if the given quality is worse than the current one,
+// an unspecified Quality is considered as QualityGood.
+func (cv *CommandValue) DowngradeQuality(quality string) {
+	level, ok := qualityLevels[quality]
+	if !ok {
+		return
+	}
+	if cv.Quality == "" || level > qualityLevels[cv.Quality] {
+		cv.Quality = quality
+	}
+}
+
 // ValueToString returns the string format of the value.
 func (cv *CommandValue) ValueToString() string {
 	if cv.Type == common.ValueTypeBinary {
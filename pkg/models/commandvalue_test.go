@@ -34,6 +34,39 @@ func TestNewCommandValueWithOrigin(t *testing.T) {
 	assert.Equal(t, cv.Origin, origin)
 }
 
+func TestCommandValue_DowngradeQuality(t *testing.T) {
+	tests := []struct {
+		name     string
+		current  string
+		quality  string
+		expected string
+	}{
+		{"unspecified to good", "", QualityGood, QualityGood},
+		{"unspecified to bad", "", QualityBad, QualityBad},
+		{"good to uncertain", QualityGood, QualityUncertain, QualityUncertain},
+		{"uncertain to bad", QualityUncertain, QualityBad, QualityBad},
+		{"bad not upgraded to good", QualityBad, QualityGood, QualityBad},
+		{"uncertain not upgraded to good", QualityUncertain, QualityGood, QualityUncertain},
+		{"unknown quality ignored", QualityGood, "unknown", QualityGood},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cv := &CommandValue{Quality: tt.current}
+			cv.DowngradeQuality(tt.quality)
+			assert.Equal(t, tt.expected, cv.Quality)
+		})
+	}
+}
+
+func TestIsValidQuality(t *testing.T) {
+	assert.True(t, IsValidQuality(""))
+	assert.True(t, IsValidQuality(QualityGood))
+	assert.True(t, IsValidQuality(QualityUncertain))
+	assert.True(t, IsValidQuality(QualityBad))
+	assert.False(t, IsValidQuality("Good"))
+	assert.False(t, IsValidQuality("unknown"))
+}
+
 func Test_validate(t *testing.T) {
 	exceedBinary := make([]byte, MaxBinaryBytes+1)
 	_, err := rand.Read(exceedBinary)
@@ -1,5 +1,5 @@
 //
-// Copyright (C) 2024 IOTech Ltd
+// Copyright (C) 2024-2026 IOTech Ltd
 //
 // SPDX-License-Identifier: Apache-2.0
 
@@ -14,3 +14,28 @@ type DeviceDiscoveryProgress struct {
 	Progress              `json:",inline"`
 	DiscoveredDeviceCount int `json:"discoveredDeviceCount,omitempty"`
 }
+
+// AssertionAlarm is the details of the System Event published when the reading of a DeviceResource fails its assertion
+type AssertionAlarm struct {
+	DeviceName   string `json:"deviceName"`
+	ResourceName string `json:"resourceName"`
+	Assertion    string `json:"assertion"`
+	Value        string `json:"value"`
+}
+
+// PendingApproval is the details of the System Event published when a discovered device is put in the pending queue
+type PendingApproval struct {
+	Id               string `json:"id"`
+	DeviceName       string `json:"deviceName"`
+	ProvisionWatcher string `json:"provisionWatcher"`
+	ProfileName      string `json:"profileName,omitempty"`
+}
+
+// StaleDevice is the details of the System Event published when a discovered device is not seen by the device discovery
+// any more, LastSeen is the time in milliseconds when it was last discovered
+type StaleDevice struct {
+	DeviceName       string `json:"deviceName"`
+	ProvisionWatcher string `json:"provisionWatcher"`
+	LastSeen         int64  `json:"lastSeen"`
+	MissedRuns       int    `json:"missedRuns"`
+}
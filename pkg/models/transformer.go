@@ -0,0 +1,21 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package models
+
+// TransformFunc transforms the CommandValue in place, both the Value and the Type may be changed. The attributes are
+// the attributes of the DeviceResource, so the transformation can be parameterized in the device profile.
+type TransformFunc func(cv *CommandValue, attributes map[string]any) error
+
+// Transformer is a custom data transformation registered with the device service and referenced by name in the
+// DeviceResource attributes, e.g. a BCD or a register pair decoding.
+type Transformer struct {
+	// Read transforms the value read from the device, nil if the transformer does not apply to the reading
+	Read TransformFunc
+	// Write transforms the value to be written to the device, it is expected to be the inverse of Read, nil if the
+	// transformer does not apply to the set command
+	Write TransformFunc
+}
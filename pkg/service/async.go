@@ -9,16 +9,12 @@ package service
 
 import (
 	"context"
-	"fmt"
-	"regexp"
 
-	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v4/bootstrap/container"
 	"github.com/edgexfoundry/go-mod-bootstrap/v4/di"
-	contractsCommon "github.com/edgexfoundry/go-mod-core-contracts/v4/common"
 	"github.com/edgexfoundry/go-mod-core-contracts/v4/dtos"
-	"github.com/edgexfoundry/go-mod-core-contracts/v4/dtos/requests"
 	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
 
+	"github.com/edgexfoundry/device-sdk-go/v4/internal/autodiscovery"
 	"github.com/edgexfoundry/device-sdk-go/v4/internal/cache"
 	"github.com/edgexfoundry/device-sdk-go/v4/internal/common"
 	"github.com/edgexfoundry/device-sdk-go/v4/internal/container"
@@ -72,7 +68,7 @@ func (s *deviceService) sendAsyncValues(acv *sdkModels.AsyncValues, working chan
 	}
 
 	configuration := container.ConfigurationFrom(dic.Get)
-	event, err := transformer.CommandValuesToEventDTO(acv.CommandValues, acv.DeviceName, acv.SourceName, configuration.Device.DataTransform, dic)
+	event, err := transformer.CommandValuesToEventDTO(acv.CommandValues, acv.DeviceName, acv.SourceName, configuration.Device.DataTransform, nil, dic)
 	if err != nil {
 		s.lc.Errorf("failed to transform CommandValues to Event: %v", err)
 		return
@@ -91,94 +87,128 @@ func (s *deviceService) processAsyncFilterAndAdd(ctx context.Context) {
 		case devices := <-s.deviceCh:
 			ctx := context.Background()
 			pws := cache.ProvisionWatchers().All()
+			candidates := make([]autodiscovery.Candidate, 0, len(devices))
 			for _, d := range devices {
-				for _, pw := range pws {
-					if pw.AdminState == models.Locked {
-						s.lc.Debugf("Skip th locked provision watcher %v", pw.Name)
-						continue
-					}
-					if s.checkAllowList(d, pw) && s.checkBlockList(d, pw) {
-						if _, ok := cache.Devices().ForName(d.Name); ok {
-							s.lc.Debugf("Candidate discovered device %s already existed", d.Name)
-							break
-						}
-
-						s.lc.Infof("Adding discovered device %s to Metadata", d.Name)
-						device := models.Device{
-							Name:           d.Name,
-							Description:    d.Description,
-							ProfileName:    pw.DiscoveredDevice.ProfileName,
-							Protocols:      d.Protocols,
-							Labels:         d.Labels,
-							ServiceName:    s.serviceKey,
-							AdminState:     pw.DiscoveredDevice.AdminState,
-							OperatingState: models.Up,
-							AutoEvents:     pw.DiscoveredDevice.AutoEvents,
-							Properties:     pw.DiscoveredDevice.Properties,
-						}
-
-						req := requests.NewAddDeviceRequest(dtos.FromDeviceModelToDTO(device))
-						_, err := bootstrapContainer.DeviceClientFrom(s.dic.Get).AddWithQueryParams(ctx, []requests.AddDeviceRequest{req},
-							map[string]string{common.BypassValidationQueryParam: contractsCommon.ValueTrue})
-						if err != nil {
-							s.lc.Errorf("failed to create discovered device %s: %v", device.Name, err)
-						} else {
-							break
-						}
-					}
-				}
+				candidates = append(candidates, s.filterAndAdd(ctx, d, pws))
 			}
+			autodiscovery.RecordCandidates(container.DiscoveryRequestIdFrom(s.dic.Get), candidates)
 			s.lc.Debug("Filtered device addition finished")
 		}
 	}
 }
 
-func (s *deviceService) checkAllowList(d sdkModels.DiscoveredDevice, pw models.ProvisionWatcher) bool {
-	// ignore the device protocol properties name
-	for _, protocol := range d.Protocols {
-		matchedCount := 0
-		for name, regex := range pw.Identifiers {
-			if value, ok := protocol[name]; ok {
-				valueString := fmt.Sprintf("%v", value)
-				if valueString == "" {
-					s.lc.Debugf("Skipping identifier %s, cannot transform %s value '%v' to string type for discovered device %s", name, name, value, d.Name)
-					continue
+// filterAndAdd evaluates the provision watchers against the discovered device in order and adds the device with the
+// first matched one, or puts it in the pending queue if the provision watcher requires approval. The evaluations and
+// the resulting action are returned as the candidate
+func (s *deviceService) filterAndAdd(ctx context.Context, d sdkModels.DiscoveredDevice, pws []models.ProvisionWatcher) autodiscovery.Candidate {
+	candidate := autodiscovery.Candidate{
+		Name:        d.Name,
+		Description: d.Description,
+		Labels:      d.Labels,
+		Protocols:   d.Protocols,
+		Action:      autodiscovery.CandidateActionNoMatch,
+	}
+	var addFailure string
+	for _, pw := range pws {
+		evaluation := autodiscovery.EvaluateProvisionWatcher(d, pw)
+		candidate.Evaluations = append(candidate.Evaluations, evaluation)
+		switch evaluation.Result {
+		case autodiscovery.WatcherResultLocked:
+			s.lc.Debugf("Skip th locked provision watcher %v", pw.Name)
+			continue
+		case autodiscovery.WatcherResultNotMatched:
+			for _, m := range evaluation.Mismatches {
+				s.lc.Debugf("Discovered Device %s %s %s '%s' did not match PW %s identifier: %s (%s)", d.Name, m.Protocol, m.Identifier, m.Value, pw.Name, m.Pattern, m.Reason)
+			}
+			continue
+		case autodiscovery.WatcherResultBlocked:
+			s.lc.Debugf("Discovered Device %s %s value cannot be %v", d.Name, evaluation.Blocked.Identifier, evaluation.Blocked.Value)
+			continue
+		}
+
+		candidate.ProvisionWatcher = pw.Name
+		name := d.Name
+		if key, ok := autodiscovery.IdentityKey(pw); ok {
+			if value, ok := autodiscovery.IdentityValue(d.Protocols, key); ok {
+				if existing, ok := autodiscovery.FindDeviceByIdentity(key, value); ok {
+					return s.updateRediscovered(ctx, candidate, existing, d, pw)
 				}
-				matched, err := regexp.MatchString(regex, valueString)
-				if !matched || err != nil {
-					s.lc.Debugf("Discovered Device %s %s value '%v' did not match PW identifier: %s", d.Name, name, value, regex)
-					break
+				name = autodiscovery.StableDeviceName(pw.DiscoveredDevice.ProfileName, value)
+				if name != d.Name {
+					candidate.DeviceName = name
 				}
-				matchedCount += 1
+			} else {
+				s.lc.Debugf("Discovered device %s has no identity key %s, matching it by name", d.Name, key)
 			}
 		}
-		// match succeed on all identifiers
-		if matchedCount == len(pw.Identifiers) {
-			return true
+		if _, ok := cache.Devices().ForName(name); ok {
+			s.lc.Debugf("Candidate discovered device %s already existed", name)
+			autodiscovery.MarkDeviceSeen(ctx, name, pw, s.dic)
+			candidate.Action = autodiscovery.CandidateActionAlreadyExists
+			return candidate
 		}
-	}
-	return false
-}
 
-func (s *deviceService) checkBlockList(d sdkModels.DiscoveredDevice, pw models.ProvisionWatcher) bool {
-	// a candidate should match none of the blocking identifiers
-	for name, blacklist := range pw.BlockingIdentifiers {
-		// ignore the device protocol properties name
-		for _, protocol := range d.Protocols {
-			if value, ok := protocol[name]; ok {
-				valueString := fmt.Sprintf("%v", value)
-				if valueString == "" {
-					s.lc.Debugf("Skipping identifier %s, cannot transform %s value '%v' to string type for discovered device %s", name, name, value, d.Name)
-					continue
-				}
-				for _, v := range blacklist {
-					if valueString == v {
-						s.lc.Debugf("Discovered Device %s %s value cannot be %v", d.Name, name, value)
-						return false
-					}
-				}
+		device := models.Device{
+			Name:           name,
+			Description:    d.Description,
+			ProfileName:    pw.DiscoveredDevice.ProfileName,
+			Protocols:      d.Protocols,
+			Labels:         d.Labels,
+			ServiceName:    s.serviceKey,
+			AdminState:     pw.DiscoveredDevice.AdminState,
+			OperatingState: models.Up,
+			AutoEvents:     pw.DiscoveredDevice.AutoEvents,
+			Properties:     pw.DiscoveredDevice.Properties,
+		}
+
+		if autodiscovery.RequiresApproval(pw, s.dic) {
+			if _, ok := autodiscovery.EnqueuePendingDevice(ctx, device, pw.Name, s.dic); !ok {
+				s.lc.Debugf("Skip the rejected discovered device %s", d.Name)
+				candidate.Action = autodiscovery.CandidateActionRejected
+				return candidate
 			}
+			candidate.Action = autodiscovery.CandidateActionPendingApproval
+			return candidate
+		}
+
+		s.lc.Infof("Adding discovered device %s to Metadata", device.Name)
+		err := autodiscovery.AddDiscoveredDevice(ctx, device, s.dic)
+		if err != nil {
+			s.lc.Errorf("failed to create discovered device %s: %v", device.Name, err)
+			// the next matched provision watcher is tried, the failure is only reported if none of them succeeds
+			candidate.Action = autodiscovery.CandidateActionAddFailed
+			addFailure = err.Error()
+			continue
 		}
+		autodiscovery.MarkDeviceSeen(ctx, name, pw, s.dic)
+		candidate.Action = autodiscovery.CandidateActionAdded
+		return candidate
+	}
+	candidate.Message = addFailure
+	return candidate
+}
+
+// updateRediscovered patches the protocols of the existing device matched by the identity of the discovered device,
+// as its address may have changed since it was added, e.g. by a DHCP renewal
+func (s *deviceService) updateRediscovered(ctx context.Context, candidate autodiscovery.Candidate, existing models.Device, d sdkModels.DiscoveredDevice, pw models.ProvisionWatcher) autodiscovery.Candidate {
+	candidate.DeviceName = existing.Name
+	autodiscovery.MarkDeviceSeen(ctx, existing.Name, pw, s.dic)
+	if !autodiscovery.ProtocolsChanged(existing.Protocols, d.Protocols) {
+		s.lc.Debugf("Candidate discovered device %s already existed as %s", d.Name, existing.Name)
+		candidate.Action = autodiscovery.CandidateActionAlreadyExists
+		return candidate
+	}
+
+	s.lc.Infof("Updating the protocols of device %s rediscovered as %s", existing.Name, d.Name)
+	err := s.PatchDeviceWithoutValidation(dtos.UpdateDevice{
+		Name:      &existing.Name,
+		Protocols: dtos.FromProtocolModelsToDTOs(d.Protocols),
+	})
+	if err != nil {
+		candidate.Action = autodiscovery.CandidateActionUpdateFailed
+		candidate.Message = err.Error()
+		return candidate
 	}
-	return true
+	candidate.Action = autodiscovery.CandidateActionUpdated
+	return candidate
 }
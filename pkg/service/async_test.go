@@ -7,35 +7,34 @@ package service
 
 import (
 	"context"
+	"net/http"
 	"testing"
 	"time"
 
 	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v4/bootstrap/container"
+	bootstrapMocks "github.com/edgexfoundry/go-mod-bootstrap/v4/bootstrap/interfaces/mocks"
 	"github.com/edgexfoundry/go-mod-bootstrap/v4/di"
 	clientMocks "github.com/edgexfoundry/go-mod-core-contracts/v4/clients/interfaces/mocks"
 	"github.com/edgexfoundry/go-mod-core-contracts/v4/clients/logger"
 	contractsCommon "github.com/edgexfoundry/go-mod-core-contracts/v4/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/dtos"
+	dtoCommon "github.com/edgexfoundry/go-mod-core-contracts/v4/dtos/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/dtos/requests"
 	"github.com/edgexfoundry/go-mod-core-contracts/v4/dtos/responses"
 	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	"github.com/edgexfoundry/device-sdk-go/v4/internal/autodiscovery"
 	"github.com/edgexfoundry/device-sdk-go/v4/internal/cache"
 	internalCommon "github.com/edgexfoundry/device-sdk-go/v4/internal/common"
+	"github.com/edgexfoundry/device-sdk-go/v4/internal/config"
+	"github.com/edgexfoundry/device-sdk-go/v4/internal/container"
 	sdkModels "github.com/edgexfoundry/device-sdk-go/v4/pkg/models"
 )
 
-var d = sdkModels.DiscoveredDevice{
-	Name: "device-sdk-test",
-}
-
-func newDeviceService() *deviceService {
-	return &deviceService{
-		serviceKey: "test-service",
-		lc:         logger.NewMockClient(),
-	}
-}
+var addedResponse = []dtoCommon.BaseWithIdResponse{{BaseResponse: dtoCommon.NewBaseResponse("", "", http.StatusCreated)}}
 
 func Test_processAsyncFilterAndAdd_bypassValidation(t *testing.T) {
 	const testServiceKey = "test-service"
@@ -59,7 +58,7 @@ func Test_processAsyncFilterAndAdd_bypassValidation(t *testing.T) {
 		mock.Anything,
 		mock.Anything,
 		map[string]string{internalCommon.BypassValidationQueryParam: contractsCommon.ValueTrue},
-	).Return(nil, nil).Run(func(mock.Arguments) { addCalled <- struct{}{} })
+	).Return(addedResponse, nil).Run(func(mock.Arguments) { addCalled <- struct{}{} })
 
 	pwcMock := &clientMocks.ProvisionWatcherClient{}
 	pwcMock.On("ProvisionWatchersByServiceName", mock.Anything, testServiceKey, 0, -1).Return(
@@ -69,6 +68,9 @@ func Test_processAsyncFilterAndAdd_bypassValidation(t *testing.T) {
 		bootstrapContainer.LoggingClientInterfaceName: func(get di.Get) interface{} {
 			return logger.NewMockClient()
 		},
+		container.ConfigurationName: func(get di.Get) interface{} {
+			return &config.ConfigurationStruct{}
+		},
 		bootstrapContainer.DeviceClientName: func(get di.Get) interface{} {
 			return dcMock
 		},
@@ -102,132 +104,176 @@ func Test_processAsyncFilterAndAdd_bypassValidation(t *testing.T) {
 	case <-time.After(time.Second):
 		t.Fatal("AddWithQueryParams was not called within timeout")
 	}
+
+	require.Eventually(t, func() bool {
+		run, ok := autodiscovery.LastDiscoveryRun()
+		return ok && len(run.Candidates) == 1 && run.Candidates[0].Action == autodiscovery.CandidateActionAdded
+	}, time.Second, 10*time.Millisecond, "discovered device is not recorded as the added candidate")
 }
 
-func Test_checkAllowList(t *testing.T) {
-	ds := newDeviceService()
-	pw := models.ProvisionWatcher{
-		Name: "test-watcher",
-		Identifiers: map[string]string{
-			"host": "localhost",
-			"port": "3[0-9]{2}",
-		},
-	}
+func Test_filterAndAdd_requireApproval(t *testing.T) {
+	const testServiceKey = "test-service"
 
-	onlyOneMatch := map[string]models.ProtocolProperties{
-		"http": {
-			"host": "localhost",
-			"port": "301",
-		},
-	}
-	oneOfProtocolsMatch := map[string]models.ProtocolProperties{
-		"tcp": {
-			"host": "localhost",
-			"port": "80",
+	dcMock := &clientMocks.DeviceClient{}
+	dcMock.On("DevicesByServiceName", mock.Anything, testServiceKey, 0, -1).Return(
+		responses.MultiDevicesResponse{}, nil)
+	pwcMock := &clientMocks.ProvisionWatcherClient{}
+	pwcMock.On("ProvisionWatchersByServiceName", mock.Anything, testServiceKey, 0, -1).Return(
+		responses.MultiProvisionWatchersResponse{}, nil)
+	dic := di.NewContainer(di.ServiceConstructorMap{
+		bootstrapContainer.LoggingClientInterfaceName: func(get di.Get) interface{} {
+			return logger.NewMockClient()
 		},
-		"http": {
-			"host": "localhost",
-			"port": "301",
+		container.ConfigurationName: func(get di.Get) interface{} {
+			return &config.ConfigurationStruct{}
 		},
-	}
-	noIdentifiersMatch := map[string]models.ProtocolProperties{
-		"http": {
-			"host": "192.168.0.1",
-			"port": "400",
+		container.DeviceServiceName: func(get di.Get) interface{} {
+			return &models.DeviceService{Name: testServiceKey}
 		},
-	}
-	someIdentifiersMatch := map[string]models.ProtocolProperties{
-		"http": {
-			"host": "127.0.0.1",
-			"port": "301",
+		bootstrapContainer.DeviceClientName: func(get di.Get) interface{} {
+			return dcMock
 		},
-		"tcp": {
-			"host": "localhost",
-			"port": "80",
+		bootstrapContainer.ProvisionWatcherClientName: func(get di.Get) interface{} {
+			return pwcMock
 		},
+	})
+	require.NoError(t, cache.InitCache(testServiceKey, testServiceKey, dic))
+
+	ds := &deviceService{serviceKey: testServiceKey, lc: logger.NewMockClient(), dic: dic}
+	pw := models.ProvisionWatcher{
+		Name:             "approval-watcher",
+		Labels:           []string{internalCommon.RequireApprovalLabel},
+		AdminState:       models.Unlocked,
+		DiscoveredDevice: models.DiscoveredDevice{ProfileName: "test-profile", AdminState: models.Unlocked},
 	}
-	noMatchInSingleIdentifier := map[string]models.ProtocolProperties{
-		"http": {
-			"port": "301",
-		},
-		"tcp": {
-			"host": "localhost",
-		},
+	discovered := sdkModels.DiscoveredDevice{
+		Name:      "approval-device",
+		Protocols: map[string]models.ProtocolProperties{"http": {"host": "localhost"}},
 	}
 
-	tests := []struct {
-		name      string
-		protocols map[string]models.ProtocolProperties
-		expected  bool
-	}{
-		{"pass - match found", onlyOneMatch, true},
-		{"pass - one match found in multiple protocol", oneOfProtocolsMatch, true},
-		{"fail - none of identifier match in one protocol", noIdentifiersMatch, false},
-		{"fail - only partial of identifiers match in one protocol", someIdentifiersMatch, false},
-		{"fail - all of the identifiers match but across different protocol", noMatchInSingleIdentifier, false},
-	}
+	candidate := ds.filterAndAdd(context.Background(), discovered, []models.ProvisionWatcher{pw})
+	require.Equal(t, autodiscovery.CandidateActionPendingApproval, candidate.Action)
+	require.Equal(t, pw.Name, candidate.ProvisionWatcher)
+	dcMock.AssertNotCalled(t, "AddWithQueryParams", mock.Anything, mock.Anything, mock.Anything)
 
-	for _, testCase := range tests {
-		t.Run(testCase.name, func(t *testing.T) {
-			d.Protocols = testCase.protocols
-			result := ds.checkAllowList(d, pw)
-			assert.Equal(t, testCase.expected, result)
-		})
+	var pendingId string
+	for _, p := range autodiscovery.PendingDevices() {
+		if p.Device.Name == discovered.Name {
+			pendingId = p.Id
+			require.Equal(t, "test-profile", p.Device.ProfileName)
+		}
 	}
+	require.NotEmpty(t, pendingId, "discovered device is not pending approval")
+
+	_, edgexErr := autodiscovery.RejectPendingDevice(pendingId)
+	require.NoError(t, edgexErr)
+	candidate = ds.filterAndAdd(context.Background(), discovered, []models.ProvisionWatcher{pw})
+	require.Equal(t, autodiscovery.CandidateActionRejected, candidate.Action)
 }
 
-func Test_checkBlockList(t *testing.T) {
-	ds := newDeviceService()
-	pw := models.ProvisionWatcher{
-		Name: "test-watcher",
-		BlockingIdentifiers: map[string][]string{
-			"port": []string{"399", "398", "397"},
-		},
-	}
+func Test_filterAndAdd_identityKey(t *testing.T) {
+	const testServiceKey = "test-service"
 
-	noBlockingIdentifierFound := map[string]models.ProtocolProperties{
-		"http": {
-			"host": "localhost",
-		},
-		"tcp": {
-			"host": "127.0.0.1",
-		},
+	existing := dtos.Device{
+		Name:        "Meter-A-00_1A_2B",
+		ProfileName: "Meter-A",
+		ServiceName: testServiceKey,
+		Protocols:   map[string]dtos.ProtocolProperties{"http": {"host": "192.168.0.10", "mac": "00:1A:2B"}},
 	}
-	noBlockingIdentifierMatch := map[string]models.ProtocolProperties{
-		"http": {
-			"host": "localhost",
-			"port": "400",
-		},
-		"tcp": {
-			"host": "localhost",
-			"port": "80",
-		},
-	}
-	blockingIdentifierMatch := map[string]models.ProtocolProperties{
-		"http": {
-			"host": "localhost",
-			"port": "399",
-		},
-		"tcp": {
-			"host": "localhost",
-			"port": "80",
-		},
+	pw := models.ProvisionWatcher{
+		Name:             "meter-watcher",
+		Labels:           []string{internalCommon.IdentityKeyLabelPrefix + "mac"},
+		AdminState:       models.Unlocked,
+		DiscoveredDevice: models.DiscoveredDevice{ProfileName: "Meter-A", AdminState: models.Unlocked},
 	}
 
 	tests := []struct {
-		name      string
-		protocols map[string]models.ProtocolProperties
-		expected  bool
+		name               string
+		discovered         sdkModels.DiscoveredDevice
+		expectedAction     string
+		expectedDeviceName string
+		expectedUpdate     bool
+		expectedAdd        bool
 	}{
-		{"pass - no blocking identifier found", noBlockingIdentifierFound, true},
-		{"pass - blocking identifier found but not match", noBlockingIdentifierMatch, true},
-		{"fail - blocking identifier match", blockingIdentifierMatch, false},
+		{"rediscovered with new address",
+			sdkModels.DiscoveredDevice{Name: "meter-192.168.0.20", Protocols: map[string]models.ProtocolProperties{"http": {"host": "192.168.0.20", "mac": "00:1a:2b"}}},
+			autodiscovery.CandidateActionUpdated, existing.Name, true, false},
+		{"rediscovered unchanged",
+			sdkModels.DiscoveredDevice{Name: "meter-192.168.0.10", Protocols: map[string]models.ProtocolProperties{"http": {"host": "192.168.0.10", "mac": "00:1A:2B"}}},
+			autodiscovery.CandidateActionAlreadyExists, existing.Name, false, false},
+		{"new device with stable name",
+			sdkModels.DiscoveredDevice{Name: "meter-192.168.0.30", Protocols: map[string]models.ProtocolProperties{"http": {"host": "192.168.0.30", "mac": "00:1A:2C"}}},
+			autodiscovery.CandidateActionAdded, "Meter-A-00_1A_2C", false, true},
+		{"new device without identity",
+			sdkModels.DiscoveredDevice{Name: "meter-192.168.0.40", Protocols: map[string]models.ProtocolProperties{"http": {"host": "192.168.0.40"}}},
+			autodiscovery.CandidateActionAdded, "", false, true},
+		{"new device rejected by Metadata",
+			sdkModels.DiscoveredDevice{Name: "meter-192.168.0.50", Protocols: map[string]models.ProtocolProperties{"http": {"host": "192.168.0.50", "mac": "00:1A:2D"}}},
+			autodiscovery.CandidateActionAddFailed, "Meter-A-00_1A_2D", false, true},
 	}
-	for _, testCase := range tests {
-		t.Run(testCase.name, func(t *testing.T) {
-			d.Protocols = testCase.protocols
-			result := ds.checkBlockList(d, pw)
-			assert.Equal(t, testCase.expected, result)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dcMock := &clientMocks.DeviceClient{}
+			dcMock.On("DevicesByServiceName", mock.Anything, testServiceKey, 0, -1).Return(
+				responses.MultiDevicesResponse{Devices: []dtos.Device{existing}}, nil)
+			dcMock.On("UpdateWithQueryParams", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+			dcMock.On("AddWithQueryParams", mock.Anything, mock.MatchedBy(func(reqs []requests.AddDeviceRequest) bool {
+				return reqs[0].Device.Name == "Meter-A-00_1A_2D"
+			}), mock.Anything).Return([]dtoCommon.BaseWithIdResponse{
+				{BaseResponse: dtoCommon.NewBaseResponse("", "device name Meter-A-00_1A_2D exists", http.StatusConflict)}}, nil)
+			dcMock.On("AddWithQueryParams", mock.Anything, mock.Anything, mock.Anything).Return(addedResponse, nil)
+			pwcMock := &clientMocks.ProvisionWatcherClient{}
+			pwcMock.On("ProvisionWatchersByServiceName", mock.Anything, testServiceKey, 0, -1).Return(
+				responses.MultiProvisionWatchersResponse{}, nil)
+			dpcMock := &clientMocks.DeviceProfileClient{}
+			dpcMock.On("DeviceProfileByName", mock.Anything, "Meter-A").Return(
+				responses.DeviceProfileResponse{Profile: dtos.DeviceProfile{DeviceProfileBasicInfo: dtos.DeviceProfileBasicInfo{Name: "Meter-A"}}}, nil)
+			metricsManager := &bootstrapMocks.MetricsManager{}
+			metricsManager.On("Register", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			dic := di.NewContainer(di.ServiceConstructorMap{
+				bootstrapContainer.LoggingClientInterfaceName: func(get di.Get) interface{} {
+					return logger.NewMockClient()
+				},
+				container.ConfigurationName: func(get di.Get) interface{} {
+					return &config.ConfigurationStruct{}
+				},
+				bootstrapContainer.MetricsManagerInterfaceName: func(get di.Get) interface{} {
+					return metricsManager
+				},
+				bootstrapContainer.DeviceClientName: func(get di.Get) interface{} {
+					return dcMock
+				},
+				bootstrapContainer.DeviceProfileClientName: func(get di.Get) interface{} {
+					return dpcMock
+				},
+				bootstrapContainer.ProvisionWatcherClientName: func(get di.Get) interface{} {
+					return pwcMock
+				},
+			})
+			require.NoError(t, cache.InitCache(testServiceKey, testServiceKey, dic))
+			ds := &deviceService{serviceKey: testServiceKey, lc: logger.NewMockClient(), dic: dic}
+
+			candidate := ds.filterAndAdd(context.Background(), tt.discovered, []models.ProvisionWatcher{pw})
+			assert.Equal(t, tt.expectedAction, candidate.Action)
+			assert.Equal(t, tt.expectedDeviceName, candidate.DeviceName)
+			if tt.expectedUpdate {
+				dcMock.AssertCalled(t, "UpdateWithQueryParams", mock.Anything, mock.MatchedBy(func(reqs []requests.UpdateDeviceRequest) bool {
+					return *reqs[0].Device.Name == existing.Name && reqs[0].Device.Protocols["http"]["host"] == "192.168.0.20"
+				}), mock.Anything)
+			} else {
+				dcMock.AssertNotCalled(t, "UpdateWithQueryParams", mock.Anything, mock.Anything, mock.Anything)
+			}
+			if tt.expectedAdd {
+				expectedName := tt.expectedDeviceName
+				if expectedName == "" {
+					expectedName = tt.discovered.Name
+				}
+				dcMock.AssertCalled(t, "AddWithQueryParams", mock.Anything, mock.MatchedBy(func(reqs []requests.AddDeviceRequest) bool {
+					return reqs[0].Device.Name == expectedName
+				}), mock.Anything)
+			} else {
+				dcMock.AssertNotCalled(t, "AddWithQueryParams", mock.Anything, mock.Anything, mock.Anything)
+			}
 		})
 	}
 }
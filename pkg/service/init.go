@@ -1,6 +1,6 @@
 // -*- Mode: Go; indent-tabs-mode: t -*-
 //
-// Copyright (C) 2020-2025 IOTech Ltd
+// Copyright (C) 2020-2026 IOTech Ltd
 //
 // SPDX-License-Identifier: Apache-2.0
 
@@ -8,10 +8,17 @@ package service
 
 import (
 	"context"
+	goErrors "errors"
+	"fmt"
+	"net"
 	"net/http"
 	"sync"
+	"time"
 
 	"github.com/edgexfoundry/go-mod-core-contracts/v4/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
+	coreModels "github.com/edgexfoundry/go-mod-core-contracts/v4/models"
 
 	bootstrapContainer "github.com/edgexfoundry/go-mod-bootstrap/v4/bootstrap/container"
 	"github.com/edgexfoundry/go-mod-bootstrap/v4/bootstrap/controller"
@@ -19,6 +26,7 @@ import (
 	"github.com/edgexfoundry/go-mod-bootstrap/v4/bootstrap/startup"
 	"github.com/edgexfoundry/go-mod-bootstrap/v4/di"
 
+	"github.com/edgexfoundry/device-sdk-go/v4/internal/application"
 	"github.com/edgexfoundry/device-sdk-go/v4/internal/cache"
 	sdkCommon "github.com/edgexfoundry/device-sdk-go/v4/internal/common"
 	"github.com/edgexfoundry/device-sdk-go/v4/internal/container"
@@ -34,6 +42,8 @@ import (
 type Bootstrap struct {
 	deviceService *deviceService
 	router        *echo.Echo
+	// provisionedSteps is the number of the registerAndProvision steps completed, so a retry skips them
+	provisionedSteps int
 }
 
 // NewBootstrap is a factory method that returns an initialized Bootstrap receiver struct.
@@ -55,14 +65,43 @@ func (b *Bootstrap) BootstrapHandler(ctx context.Context, wg *sync.WaitGroup, st
 	s.controller = restController.NewRestController(b.router, dic, s.serviceKey)
 	s.controller.InitRestRoutes(dic)
 
-	if !b.checkDependencyServiceAvailable(common.CoreMetaDataServiceKey, startupTimer) {
-		return false
+	snapshotInfo := s.config.Device.Snapshot
+	degraded := false
+	if snapshotInfo.Enabled {
+		// the device service starts in degraded mode as soon as core-metadata can't be connected, rather than once
+		// the startup timer has expired
+		if err := b.pingService(common.CoreMetaDataServiceKey); err != nil && isConnectionError(err) {
+			s.lc.Warnf("Failed to connect to %s: %v", common.CoreMetaDataServiceKey, err)
+			degraded = true
+		}
 	}
-
-	edgexErr := cache.InitCache(s.serviceKey, s.baseServiceName, dic)
-	if edgexErr != nil {
-		s.lc.Errorf("Failed to init cache: %s", edgexErr.Error())
-		return false
+	if !degraded {
+		if !b.checkDependencyServiceAvailable(common.CoreMetaDataServiceKey, startupTimer) {
+			if !snapshotInfo.Enabled {
+				return false
+			}
+			degraded = true
+		} else if edgexErr := cache.InitCache(s.serviceKey, s.baseServiceName, dic); edgexErr != nil {
+			if !snapshotInfo.Enabled || errors.Kind(edgexErr) != errors.KindServiceUnavailable {
+				s.lc.Errorf("Failed to init cache: %s", edgexErr.Error())
+				return false
+			}
+			s.lc.Warnf("Failed to init cache: %s", edgexErr.Error())
+			degraded = true
+		}
+	}
+	if degraded {
+		// start in degraded mode from the local snapshot, the caches are reconciled once core-metadata is available
+		snapshot, edgexErr := cache.ReadSnapshot(snapshotInfo.Path)
+		if edgexErr != nil {
+			s.lc.Errorf("Failed to start in degraded mode: %s", edgexErr.Error())
+			return false
+		}
+		cache.InitCacheFromSnapshot(snapshot, dic)
+		*s.deviceServiceModel = dtos.ToDeviceServiceModel(snapshot.DeviceService)
+		degraded = true
+		s.lc.Warnf("%s is unavailable, started in degraded mode from the metadata snapshot created at %s",
+			common.CoreMetaDataServiceKey, time.Unix(0, snapshot.Created).Format(time.RFC3339))
 	}
 
 	devices := cache.Devices().All()
@@ -101,28 +140,33 @@ func (b *Bootstrap) BootstrapHandler(ctx context.Context, wg *sync.WaitGroup, st
 		return false
 	}
 
-	edgexErr = s.selfRegister()
-	if edgexErr != nil {
-		s.lc.Errorf("Failed to register %s on Metadata: %s", s.serviceKey, edgexErr.Error())
-		return false
-	}
-
-	edgexErr = provision.LoadProfiles(s.config.Device.ProfilesDir, s.overwriteProfiles, dic)
-	if edgexErr != nil {
-		s.lc.Errorf("Failed to load device profiles: %s", edgexErr.Error())
-		return false
-	}
-
-	edgexErr = provision.LoadDevices(s.config.Device.DevicesDir, s.overwriteDevices, dic)
-	if edgexErr != nil {
-		s.lc.Errorf("Failed to load devices: %s", edgexErr.Error())
-		return false
+	var saveInterval, retryInterval time.Duration
+	if snapshotInfo.Enabled {
+		if saveInterval, err = time.ParseDuration(snapshotInfo.SaveInterval); err != nil {
+			s.lc.Errorf("Invalid metadata snapshot SaveInterval %s: %v", snapshotInfo.SaveInterval, err)
+			return false
+		}
+		if retryInterval, err = time.ParseDuration(snapshotInfo.RetryInterval); err != nil {
+			s.lc.Errorf("Invalid metadata snapshot RetryInterval %s: %v", snapshotInfo.RetryInterval, err)
+			return false
+		}
 	}
 
-	edgexErr = provision.LoadProvisionWatchers(s.config.Device.ProvisionWatchersDir, dic)
-	if edgexErr != nil {
-		s.lc.Errorf("Failed to load provision watchers: %s", edgexErr.Error())
-		return false
+	if degraded {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			b.recoverFromSnapshot(ctx, wg, retryInterval, saveInterval, dic)
+		}()
+	} else {
+		if !b.registerAndProvision(dic) {
+			return false
+		}
+		if snapshotInfo.Enabled {
+			b.startSnapshotSaver(ctx, wg, saveInterval, dic)
+		}
+		b.startReconciler(ctx, wg, dic)
+		b.startProvisionWatcher(ctx, wg, dic)
 	}
 
 	s.autoEventManager.StartAutoEvents()
@@ -130,31 +174,203 @@ func (b *Bootstrap) BootstrapHandler(ctx context.Context, wg *sync.WaitGroup, st
 	// Very important that this bootstrap handler is called after the NewServiceMetrics handler so
 	// MetricsManager dependency has been created.
 	sdkCommon.InitializeSentMetrics(s.lc, dic)
+	sdkCommon.InitializeAssertionMetrics(s.lc, dic)
+	sdkCommon.InitializeReconcileMetrics(s.lc, dic)
 	return true
 }
 
-func (b *Bootstrap) checkDependencyServiceAvailable(serviceKey string, startupTimer startup.Timer) bool {
-	lc := b.deviceService.lc
+// registerAndProvision registers the device service on core-metadata and loads the provisioning files. The completed
+// steps are recorded, so a retry after a failure continues from the step which failed.
+func (b *Bootstrap) registerAndProvision(dic *di.Container) bool {
+	s := b.deviceService
+	steps := []struct {
+		name string
+		run  func() errors.EdgeX
+	}{
+		{fmt.Sprintf("register %s on Metadata", s.serviceKey), s.selfRegister},
+		{"load device profiles", func() errors.EdgeX {
+			return provision.LoadProfiles(s.config.Device.ProfilesDir, s.overwriteProfiles, dic)
+		}},
+		{"load devices", func() errors.EdgeX {
+			return b.loadDevices(dic)
+		}},
+		{"load provision watchers", func() errors.EdgeX {
+			return b.loadProvisionWatchers(dic)
+		}},
+	}
+	for ; b.provisionedSteps < len(steps); b.provisionedSteps++ {
+		step := steps[b.provisionedSteps]
+		if edgexErr := step.run(); edgexErr != nil {
+			s.lc.Errorf("Failed to %s: %s", step.name, edgexErr.Error())
+			return false
+		}
+	}
+	return true
+}
+
+// loadDevices loads the DevicesDir additively, or declaratively if the declarative provisioning is enabled
+func (b *Bootstrap) loadDevices(dic *di.Container) errors.EdgeX {
+	s := b.deviceService
+	if provisioning := s.config.Device.Provisioning; provisioning.Declarative {
+		return provision.LoadDevicesDeclarative(s.config.Device.DevicesDir, provisioning.DryRun, dic)
+	}
+	return provision.LoadDevices(s.config.Device.DevicesDir, s.overwriteDevices, dic)
+}
+
+// loadProvisionWatchers loads the ProvisionWatchersDir additively, or declaratively if the declarative provisioning
+// is enabled
+func (b *Bootstrap) loadProvisionWatchers(dic *di.Container) errors.EdgeX {
+	s := b.deviceService
+	if provisioning := s.config.Device.Provisioning; provisioning.Declarative {
+		return provision.LoadProvisionWatchersDeclarative(s.config.Device.ProvisionWatchersDir, provisioning.DryRun, dic)
+	}
+	return provision.LoadProvisionWatchers(s.config.Device.ProvisionWatchersDir, s.overwriteWatchers, dic)
+}
+
+// loadDeviceFiles loads the changed files of the DevicesDir additively. The declarative provisioning plans the whole
+// DevicesDir, as the Devices of the removed files are deleted, and only the differences are sent to core-metadata.
+func (b *Bootstrap) loadDeviceFiles(files []string, dic *di.Container) errors.EdgeX {
+	s := b.deviceService
+	if s.config.Device.Provisioning.Declarative {
+		return b.loadDevices(dic)
+	}
+	return provision.LoadDeviceFiles(files, s.overwriteDevices, dic)
+}
+
+// loadProvisionWatcherFiles loads the changed files of the ProvisionWatchersDir additively, or plans the whole
+// ProvisionWatchersDir if the declarative provisioning is enabled
+func (b *Bootstrap) loadProvisionWatcherFiles(files []string, dic *di.Container) errors.EdgeX {
+	s := b.deviceService
+	if s.config.Device.Provisioning.Declarative {
+		return b.loadProvisionWatchers(dic)
+	}
+	return provision.LoadProvisionWatcherFiles(files, s.overwriteWatchers, dic)
+}
+
+// recoverFromSnapshot waits for core-metadata to be available when the device service is started in degraded mode,
+// then registers the device service, loads the provisioning files and reconciles the caches with core-metadata.
+func (b *Bootstrap) recoverFromSnapshot(ctx context.Context, wg *sync.WaitGroup, retryInterval time.Duration, saveInterval time.Duration, dic *di.Container) {
+	s := b.deviceService
+	ticker := time.NewTicker(retryInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if err := b.dependencyServiceAvailable(common.CoreMetaDataServiceKey); err != nil {
+			s.lc.Debugf("%s is still unavailable: %v", common.CoreMetaDataServiceKey, err)
+			continue
+		}
+		if !b.registerAndProvision(dic) {
+			continue
+		}
+		corrected, edgexErr := application.Reconcile(s.serviceKey, s.baseServiceName, dic)
+		if edgexErr != nil {
+			s.lc.Errorf("Failed to reconcile the caches with %s: %v", common.CoreMetaDataServiceKey, edgexErr)
+		}
+		s.lc.Infof("%s is available, left degraded mode with %d differences from the metadata snapshot reconciled", common.CoreMetaDataServiceKey, corrected)
+		b.startSnapshotSaver(ctx, wg, saveInterval, dic)
+		b.startReconciler(ctx, wg, dic)
+		b.startProvisionWatcher(ctx, wg, dic)
+		return
+	}
+}
+
+// startSnapshotSaver saves the snapshot of the caches periodically to start the device service in degraded mode
+func (b *Bootstrap) startSnapshotSaver(ctx context.Context, wg *sync.WaitGroup, saveInterval time.Duration, dic *di.Container) {
+	s := b.deviceService
+	cache.StartSnapshotSaver(ctx, wg, s.config.Device.Snapshot.Path, saveInterval, func() coreModels.DeviceService {
+		return *s.deviceServiceModel
+	}, dic)
+}
+
+// startProvisionWatcher starts watching the provisioning directories for the added or changed files if enabled
+func (b *Bootstrap) startProvisionWatcher(ctx context.Context, wg *sync.WaitGroup, dic *di.Container) {
+	s := b.deviceService
+	hotReload := s.config.Device.HotReload
+	if !hotReload.Enabled {
+		return
+	}
+
+	debounce, err := time.ParseDuration(hotReload.Debounce)
+	if err != nil || debounce <= 0 {
+		s.lc.Errorf("Provisioning directories hot-reload stopped: debounce %s error in configuration: %v", hotReload.Debounce, err)
+		return
+	}
+	dirs := []provision.WatchedDirectory{
+		{Path: s.config.Device.ProfilesDir, Load: func(files []string) errors.EdgeX {
+			return provision.LoadProfileFiles(s.config.Device.ProfilesDir, files, s.overwriteProfiles, dic)
+		}},
+		{Path: s.config.Device.DevicesDir, Load: func(files []string) errors.EdgeX {
+			return b.loadDeviceFiles(files, dic)
+		}},
+		{Path: s.config.Device.ProvisionWatchersDir, Load: func(files []string) errors.EdgeX {
+			return b.loadProvisionWatcherFiles(files, dic)
+		}},
+	}
+	if edgexErr := provision.StartWatcher(ctx, wg, debounce, dirs, dic); edgexErr != nil {
+		s.lc.Errorf("Failed to start provisioning directories hot-reload: %v", edgexErr)
+	}
+}
+
+// startReconciler starts the periodic reconciliation of the caches with core-metadata if enabled
+func (b *Bootstrap) startReconciler(ctx context.Context, wg *sync.WaitGroup, dic *di.Container) {
+	s := b.deviceService
+	if s.config.Device.Reconciliation.Enabled {
+		application.StartReconciler(ctx, wg, s.serviceKey, s.baseServiceName, dic)
+	}
+}
+
+// dependencyServiceAvailable checks the availability of the service once via Registry or by Ping
+func (b *Bootstrap) dependencyServiceAvailable(serviceKey string) error {
 	registry := bootstrapContainer.RegistryFrom(b.deviceService.dic.Get)
 	mode := bootstrapContainer.DevRemoteModeFrom(b.deviceService.dic.Get)
+	if registry != nil && !mode.InDevMode && !mode.InRemoteMode {
+		_, err := registry.IsServiceAvailable(serviceKey)
+		return err
+	}
+
+	// ping the service in dev or remote mode, or without Registry
+	return b.pingService(serviceKey)
+}
+
+// pingService pings the service directly, regardless of the Registry
+func (b *Bootstrap) pingService(serviceKey string) error {
 	clients := bootstrapContainer.ConfigurationFrom(b.deviceService.dic.Get).GetBootstrap().Clients
 	clientInfo, ok := (*clients)[serviceKey]
 	if !ok {
+		return fmt.Errorf("client configuration for '%s' not found", serviceKey)
+	}
+	client := &http.Client{}
+	res, err := client.Get(clientInfo.Url() + common.ApiPingRoute)
+	if err != nil {
+		return err
+	}
+	return res.Body.Close()
+}
+
+// isConnectionError reports whether the error is a failure to connect to the service, e.g. the connection is refused
+// or timed out, rather than an error response of the service
+func isConnectionError(err error) bool {
+	var netErr net.Error
+	return goErrors.As(err, &netErr)
+}
+
+func (b *Bootstrap) checkDependencyServiceAvailable(serviceKey string, startupTimer startup.Timer) bool {
+	lc := b.deviceService.lc
+	clients := bootstrapContainer.ConfigurationFrom(b.deviceService.dic.Get).GetBootstrap().Clients
+	if _, ok := (*clients)[serviceKey]; !ok {
 		lc.Errorf("Client configuration for '%s' not found, missing common config? Use -cp or -cc flags for common config.", serviceKey)
 		return false
 	}
-	pingUrl := clientInfo.Url() + common.ApiPingRoute
 
 	var err error
 	for startupTimer.HasNotElapsed() {
-		if registry == nil || mode.InDevMode || mode.InRemoteMode {
-			lc.Debugf("Check service '%s' availability by Ping", serviceKey)
-			client := &http.Client{}
-			_, err = client.Get(pingUrl)
-		} else {
-			lc.Debugf("Check service '%s' availability via Registry", serviceKey)
-			_, err = registry.IsServiceAvailable(serviceKey)
-		}
+		lc.Debugf("Check service '%s' availability", serviceKey)
+		err = b.dependencyServiceAvailable(serviceKey)
 		if err == nil {
 			break
 		}
@@ -75,6 +75,31 @@ func (s *deviceService) Devices() []models.Device {
 	return cache.Devices().All()
 }
 
+// DevicesByLabel returns the managed Devices with the given label from cache
+func (s *deviceService) DevicesByLabel(label string) []models.Device {
+	return cache.Devices().ForLabel(label)
+}
+
+// DevicesByProfileName returns the managed Devices associated with the given profile from cache
+func (s *deviceService) DevicesByProfileName(profileName string) []models.Device {
+	return cache.Devices().ForProfileName(profileName)
+}
+
+// DevicesByAdminState returns the managed Devices in the given admin state from cache
+func (s *deviceService) DevicesByAdminState(state models.AdminState) []models.Device {
+	return cache.Devices().ForAdminState(state)
+}
+
+// DevicesByOperatingState returns the managed Devices in the given operating state from cache
+func (s *deviceService) DevicesByOperatingState(state models.OperatingState) []models.Device {
+	return cache.Devices().ForOperatingState(state)
+}
+
+// DevicesByProtocolProperty returns the managed Devices whose protocol property has the given value from cache
+func (s *deviceService) DevicesByProtocolProperty(protocol string, property string, value string) []models.Device {
+	return cache.Devices().ForProtocolProperty(protocol, property, value)
+}
+
 // GetDeviceByName returns the Device by its name if it exists in the cache, or returns an error.
 func (s *deviceService) GetDeviceByName(name string) (models.Device, error) {
 	device, ok := cache.Devices().ForName(name)
@@ -27,6 +27,7 @@ import (
 
 	"github.com/edgexfoundry/device-sdk-go/v4/internal/autodiscovery"
 	"github.com/edgexfoundry/device-sdk-go/v4/internal/autoevent"
+	"github.com/edgexfoundry/device-sdk-go/v4/internal/cache"
 	sdkCommon "github.com/edgexfoundry/device-sdk-go/v4/internal/common"
 	"github.com/edgexfoundry/device-sdk-go/v4/internal/config"
 	"github.com/edgexfoundry/device-sdk-go/v4/internal/container"
@@ -67,6 +68,7 @@ type deviceService struct {
 	flags              *flags.Default
 	overwriteDevices   bool
 	overwriteProfiles  bool
+	overwriteWatchers  bool
 	deviceServiceModel *models.DeviceService
 	config             *config.ConfigurationStruct
 	configProcessor    *bootstrapConfig.Processor
@@ -74,6 +76,7 @@ type deviceService struct {
 	ctx                context.Context
 	dic                *di.Container
 	pool               *ants.Pool
+	transformers       *container.TransformerRegistry
 }
 
 // NewDeviceService returns an implementation of interfaces.DeviceServiceSDKExt for the specified key, version, and driver.
@@ -98,6 +101,7 @@ func NewDeviceService(serviceKey string, serviceVersion string, driver interface
 	}
 
 	service.config = &config.ConfigurationStruct{}
+	service.transformers = container.NewTransformerRegistry()
 	return interfaces.DeviceServiceSDK(&service), nil
 }
 
@@ -105,6 +109,8 @@ func (s *deviceService) Run() error {
 	var instanceName string
 	var overwriteDevices bool
 	var overwriteProfiles bool
+	var overwriteWatchers bool
+	var validateProvisioning bool
 	startupTimer := startup.NewStartUpTimer(s.serviceKey)
 
 	additionalUsage :=
@@ -114,6 +120,13 @@ func (s *deviceService) Run() error {
 			"    -od, --overwriteDevices      Overwrite core-metadata with the versions of the associated device files.\n" +
 			"                                 *** Use with cation *** Use will clobber existing devices in core-metadata,\n" +
 			"                                 problematic if those devices were edited by hand intentionally.\n" +
+			"    -opw, --overwriteProvisionWatchers\n" +
+			"                                 Overwrite core-metadata with the versions of the associated provision watcher files.\n" +
+			"                                 *** Use with caution *** Use will clobber existing provision watchers in core-metadata,\n" +
+			"                                 problematic if those provision watchers were edited by hand intentionally.\n" +
+			"    -vp, --validateProvisioning  Validate the device profile, device and provision watcher files of the local\n" +
+			"                                 configuration without core-metadata, write the report in JSON and exit.\n" +
+			"                                 The exit code is non-zero if the files are invalid.\n" +
 			"    -i, --instance                  Provides a service name suffix which allows unique instance to be created\n" +
 			"                                    If the option is provided, service name will be replaced with \"<name>_<instance>\"\n"
 	s.flags = flags.NewWithUsage(additionalUsage)
@@ -123,10 +136,20 @@ func (s *deviceService) Run() error {
 	s.flags.FlagSet.BoolVar(&overwriteProfiles, "overwriteProfiles", false, "")
 	s.flags.FlagSet.BoolVar(&overwriteDevices, "od", false, "")
 	s.flags.FlagSet.BoolVar(&overwriteDevices, "overwriteDevices", false, "")
+	s.flags.FlagSet.BoolVar(&overwriteWatchers, "opw", false, "")
+	s.flags.FlagSet.BoolVar(&overwriteWatchers, "overwriteProvisionWatchers", false, "")
+	s.flags.FlagSet.BoolVar(&validateProvisioning, "vp", false, "")
+	s.flags.FlagSet.BoolVar(&validateProvisioning, "validateProvisioning", false, "")
 	s.flags.Parse(os.Args[1:])
 	s.setServiceName(instanceName)
 	s.overwriteDevices = overwriteDevices
 	s.overwriteProfiles = overwriteProfiles
+	s.overwriteWatchers = overwriteWatchers
+
+	if validateProvisioning {
+		s.config = &config.ConfigurationStruct{}
+		return s.validateProvisioning()
+	}
 
 	s.config = &config.ConfigurationStruct{}
 	s.deviceServiceModel = &models.DeviceService{Name: s.serviceKey}
@@ -144,6 +167,9 @@ func (s *deviceService) Run() error {
 		container.ExtendedProtocolDriverName: func(get di.Get) any {
 			return s.extdriver
 		},
+		container.TransformerRegistryName: func(get di.Get) any {
+			return s.transformers
+		},
 	})
 
 	// set poolSize to config.Device.AsyncBufferSize
@@ -383,3 +409,23 @@ func (s *deviceService) PublishProfileScanProgressSystemEvent(reqId string, prog
 func (s *deviceService) PublishGenericSystemEvent(eventType, action string, details any) {
 	sdkUtils.PublishGenericSystemEvent(eventType, action, details, s.ctx, s.dic)
 }
+
+// RegisterTransformer registers a custom data transformer which is referenced by name in the DeviceResource attributes
+func (s *deviceService) RegisterTransformer(name string, transformer sdkModels.Transformer) error {
+	return s.transformers.Register(name, transformer)
+}
+
+// WatchDevices subscribes the handler to the changes of the device cache
+func (s *deviceService) WatchDevices(handler func(sdkModels.DeviceChange)) func() {
+	return cache.WatchDevices(handler)
+}
+
+// WatchDeviceProfiles subscribes the handler to the changes of the profile cache
+func (s *deviceService) WatchDeviceProfiles(handler func(sdkModels.DeviceProfileChange)) func() {
+	return cache.WatchDeviceProfiles(handler)
+}
+
+// WatchProvisionWatchers subscribes the handler to the changes of the provision watcher cache
+func (s *deviceService) WatchProvisionWatchers(handler func(sdkModels.ProvisionWatcherChange)) func() {
+	return cache.WatchProvisionWatchers(handler)
+}
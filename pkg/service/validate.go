@@ -0,0 +1,58 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2026 IOTech Ltd
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	bootstrapConfig "github.com/edgexfoundry/go-mod-bootstrap/v4/bootstrap/config"
+	"github.com/edgexfoundry/go-mod-bootstrap/v4/bootstrap/environment"
+	"github.com/edgexfoundry/go-mod-bootstrap/v4/bootstrap/file"
+	"github.com/edgexfoundry/go-mod-bootstrap/v4/bootstrap/utils"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
+	"gopkg.in/yaml.v3"
+
+	"github.com/edgexfoundry/device-sdk-go/v4/internal/provision"
+)
+
+// validateProvisioning validates the provisioning files of the local configuration file without the other EdgeX
+// services and writes the report in JSON to the stdout. An error is returned if the files are invalid, so the
+// service exits non-zero.
+func (s *deviceService) validateProvisioning() error {
+	// only the errors are logged to keep the report readable
+	s.lc = logger.NewClient(s.serviceKey, models.ErrorLog)
+
+	configFile := bootstrapConfig.GetConfigFileLocation(s.lc, s.flags)
+	contents, err := file.Load(configFile, nil, s.lc)
+	if err != nil {
+		return fmt.Errorf("failed to read configuration file %s: %v", configFile, err)
+	}
+	configMap := make(map[string]any)
+	if err = yaml.Unmarshal(contents, &configMap); err != nil {
+		return fmt.Errorf("failed to unmarshal configuration file %s: %v", configFile, err)
+	}
+	if err = utils.ConvertFromMap(configMap, s.config); err != nil {
+		return fmt.Errorf("failed to convert configuration file %s: %v", configFile, err)
+	}
+	if _, err = environment.NewVariables(s.lc).OverrideConfiguration(s.config); err != nil {
+		return fmt.Errorf("failed to override configuration with environment variables: %v", err)
+	}
+
+	report := provision.ValidateProvisioning(s.config.Device, s.serviceKey, s.lc)
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err = encoder.Encode(report); err != nil {
+		return fmt.Errorf("failed to write the provisioning validation report: %v", err)
+	}
+	if !report.Valid {
+		return fmt.Errorf("provisioning validation found %d issues", len(report.Issues))
+	}
+	return nil
+}